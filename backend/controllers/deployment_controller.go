@@ -1,15 +1,18 @@
 package controllers
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings" 
 	"sync"
-	"time"
 	"github.com/gin-gonic/gin"
 	"github.com/multi-cloud-landing-zone/backend/models"
 	"github.com/multi-cloud-landing-zone/backend/utils"
@@ -64,6 +67,28 @@ func StartDeployment(c *gin.Context) {
 	configJSON, _ := json.MarshalIndent(deploymentConfig, "", "  ")
 	utils.LogInfo(fmt.Sprintf("解析后的部署配置:\n%s", string(configJSON)))
 
+	// 渲染部署配置，标签校验失败或生成过程中发现错误时拒绝部署
+	deploymentID, err := newDeploymentID()
+	if err != nil {
+		utils.LogError(fmt.Sprintf("生成部署ID失败: %v", err))
+		c.JSON(500, gin.H{
+			"success": false,
+			"message": "生成部署ID失败: " + err.Error(),
+		})
+		return
+	}
+	deploymentConfig.DeploymentID = deploymentID
+	rendered := utils.RenderDeployment(deploymentConfig)
+	if utils.HasErrorFindings(rendered.Findings) {
+		utils.LogError(fmt.Sprintf("部署配置存在错误，已拒绝部署 ID: %s", deploymentID))
+		c.JSON(400, gin.H{
+			"success":  false,
+			"message":  "部署配置存在错误",
+			"findings": rendered.Findings,
+		})
+		return
+	}
+
 	// 更新部署状态
 	deploymentMutex.Lock()
 	deploymentStatus.Status = "preparing"
@@ -75,9 +100,8 @@ func StartDeployment(c *gin.Context) {
 	deploymentMutex.Unlock()
 
 	// 异步处理部署
-	deploymentID := fmt.Sprintf("%d", time.Now().Unix())
 	utils.LogInfo(fmt.Sprintf("开始异步处理部署，部署ID: %s", deploymentID))
-	go processDeploy(deploymentConfig, deploymentID)
+	go processDeploy(deploymentConfig, deploymentID, rendered)
 
 	// 立即返回响应，不等待部署完成
 	c.JSON(200, gin.H{
@@ -104,8 +128,170 @@ func GetDeploymentStatus(c *gin.Context) {
 	utils.LogInfo(fmt.Sprintf("已返回部署状态: %s, 进度: %d%%", status.Status, status.Progress))
 }
 
-// processDeploy 异步处理部署过程
-func processDeploy(config models.DeploymentConfig, deploymentID string) {
+// RenderDeployment 渲染部署配置并返回Terraform文件、预测的拓扑图和发现的问题，不会执行部署或写入部署目录
+// validate=true时在临时目录中运行terraform init -backend=false和terraform validate
+func RenderDeployment(c *gin.Context) {
+	utils.LogInfo("收到渲染预览请求")
+
+	var deploymentConfig models.DeploymentConfig
+	if err := c.ShouldBindJSON(&deploymentConfig); err != nil {
+		utils.LogError(fmt.Sprintf("解析部署配置失败: %v", err))
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "无效的部署配置: " + err.Error(),
+		})
+		return
+	}
+	if deploymentConfig.DeploymentID == "" {
+		deploymentConfig.DeploymentID = "preview"
+	}
+
+	rendered := utils.RenderDeployment(deploymentConfig)
+	if c.Query("validate") == "true" {
+		rendered.Findings = append(rendered.Findings, validateRenderedFiles(rendered.Files)...)
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"valid":   !utils.HasErrorFindings(rendered.Findings),
+		"data":    rendered,
+	})
+	utils.LogInfo(fmt.Sprintf("已返回渲染预览: 文件=%d, 问题=%d", len(rendered.Files), len(rendered.Findings)))
+}
+
+// validateRenderedFiles 将渲染结果写入临时目录并运行terraform validate，完成后删除临时目录
+func validateRenderedFiles(files map[string]string) []models.ValidationFinding {
+	if _, err := exec.LookPath("terraform"); err != nil {
+		utils.LogWarn("未找到Terraform CLI，跳过terraform validate")
+		return []models.ValidationFinding{{Severity: "warning", Source: "terraform", Message: "未找到Terraform CLI，未执行terraform validate"}}
+	}
+
+	tempDir, err := os.MkdirTemp("", "landing-zone-render-")
+	if err != nil {
+		utils.LogError(fmt.Sprintf("创建临时目录失败: %v", err))
+		return []models.ValidationFinding{{Severity: "error", Source: "terraform", Message: "创建临时目录失败: " + err.Error()}}
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := utils.SaveTerraformModule(files, tempDir); err != nil {
+		return []models.ValidationFinding{{Severity: "error", Source: "terraform", Message: "写入临时目录失败: " + err.Error()}}
+	}
+
+	cmd := exec.Command("terraform", "init", "-backend=false", "-input=false", "-no-color")
+	cmd.Dir = tempDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		utils.LogError(fmt.Sprintf("Terraform初始化失败: %v, 输出: %s", err, string(output)))
+		return []models.ValidationFinding{{Severity: "error", Source: "terraform", Message: fmt.Sprintf("terraform init失败: %s", strings.TrimSpace(string(output)))}}
+	}
+
+	// validate发现错误时退出码不为0，结果以JSON输出，只在无法解析时报告命令错误
+	cmd = exec.Command("terraform", "validate", "-json", "-no-color")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	var result struct {
+		Valid       bool `json:"valid"`
+		Diagnostics []struct {
+			Severity string `json:"severity"`
+			Summary  string `json:"summary"`
+			Detail   string `json:"detail"`
+			Range    *struct {
+				Filename string `json:"filename"`
+			} `json:"range"`
+		} `json:"diagnostics"`
+	}
+	if jsonErr := json.Unmarshal(output, &result); jsonErr != nil {
+		utils.LogError(fmt.Sprintf("Terraform配置验证失败: %v, 输出: %s", err, string(output)))
+		return []models.ValidationFinding{{Severity: "error", Source: "terraform", Message: fmt.Sprintf("terraform validate失败: %v", err)}}
+	}
+
+	var findings []models.ValidationFinding
+	for _, diagnostic := range result.Diagnostics {
+		finding := models.ValidationFinding{Severity: diagnostic.Severity, Source: "terraform", Message: diagnostic.Summary}
+		if diagnostic.Detail != "" {
+			finding.Message += ": " + diagnostic.Detail
+		}
+		if diagnostic.Range != nil {
+			finding.File = diagnostic.Range.Filename
+		}
+		findings = append(findings, finding)
+	}
+	utils.LogInfo(fmt.Sprintf("Terraform配置验证完成: valid=%t, 诊断=%d", result.Valid, len(findings)))
+	return findings
+}
+
+// deploymentIDRegexp 部署ID只能包含字母、数字、下划线和连字符，避免访问部署目录以外的路径
+var deploymentIDRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// newDeploymentID 生成随机的部署ID，部署ID用于下载配置包，不能被猜测
+func newDeploymentID() (string, error) {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buffer), nil
+}
+
+// GetDeploymentBundle 下载部署生成的Terraform配置包，部署ID是随机生成的，只有部署发起方知道
+// format可选zip（默认）或tar.gz；includeState=true时打包状态文件，需要在X-Admin-Token请求头中提供ADMIN_TOKEN
+func GetDeploymentBundle(c *gin.Context) {
+	deploymentID := c.Param("id")
+	utils.LogInfo(fmt.Sprintf("收到下载部署配置包请求，部署ID: %s", deploymentID))
+
+	if !deploymentIDRegexp.MatchString(deploymentID) {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "无效的部署ID: " + deploymentID,
+		})
+		return
+	}
+
+	options := utils.BundleOptions{
+		Format:       c.DefaultQuery("format", "zip"),
+		IncludeState: c.Query("includeState") == "true",
+	}
+	if options.IncludeState {
+		adminToken := os.Getenv("ADMIN_TOKEN")
+		if adminToken == "" || subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Admin-Token")), []byte(adminToken)) != 1 {
+			utils.LogWarn(fmt.Sprintf("非管理员请求下载部署 %s 的状态文件，已拒绝", deploymentID))
+			c.JSON(403, gin.H{
+				"success": false,
+				"message": "只有管理员可以下载状态文件",
+			})
+			return
+		}
+	}
+
+	workDir := filepath.Join("terraform", "deployments", deploymentID)
+	if _, err := os.Stat(workDir); err != nil {
+		utils.LogError(fmt.Sprintf("部署目录不存在: %s", workDir))
+		c.JSON(404, gin.H{
+			"success": false,
+			"message": "部署不存在: " + deploymentID,
+		})
+		return
+	}
+
+	bundle, fileName, err := utils.BuildTerraformBundle(workDir, deploymentID, options)
+	if err != nil {
+		utils.LogError(fmt.Sprintf("打包部署配置失败: %v", err))
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": "打包部署配置失败: " + err.Error(),
+		})
+		return
+	}
+
+	contentType := "application/zip"
+	if strings.HasSuffix(fileName, ".tar.gz") {
+		contentType = "application/gzip"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Data(200, contentType, bundle)
+	utils.LogInfo(fmt.Sprintf("已返回部署配置包: %s, 大小: %d字节", fileName, len(bundle)))
+}
+
+// processDeploy 异步处理部署过程，rendered是StartDeployment中渲染并校验过的Terraform模块
+func processDeploy(config models.DeploymentConfig, deploymentID string, rendered utils.RenderResult) {
 	utils.LogInfo(fmt.Sprintf("开始处理部署 ID: %s", deploymentID))
 	
 	defer func() {
@@ -142,20 +328,29 @@ func processDeploy(config models.DeploymentConfig, deploymentID string) {
 		deploymentStatus.Message = "正在生成Terraform配置..."
 		deploymentMutex.Unlock()
 
-		// 生成Terraform配置文件
-		terraformConfig := utils.GenerateTerraformConfig(config)
-		mainTfPath := filepath.Join(workDir, "main.tf")
+		// 渲染结果中包含错误时不能部署
+		for _, finding := range rendered.Findings {
+			if finding.Severity == "error" {
+				deploymentMutex.Lock()
+				deploymentStatus.Logs = append(deploymentStatus.Logs, fmt.Sprintf("错误: %s", finding.Message))
+				deploymentMutex.Unlock()
+			}
+		}
+		if utils.HasErrorFindings(rendered.Findings) {
+			return fmt.Errorf("部署配置存在错误，已停止部署")
+		}
+		terraformFiles := rendered.Files
 		
 		// 保存Terraform配置文件
-		if err := utils.SaveTerraformConfig(terraformConfig, mainTfPath); err != nil {
+		if err := utils.SaveTerraformModule(terraformFiles, workDir); err != nil {
 			utils.LogError(fmt.Sprintf("保存Terraform配置文件失败: %v", err))
 			return fmt.Errorf("保存Terraform配置文件失败: %w", err)
 		}
 
-		utils.LogInfo(fmt.Sprintf("Terraform配置文件已保存到: %s", mainTfPath))
+		utils.LogInfo(fmt.Sprintf("Terraform模块已保存到: %s，共 %d 个文件", workDir, len(terraformFiles)))
 		deploymentMutex.Lock()
 		deploymentStatus.Logs = append(deploymentStatus.Logs, "生成Terraform配置文件完成")
-		deploymentStatus.Logs = append(deploymentStatus.Logs, fmt.Sprintf("Terraform配置文件路径: %s", mainTfPath))
+		deploymentStatus.Logs = append(deploymentStatus.Logs, fmt.Sprintf("Terraform模块目录: %s", workDir))
 		deploymentStatus.Progress = 20
 		deploymentStatus.Message = "正在初始化Terraform..."
 		deploymentMutex.Unlock()
@@ -207,6 +402,9 @@ func processDeploy(config models.DeploymentConfig, deploymentID string) {
 		}
 
 		utils.LogInfo(fmt.Sprintf("Terraform执行计划生成完成，输出:\n%s", string(output)))
+		if err := utils.SavePlanSummary(string(output), workDir); err != nil {
+			utils.LogWarn(fmt.Sprintf("保存Terraform执行计划摘要失败: %v", err))
+		}
 		deploymentMutex.Lock()
 		deploymentStatus.Logs = append(deploymentStatus.Logs, "Terraform执行计划生成完成")
 		deploymentStatus.Logs = append(deploymentStatus.Logs, string(output))
@@ -234,7 +432,7 @@ func processDeploy(config models.DeploymentConfig, deploymentID string) {
 
 		// 生成拓扑图
 		utils.LogInfo("开始生成资源拓扑图")
-		topology := utils.GenerateTopology(config)
+		topology := rendered.Topology
 
 		// 完成部署
 		utils.LogInfo("部署完成，更新最终状态")
@@ -250,7 +448,20 @@ func processDeploy(config models.DeploymentConfig, deploymentID string) {
 			"vpc":            config.VPC,
 			"subnet":         config.Subnet,
 			"components":     config.Components,
-			"terraformPath":  mainTfPath,
+			"terraformPath":  workDir,
+		}
+		// 多云部署返回每个分段的提供商和区域
+		if len(config.Sections) > 0 {
+			var sections []map[string]interface{}
+			for _, section := range config.Sections {
+				sections = append(sections, map[string]interface{}{
+					"cloudProvider": section.CloudProvider,
+					"region":        section.Region,
+					"providerAlias": section.ProviderAlias,
+					"components":    section.Components,
+				})
+			}
+			deploymentStatus.Result.(map[string]interface{})["sections"] = sections
 		}
 		deploymentStatus.Topology = topology
 		deploymentMutex.Unlock()
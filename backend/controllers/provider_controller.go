@@ -3,6 +3,7 @@ package controllers
 import (
         "github.com/gin-gonic/gin"
         "github.com/multi-cloud-landing-zone/backend/models"
+        "github.com/multi-cloud-landing-zone/backend/utils"
 )
 
 // GetProviders 返回支持的云服务提供商列表
@@ -196,6 +197,921 @@ func GetComponents(c *gin.Context) {
                                         Placeholder:  "例如: Standard, IA, Archive",
                                         Description:  "存储类型决定数据的访问频率和成本",
                                 },
+                                {
+                                        Name:         "访问策略",
+                                        Key:          "policy_type",
+                                        Type:         "text",
+                                        DefaultValue: "private",
+                                        Placeholder:  "例如: private, public-read, custom",
+                                        Description:  "存储桶的访问策略，custom需要在组件配置中提供自定义策略",
+                                },
+                                {
+                                        Name:         "版本控制",
+                                        Key:          "versioning",
+                                        Type:         "boolean",
+                                        DefaultValue: "false",
+                                        Placeholder:  "",
+                                        Description:  "是否开启对象版本控制",
+                                },
+                                {
+                                        Name:         "服务端加密",
+                                        Key:          "encryption",
+                                        Type:         "text",
+                                        DefaultValue: "AES256",
+                                        Placeholder:  "例如: none, AES256, KMS",
+                                        Description:  "存储桶的服务端加密方式",
+                                },
+                        },
+                },
+                {
+                        Name:        "计算实例",
+                        Value:       "compute",
+                        Description: "跨云虚拟机实例，按操作系统和版本自动查找各云的公共镜像",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "实例名称",
+                                        Key:          "name",
+                                        Type:         "text",
+                                        DefaultValue: "compute",
+                                        Placeholder:  "请输入实例名称前缀",
+                                        Description:  "实例名称前缀，多个实例会追加序号",
+                                },
+                                {
+                                        Name:         "实例规格",
+                                        Key:          "instance_type",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: t3.micro, ecs.g6.large",
+                                        Description:  "实例规格，留空时使用各云的默认规格",
+                                },
+                                {
+                                        Name:         "实例数量",
+                                        Key:          "instance_count",
+                                        Type:         "number",
+                                        DefaultValue: "1",
+                                        Placeholder:  "请输入实例数量",
+                                        Description:  "实例会轮流放置到所选子网中",
+                                },
+                                {
+                                        Name:         "操作系统",
+                                        Key:          "os_family",
+                                        Type:         "text",
+                                        DefaultValue: "ubuntu",
+                                        Placeholder:  "例如: ubuntu, debian, centos, amazon-linux",
+                                        Description:  "用于查找公共镜像的操作系统类型",
+                                },
+                                {
+                                        Name:         "系统版本",
+                                        Key:          "os_version",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 22.04",
+                                        Description:  "操作系统版本，留空时使用该系统的默认版本",
+                                },
+                                {
+                                        Name:         "镜像ID",
+                                        Key:          "image_id",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "可选，指定后不再按操作系统查找镜像",
+                                        Description:  "直接指定的镜像ID",
+                                },
+                                {
+                                        Name:         "密钥对",
+                                        Key:          "key_name",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入已存在的密钥对名称",
+                                        Description:  "登录实例使用的密钥对",
+                                },
+                                {
+                                        Name:         "安全组",
+                                        Key:          "security_group_ids",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个安全组用逗号分隔",
+                                        Description:  "实例绑定的安全组，留空时创建仅允许出站的默认安全组",
+                                },
+                                {
+                                        Name:         "系统盘大小",
+                                        Key:          "system_disk_size",
+                                        Type:         "number",
+                                        DefaultValue: "40",
+                                        Placeholder:  "请输入系统盘大小(GB)",
+                                        Description:  "系统盘大小，单位为GB",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "实例放置的子网名称，留空时使用全部子网",
+                                },
+                                {
+                                        Name:         "安全组",
+                                        Key:          "security_groups",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个安全组用逗号分隔",
+                                        Description:  "引用安全组组件中定义的安全组名称，留空时自动创建默认安全组",
+                                },
+                        },
+                },
+                {
+                        Name:        "托管数据库",
+                        Value:       "database",
+                        Description: "跨云托管关系型数据库，密码由random_password自动生成并仅保存在Terraform状态中",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "实例名称",
+                                        Key:          "name",
+                                        Type:         "text",
+                                        DefaultValue: "landing-zone-db",
+                                        Placeholder:  "请输入数据库实例名称",
+                                        Description:  "数据库实例名称，只能包含小写字母、数字和连字符",
+                                },
+                                {
+                                        Name:         "数据库引擎",
+                                        Key:          "engine",
+                                        Type:         "text",
+                                        DefaultValue: "mysql",
+                                        Placeholder:  "例如: mysql, postgres, mariadb",
+                                        Description:  "数据库引擎，mariadb仅支持AWS",
+                                },
+                                {
+                                        Name:         "引擎版本",
+                                        Key:          "engine_version",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 8.0, 15",
+                                        Description:  "引擎版本，参数组族根据引擎和版本自动推导",
+                                },
+                                {
+                                        Name:         "实例规格",
+                                        Key:          "instance_class",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: db.t3.medium",
+                                        Description:  "实例规格，留空时使用各云的默认规格",
+                                },
+                                {
+                                        Name:         "存储大小",
+                                        Key:          "allocated_storage",
+                                        Type:         "number",
+                                        DefaultValue: "50",
+                                        Placeholder:  "请输入存储大小(GB)",
+                                        Description:  "数据库存储大小，单位为GB",
+                                },
+                                {
+                                        Name:         "数据库名称",
+                                        Key:          "db_name",
+                                        Type:         "text",
+                                        DefaultValue: "appdb",
+                                        Placeholder:  "请输入初始数据库名称",
+                                        Description:  "实例创建时初始化的数据库",
+                                },
+                                {
+                                        Name:         "管理员用户名",
+                                        Key:          "username",
+                                        Type:         "text",
+                                        DefaultValue: "dbadmin",
+                                        Placeholder:  "请输入管理员用户名",
+                                        Description:  "数据库管理员用户名",
+                                },
+                                {
+                                        Name:         "多可用区",
+                                        Key:          "multi_az",
+                                        Type:         "boolean",
+                                        DefaultValue: "true",
+                                        Placeholder:  "",
+                                        Description:  "是否部署为多可用区高可用实例，AWS和阿里云要求子网覆盖至少2个可用区",
+                                },
+                                {
+                                        Name:         "备可用区",
+                                        Key:          "secondary_zone",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: ap-guangzhou-4",
+                                        Description:  "备节点所在可用区，未指定时使用子网覆盖的第二个可用区",
+                                },
+                                {
+                                        Name:         "备份保留天数",
+                                        Key:          "backup_retention_days",
+                                        Type:         "number",
+                                        DefaultValue: "7",
+                                        Placeholder:  "请输入备份保留天数",
+                                        Description:  "自动备份的保留天数",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "数据库子网组使用的子网名称，留空时使用全部子网",
+                                },
+                                {
+                                        Name:         "安全组",
+                                        Key:          "security_groups",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个安全组用逗号分隔",
+                                        Description:  "引用安全组组件中定义的安全组名称，留空时自动创建默认安全组",
+                                },
+                        },
+                },
+                {
+                        Name:        "托管Kubernetes",
+                        Value:       "kubernetes",
+                        Description: "跨云托管Kubernetes集群及节点池，集群入口地址和CA证书作为敏感输出导出",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "集群名称",
+                                        Key:          "name",
+                                        Type:         "text",
+                                        DefaultValue: "landing-zone-k8s",
+                                        Placeholder:  "请输入集群名称",
+                                        Description:  "集群名称，只能包含小写字母、数字和连字符",
+                                },
+                                {
+                                        Name:         "Kubernetes版本",
+                                        Key:          "version",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 1.30",
+                                        Description:  "集群的Kubernetes版本，留空时使用各云的默认版本",
+                                },
+                                {
+                                        Name:         "节点池",
+                                        Key:          "node_pools",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: [{\"name\":\"general\",\"instanceType\":\"t3.large\",\"count\":2,\"minSize\":1,\"maxSize\":5}]",
+                                        Description:  "JSON格式的节点池列表，minSize与maxSize不同时开启自动伸缩；留空时创建一个1-3节点的默认节点池",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "集群和节点池使用的子网名称，必须属于同一个VPC，EKS要求覆盖至少2个可用区；留空时使用全部子网",
+                                },
+                                {
+                                        Name:         "公网访问",
+                                        Key:          "public_endpoint",
+                                        Type:         "boolean",
+                                        DefaultValue: "false",
+                                        Placeholder:  "",
+                                        Description:  "是否开放API Server的公网访问",
+                                },
+                                {
+                                        Name:         "Pod网段",
+                                        Key:          "pod_cidr",
+                                        Type:         "text",
+                                        DefaultValue: "172.20.0.0/16",
+                                        Placeholder:  "例如: 172.20.0.0/16",
+                                        Description:  "Pod使用的网段，不能与VPC网段重叠，AWS和Azure的Pod直接使用子网地址",
+                                },
+                                {
+                                        Name:         "Service网段",
+                                        Key:          "service_cidr",
+                                        Type:         "text",
+                                        DefaultValue: "172.21.0.0/20",
+                                        Placeholder:  "例如: 172.21.0.0/20",
+                                        Description:  "Service使用的网段，不能与VPC和Pod网段重叠",
+                                },
+                                {
+                                        Name:         "密钥对",
+                                        Key:          "key_name",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入已有的密钥对名称",
+                                        Description:  "节点登录使用的密钥对，留空时阿里云、华为云、腾讯云和火山引擎使用随机生成的密码",
+                                },
+                                {
+                                        Name:         "安全组",
+                                        Key:          "security_groups",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个安全组用逗号分隔",
+                                        Description:  "引用安全组组件中定义的安全组名称，留空时自动创建集群安全组",
+                                },
+                        },
+                },
+                {
+                        Name:        "堡垒机",
+                        Value:       "bastion",
+                        Description: "访问私有子网的堡垒机，入站只允许来自管理网段的SSH或RDP，Azure和阿里云使用托管堡垒机服务",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "堡垒机名称",
+                                        Key:          "name",
+                                        Type:         "text",
+                                        DefaultValue: "bastion",
+                                        Placeholder:  "请输入堡垒机名称",
+                                        Description:  "堡垒机名称，只能包含小写字母、数字和连字符",
+                                },
+                                {
+                                        Name:         "访问协议",
+                                        Key:          "protocol",
+                                        Type:         "text",
+                                        DefaultValue: "ssh",
+                                        Placeholder:  "例如: ssh, rdp",
+                                        Description:  "堡垒机入站只允许该协议，rdp在AWS以外的云需要通过镜像ID指定Windows镜像",
+                                },
+                                {
+                                        Name:         "管理网段",
+                                        Key:          "admin_cidrs",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个网段用逗号分隔，例如: 203.0.113.0/24",
+                                        Description:  "允许访问堡垒机的管理网段，不允许0.0.0.0/0",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnet",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入公有子网名称",
+                                        Description:  "堡垒机所在的公有子网，留空时使用第一个公有子网；Azure和阿里云通过该子网确定VPC",
+                                },
+                                {
+                                        Name:         "实例规格",
+                                        Key:          "instance_type",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: t3.micro",
+                                        Description:  "堡垒机实例规格，留空时使用各云的默认规格，托管堡垒机忽略该属性",
+                                },
+                                {
+                                        Name:         "操作系统",
+                                        Key:          "os_family",
+                                        Type:         "text",
+                                        DefaultValue: "ubuntu",
+                                        Placeholder:  "例如: ubuntu, debian, centos",
+                                        Description:  "SSH堡垒机的操作系统，实例通过cloud-init加固",
+                                },
+                                {
+                                        Name:         "镜像ID",
+                                        Key:          "image_id",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入镜像ID",
+                                        Description:  "指定镜像ID时不再按操作系统查找镜像",
+                                },
+                                {
+                                        Name:         "密钥对",
+                                        Key:          "key_name",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入已有的密钥对名称",
+                                        Description:  "SSH登录使用的密钥对，AWS未开启会话管理时必填",
+                                },
+                                {
+                                        Name:         "会话管理",
+                                        Key:          "session_manager",
+                                        Type:         "boolean",
+                                        DefaultValue: "false",
+                                        Placeholder:  "",
+                                        Description:  "是否附加AmazonSSMManagedInstanceCore实例角色，开启后可通过Session Manager登录，仅支持AWS",
+                                },
+                                {
+                                        Name:         "Azure Bastion子网网段",
+                                        Key:          "bastion_subnet_cidr",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 10.0.255.0/26",
+                                        Description:  "Azure在所选子网的VNet中创建AzureBastionSubnet使用的网段，不小于/26",
+                                },
+                        },
+                },
+                {
+                        Name:        "监控告警",
+                        Value:       "monitoring",
+                        Description: "为负载均衡5xx比例、数据库CPU和存储、NAT网关丢包创建指标告警，发送到SNS主题、Azure操作组、阿里云联系人组或腾讯云通知模板",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "告警名称前缀",
+                                        Key:          "name",
+                                        Type:         "text",
+                                        DefaultValue: "landing-zone",
+                                        Placeholder:  "请输入名称前缀",
+                                        Description:  "告警、通知目标等资源名称的前缀",
+                                },
+                                {
+                                        Name:         "告警邮箱",
+                                        Key:          "emails",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个邮箱用逗号分隔，例如: ops@example.com",
+                                        Description:  "接收告警的邮件地址，AWS订阅SNS主题，Azure加入操作组，阿里云创建报警联系人",
+                                },
+                                {
+                                        Name:         "Webhook地址",
+                                        Key:          "webhook_url",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: https://hooks.example.com/alarm",
+                                        Description:  "接收告警的HTTPS回调地址",
+                                },
+                                {
+                                        Name:         "腾讯云通知用户",
+                                        Key:          "notice_user_ids",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子用户UID用逗号分隔",
+                                        Description:  "腾讯云通知模板接收告警的子用户UID，仅支持腾讯云",
+                                },
+                                {
+                                        Name:         "负载均衡5xx阈值(%)",
+                                        Key:          "lb_5xx_threshold",
+                                        Type:         "number",
+                                        DefaultValue: "5",
+                                        Placeholder:  "例如: 5",
+                                        Description:  "负载均衡5xx响应占请求数的百分比超过该值时告警，仅AWS应用负载均衡",
+                                },
+                                {
+                                        Name:         "数据库CPU阈值(%)",
+                                        Key:          "db_cpu_threshold",
+                                        Type:         "number",
+                                        DefaultValue: "80",
+                                        Placeholder:  "例如: 80",
+                                        Description:  "数据库CPU使用率超过该值时告警",
+                                },
+                                {
+                                        Name:         "数据库存储阈值(%)",
+                                        Key:          "db_storage_threshold",
+                                        Type:         "number",
+                                        DefaultValue: "85",
+                                        Placeholder:  "例如: 85",
+                                        Description:  "数据库存储使用率超过该值时告警",
+                                },
+                                {
+                                        Name:         "NAT丢包阈值",
+                                        Key:          "nat_drop_threshold",
+                                        Type:         "number",
+                                        DefaultValue: "100",
+                                        Placeholder:  "例如: 100",
+                                        Description:  "NAT网关每个统计周期的丢包数超过该值时告警，需要开启路由表",
+                                },
+                                {
+                                        Name:         "统计周期(秒)",
+                                        Key:          "period",
+                                        Type:         "number",
+                                        DefaultValue: "300",
+                                        Placeholder:  "例如: 300",
+                                        Description:  "告警指标的统计周期，最小60秒",
+                                },
+                                {
+                                        Name:         "连续周期数",
+                                        Key:          "evaluation_periods",
+                                        Type:         "number",
+                                        DefaultValue: "3",
+                                        Placeholder:  "例如: 3",
+                                        Description:  "指标连续超过阈值的周期数达到该值时告警",
+                                },
+                        },
+                },
+                {
+                        Name:        "缓存",
+                        Value:       "cache",
+                        Description: "部署在私有子网中的托管Redis：ElastiCache、Azure Cache for Redis、阿里云Redis、DCS和TencentDB for Redis，访问密码由random_password生成",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "缓存名称",
+                                        Key:          "name",
+                                        Type:         "text",
+                                        DefaultValue: "landing-zone-redis",
+                                        Placeholder:  "请输入缓存名称",
+                                        Description:  "缓存实例名称，只能包含小写字母、数字和连字符",
+                                },
+                                {
+                                        Name:         "Redis版本",
+                                        Key:          "engine_version",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 7.0",
+                                        Description:  "留空时使用各云的默认版本，腾讯云支持4.0、5.0、6.2和7.0",
+                                },
+                                {
+                                        Name:         "实例规格",
+                                        Key:          "node_type",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: cache.t3.medium",
+                                        Description:  "留空时使用各云的默认规格，Azure为C1或P1等规格，华为云和腾讯云按容量选择规格",
+                                },
+                                {
+                                        Name:         "容量(GB)",
+                                        Key:          "capacity",
+                                        Type:         "number",
+                                        DefaultValue: "1",
+                                        Placeholder:  "例如: 1",
+                                        Description:  "缓存容量，华为云和腾讯云使用",
+                                },
+                                {
+                                        Name:         "多可用区",
+                                        Key:          "multi_az",
+                                        Type:         "boolean",
+                                        DefaultValue: "true",
+                                        Placeholder:  "",
+                                        Description:  "是否在备可用区部署副本",
+                                },
+                                {
+                                        Name:         "备可用区",
+                                        Key:          "secondary_zone",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入备可用区",
+                                        Description:  "副本所在的可用区，留空时使用第二个子网所在的可用区",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "缓存所在的私有子网，留空时使用全部私有子网，公有子网会被忽略",
+                                },
+                                {
+                                        Name:         "安全组",
+                                        Key:          "security_groups",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个安全组用逗号分隔",
+                                        Description:  "引用安全组组件中定义的安全组，留空时AWS创建只允许VPC内访问的安全组",
+                                },
+                        },
+                },
+                {
+                        Name:        "消息队列",
+                        Value:       "message-queue",
+                        Description: "部署在私有子网中的托管Kafka或RocketMQ：MSK、Event Hubs、阿里云消息队列、华为云DMS和腾讯云CKafka、RocketMQ，认证密码由random_password生成",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "消息队列名称",
+                                        Key:          "name",
+                                        Type:         "text",
+                                        DefaultValue: "landing-zone-mq",
+                                        Placeholder:  "请输入消息队列名称",
+                                        Description:  "消息队列实例名称，只能包含小写字母、数字和连字符",
+                                },
+                                {
+                                        Name:         "引擎",
+                                        Key:          "engine",
+                                        Type:         "text",
+                                        DefaultValue: "kafka",
+                                        Placeholder:  "例如: kafka, rocketmq",
+                                        Description:  "消息队列引擎，AWS和Azure只支持kafka",
+                                },
+                                {
+                                        Name:         "引擎版本",
+                                        Key:          "version",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 3.6.0",
+                                        Description:  "留空时使用各云的默认版本",
+                                },
+                                {
+                                        Name:         "实例规格",
+                                        Key:          "instance_type",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: kafka.m5.large",
+                                        Description:  "留空时使用各云的默认规格，Azure为Event Hubs层级Standard或Premium",
+                                },
+                                {
+                                        Name:         "存储容量(GB)",
+                                        Key:          "storage",
+                                        Type:         "number",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 300",
+                                        Description:  "每个节点的存储容量，留空时使用各云的默认容量",
+                                },
+                                {
+                                        Name:         "节点数量",
+                                        Key:          "broker_count",
+                                        Type:         "number",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 3",
+                                        Description:  "Kafka代理数量，留空时AWS每个子网一个、华为云3个；Azure为Event Hubs吞吐量单位，默认1",
+                                },
+                                {
+                                        Name:         "用户名",
+                                        Key:          "username",
+                                        Type:         "text",
+                                        DefaultValue: "mqadmin",
+                                        Placeholder:  "请输入用户名",
+                                        Description:  "SASL或ACL用户名，腾讯云RocketMQ为角色名称",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "消息队列所在的私有子网，留空时使用全部私有子网，AWS需要覆盖至少2个可用区",
+                                },
+                                {
+                                        Name:         "安全组",
+                                        Key:          "security_groups",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个安全组用逗号分隔",
+                                        Description:  "引用安全组组件中定义的安全组，留空时AWS和华为云创建只允许VPC内访问的安全组",
+                                },
+                        },
+                },
+                {
+                        Name:        "安全组",
+                        Value:       "security-group",
+                        Description: "按结构化规则定义的安全组，其他组件可通过安全组名称引用",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "安全组名称",
+                                        Key:          "group_name",
+                                        Type:         "text",
+                                        DefaultValue: "app-sg",
+                                        Placeholder:  "请输入安全组名称",
+                                        Description:  "安全组名称，其他组件通过该名称引用安全组",
+                                },
+                                {
+                                        Name:         "描述",
+                                        Key:          "description",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入安全组描述",
+                                        Description:  "安全组的描述信息",
+                                },
+                                {
+                                        Name:         "规则",
+                                        Key:          "rules",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: [{\"direction\":\"ingress\",\"protocol\":\"tcp\",\"fromPort\":443,\"cidrBlock\":\"10.0.0.0/8\"}]",
+                                        Description:  "JSON格式的规则列表，每条规则需指定direction、protocol、端口，以及cidrBlock或peerGroup之一；未定义出站规则时默认允许全部出站",
+                                },
+                        },
+                },
+                {
+                        Name:        "中转网关",
+                        Value:       "transit-gateway",
+                        Description: "连接同一部署中多个VPC的中转网关，阿里云为CEN转发路由器，腾讯云为云联网，华为云为企业路由器，Azure为虚拟WAN",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "名称",
+                                        Key:          "name",
+                                        Type:         "text",
+                                        DefaultValue: "transit-gateway",
+                                        Placeholder:  "请输入中转网关名称",
+                                        Description:  "中转网关名称",
+                                },
+                                {
+                                        Name:         "描述",
+                                        Key:          "description",
+                                        Type:         "text",
+                                        DefaultValue: "Transit Gateway for multi-cloud connectivity",
+                                        Placeholder:  "请输入描述",
+                                        Description:  "中转网关的描述信息",
+                                },
+                                {
+                                        Name:         "自动接受共享挂载",
+                                        Key:          "auto_accept_shared_attachments",
+                                        Type:         "text",
+                                        DefaultValue: "disable",
+                                        Placeholder:  "enable 或 disable",
+                                        Description:  "是否自动接受共享的挂载请求",
+                                },
+                                {
+                                        Name:         "DNS支持",
+                                        Key:          "dns_support",
+                                        Type:         "text",
+                                        DefaultValue: "enable",
+                                        Placeholder:  "enable 或 disable",
+                                        Description:  "是否启用DNS支持（AWS）",
+                                },
+                                {
+                                        Name:         "VPN ECMP支持",
+                                        Key:          "vpn_ecmp_support",
+                                        Type:         "text",
+                                        DefaultValue: "disable",
+                                        Placeholder:  "enable 或 disable",
+                                        Description:  "是否启用VPN等价多路径路由（AWS）",
+                                },
+                                {
+                                        Name:         "ASN",
+                                        Key:          "asn",
+                                        Type:         "number",
+                                        DefaultValue: "64512",
+                                        Placeholder:  "请输入BGP ASN",
+                                        Description:  "企业路由器的BGP自治系统号（华为云）",
+                                },
+                                {
+                                        Name:         "Hub地址前缀",
+                                        Key:          "hub_address_prefix",
+                                        Type:         "text",
+                                        DefaultValue: "10.255.0.0/23",
+                                        Placeholder:  "例如: 10.255.0.0/23",
+                                        Description:  "虚拟Hub使用的地址前缀（Azure）",
+                                },
+                                {
+                                        Name:         "挂载",
+                                        Key:          "tgwAttachments",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: [{\"name\":\"app\",\"vpcIndex\":0,\"routeTable\":\"prod\"}]",
+                                        Description:  "JSON格式的挂载列表，通过vpcIndex引用本部署中的VPC",
+                                },
+                        },
+                },
+                {
+                        Name:        "VPC对等连接",
+                        Value:       "vpc-peering",
+                        Description: "连接同一部署中的多个VPC，生成对等连接、接受操作和双向路由",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "VPC对",
+                                        Key:          "vpc_pairs",
+                                        Type:         "text",
+                                        DefaultValue: "0:1",
+                                        Placeholder:  "例如: 0:1,0:2",
+                                        Description:  "逗号分隔的VPC索引对，格式为 请求方索引:接受方索引",
+                                },
+                                {
+                                        Name:         "对等连接",
+                                        Key:          "peerings",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: [{\"name\":\"app-to-db\",\"requesterVpcIndex\":0,\"accepterVpcIndex\":1}]",
+                                        Description:  "JSON格式的对等连接列表，设置后忽略VPC对属性",
+                                },
+                        },
+                },
+                {
+                        Name:        "跨云VPN",
+                        Value:       "cross-cloud-vpn",
+                        Description: "通过站点到站点IPsec VPN连接本部署中的VPC和另一云提供商上的VPC，预共享密钥自动生成",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "名称",
+                                        Key:          "name",
+                                        Type:         "text",
+                                        DefaultValue: "cross-cloud-vpn",
+                                        Placeholder:  "请输入VPN名称",
+                                        Description:  "VPN连接名称",
+                                },
+                                {
+                                        Name:         "本端VPC索引",
+                                        Key:          "local_vpc_index",
+                                        Type:         "number",
+                                        DefaultValue: "0",
+                                        Placeholder:  "例如: 0",
+                                        Description:  "本部署中参与VPN的VPC索引",
+                                },
+                                {
+                                        Name:         "对端云提供商",
+                                        Key:          "remote_provider",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: alicloud",
+                                        Description:  "对端云提供商，支持aws、azure、alicloud、huawei、tencent，不能与本端相同",
+                                },
+                                {
+                                        Name:         "对端区域",
+                                        Key:          "remote_region",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: cn-hangzhou",
+                                        Description:  "对端VPC所在区域",
+                                },
+                                {
+                                        Name:         "对端VPC ID",
+                                        Key:          "remote_vpc_id",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: vpc-bp1xxxx",
+                                        Description:  "对端已有VPC的ID，Azure为VNet的完整资源ID",
+                                },
+                                {
+                                        Name:         "对端VPC网段",
+                                        Key:          "remote_vpc_cidr",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 172.16.0.0/16",
+                                        Description:  "对端VPC的网段，不能与本端重叠",
+                                },
+                                {
+                                        Name:         "对端子网ID",
+                                        Key:          "remote_subnet_id",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: vsw-bp1xxxx",
+                                        Description:  "对端VPN网关使用的子网，华为云必填",
+                                },
+                                {
+                                        Name:         "对端分段VPC",
+                                        Key:          "remote_vpc_name",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: main",
+                                        Description:  "多云部署中对端分段的VPC名称，设置后无需填写对端VPC ID和网段",
+                                },
+                                {
+                                        Name:         "VPN连接",
+                                        Key:          "vpnConnections",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "JSON格式的VPN连接列表",
+                                        Description:  "JSON格式的VPN连接列表，设置后忽略上面的单个连接属性",
+                                },
+                        },
+                },
+                {
+                        Name:        "私有DNS",
+                        Value:       "private-dns",
+                        Description: "创建私有DNS区域并关联到选定的VPC，可选地创建解析记录",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "区域名称",
+                                        Key:          "zone_name",
+                                        Type:         "text",
+                                        DefaultValue: "landing-zone.internal",
+                                        Placeholder:  "例如: corp.internal",
+                                        Description:  "私有DNS区域的域名",
+                                },
+                                {
+                                        Name:         "关联VPC",
+                                        Key:          "vpcs",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: main,shared 或 0,1",
+                                        Description:  "关联的VPC名称或索引，逗号分隔，留空时关联所有VPC",
+                                },
+                                {
+                                        Name:         "默认TTL",
+                                        Key:          "ttl",
+                                        Type:         "number",
+                                        DefaultValue: "300",
+                                        Placeholder:  "例如: 300",
+                                        Description:  "解析记录未指定TTL时使用的TTL（秒）",
+                                },
+                                {
+                                        Name:         "解析记录",
+                                        Key:          "records",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: [{\"name\":\"api\",\"type\":\"A\",\"values\":[\"10.0.1.10\"]}]",
+                                        Description:  "JSON数组，每条记录包含name、type（A、AAAA、CNAME、TXT）、ttl和values，name为 @ 表示区域顶点",
+                                },
+                        },
+                },
+                {
+                        Name:        "IAM基线",
+                        Value:       "iam-baseline",
+                        Description: "创建管理员、网络运维、只读和紧急访问等标准身份角色，并授予云提供商原生的系统策略和经过校验的自定义策略",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "身份角色",
+                                        Key:          "personas",
+                                        Type:         "text",
+                                        DefaultValue: "admin,network-ops,read-only,break-glass",
+                                        Placeholder:  "例如: admin,read-only",
+                                        Description:  "需要创建的身份角色，逗号分隔，支持admin、network-ops、read-only和break-glass",
+                                },
+                                {
+                                        Name:         "名称前缀",
+                                        Key:          "name_prefix",
+                                        Type:         "text",
+                                        DefaultValue: "landing-zone",
+                                        Placeholder:  "例如: landing-zone",
+                                        Description:  "角色、用户组和策略名称的前缀",
+                                },
+                                {
+                                        Name:         "受信任账号",
+                                        Key:          "trusted_account_id",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: 123456789012",
+                                        Description:  "允许扮演角色的账号ID，留空时为当前账号，仅AWS、阿里云和腾讯云使用",
+                                },
+                                {
+                                        Name:         "要求MFA",
+                                        Key:          "require_mfa",
+                                        Type:         "boolean",
+                                        DefaultValue: "true",
+                                        Placeholder:  "",
+                                        Description:  "扮演AWS角色时要求多因素认证，紧急访问角色始终要求MFA",
+                                },
+                                {
+                                        Name:         "自定义策略",
+                                        Key:          "custom_policies",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "例如: [{\"name\":\"dns-ops\",\"persona\":\"network-ops\",\"document\":\"{...}\"}]",
+                                        Description:  "JSON数组，每个策略包含name、persona和document，document为云提供商原生格式的策略文档，渲染前会进行校验",
+                                },
                         },
                 },
         }
@@ -223,9 +1139,9 @@ func GetComponents(c *gin.Context) {
                                         Name:         "运行时",
                                         Key:          "runtime",
                                         Type:         "text",
-                                        DefaultValue: "nodejs14.x",
-                                        Placeholder:  "例如: nodejs14.x, python3.9",
-                                        Description:  "Lambda函数的运行时环境",
+                                        DefaultValue: "nodejs18.x",
+                                        Placeholder:  "例如: nodejs18.x, python3.9",
+                                        Description:  "Lambda函数的运行时环境，支持Node.js和Python",
                                 },
                                 {
                                         Name:         "内存大小",
@@ -235,6 +1151,38 @@ func GetComponents(c *gin.Context) {
                                         Placeholder:  "请输入内存大小(MB)",
                                         Description:  "Lambda函数的内存大小，单位为MB",
                                 },
+                                {
+                                        Name:         "超时时间",
+                                        Key:          "timeout",
+                                        Type:         "number",
+                                        DefaultValue: "30",
+                                        Placeholder:  "请输入超时时间(秒)",
+                                        Description:  "Lambda函数的执行超时时间，单位为秒",
+                                },
+                                {
+                                        Name:         "日志保留天数",
+                                        Key:          "log_retention_days",
+                                        Type:         "number",
+                                        DefaultValue: "14",
+                                        Placeholder:  "请输入日志保留天数",
+                                        Description:  "函数日志组的保留天数",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "函数接入VPC使用的子网名称，留空时使用全部子网",
+                                },
+                                {
+                                        Name:         "安全组",
+                                        Key:          "security_groups",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个安全组用逗号分隔",
+                                        Description:  "引用安全组组件中定义的安全组名称，留空时自动创建默认安全组",
+                                },
                         },
                 })
         } else if providerParam == "azure" {
@@ -255,14 +1203,225 @@ func GetComponents(c *gin.Context) {
                                         Name:         "运行时",
                                         Key:          "runtime",
                                         Type:         "text",
-                                        DefaultValue: "node",
-                                        Placeholder:  "例如: node, python, dotnet",
-                                        Description:  "Azure Functions的运行时环境",
+                                        DefaultValue: "node18",
+                                        Placeholder:  "例如: node18, python3.11",
+                                        Description:  "Azure Functions的运行时环境，支持Node.js和Python",
+                                },
+                                {
+                                        Name:         "内存大小",
+                                        Key:          "memory_size",
+                                        Type:         "number",
+                                        DefaultValue: "128",
+                                        Placeholder:  "请输入内存大小(MB)",
+                                        Description:  "Azure Functions的内存大小，单位为MB",
+                                },
+                                {
+                                        Name:         "超时时间",
+                                        Key:          "timeout",
+                                        Type:         "number",
+                                        DefaultValue: "30",
+                                        Placeholder:  "请输入超时时间(秒)",
+                                        Description:  "Azure Functions的执行超时时间，单位为秒",
+                                },
+                                {
+                                        Name:         "日志保留天数",
+                                        Key:          "log_retention_days",
+                                        Type:         "number",
+                                        DefaultValue: "14",
+                                        Placeholder:  "请输入日志保留天数",
+                                        Description:  "函数日志组的保留天数",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "函数接入VPC使用的子网名称，留空时使用全部子网",
+                                },
+                        },
+                })
+        } else if providerParam == "alicloud" {
+                components = append(components, models.Component{
+                        Name:        "函数计算",
+                        Value:       "function-compute",
+                        Description: "阿里云函数计算是事件驱动的全托管无服务器计算服务",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "函数名称",
+                                        Key:          "function_name",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入函数名称",
+                                        Description:  "函数计算的名称",
+                                },
+                                {
+                                        Name:         "运行时",
+                                        Key:          "runtime",
+                                        Type:         "text",
+                                        DefaultValue: "nodejs18",
+                                        Placeholder:  "例如: nodejs18, python3.10",
+                                        Description:  "函数计算的运行时环境，支持Node.js和Python",
+                                },
+                                {
+                                        Name:         "内存大小",
+                                        Key:          "memory_size",
+                                        Type:         "number",
+                                        DefaultValue: "128",
+                                        Placeholder:  "请输入内存大小(MB)",
+                                        Description:  "函数计算的内存大小，单位为MB",
+                                },
+                                {
+                                        Name:         "超时时间",
+                                        Key:          "timeout",
+                                        Type:         "number",
+                                        DefaultValue: "30",
+                                        Placeholder:  "请输入超时时间(秒)",
+                                        Description:  "函数计算的执行超时时间，单位为秒",
+                                },
+                                {
+                                        Name:         "日志保留天数",
+                                        Key:          "log_retention_days",
+                                        Type:         "number",
+                                        DefaultValue: "14",
+                                        Placeholder:  "请输入日志保留天数",
+                                        Description:  "函数日志组的保留天数",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "函数接入VPC使用的子网名称，留空时使用全部子网",
+                                },
+                                {
+                                        Name:         "安全组",
+                                        Key:          "security_groups",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个安全组用逗号分隔",
+                                        Description:  "引用安全组组件中定义的安全组名称，留空时自动创建默认安全组",
+                                },
+                        },
+                })
+        } else if providerParam == "huawei" {
+                components = append(components, models.Component{
+                        Name:        "FunctionGraph",
+                        Value:       "functiongraph",
+                        Description: "华为云FunctionGraph是事件驱动的无服务器计算服务",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "函数名称",
+                                        Key:          "function_name",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入函数名称",
+                                        Description:  "FunctionGraph函数的名称",
+                                },
+                                {
+                                        Name:         "运行时",
+                                        Key:          "runtime",
+                                        Type:         "text",
+                                        DefaultValue: "nodejs18",
+                                        Placeholder:  "例如: nodejs18, python3.9",
+                                        Description:  "FunctionGraph函数的运行时环境，支持Node.js和Python",
+                                },
+                                {
+                                        Name:         "内存大小",
+                                        Key:          "memory_size",
+                                        Type:         "number",
+                                        DefaultValue: "128",
+                                        Placeholder:  "请输入内存大小(MB)",
+                                        Description:  "FunctionGraph函数的内存大小，单位为MB",
+                                },
+                                {
+                                        Name:         "超时时间",
+                                        Key:          "timeout",
+                                        Type:         "number",
+                                        DefaultValue: "30",
+                                        Placeholder:  "请输入超时时间(秒)",
+                                        Description:  "FunctionGraph函数的执行超时时间，单位为秒",
+                                },
+                                {
+                                        Name:         "日志保留天数",
+                                        Key:          "log_retention_days",
+                                        Type:         "number",
+                                        DefaultValue: "14",
+                                        Placeholder:  "请输入日志保留天数",
+                                        Description:  "函数日志组的保留天数",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "函数接入VPC使用的子网名称，留空时使用全部子网",
+                                },
+                        },
+                })
+        } else if providerParam == "tencent" {
+                components = append(components, models.Component{
+                        Name:        "云函数SCF",
+                        Value:       "scf",
+                        Description: "腾讯云云函数SCF是无服务器执行环境，无需购买和管理服务器即可运行代码",
+                        Properties: []models.ComponentProperty{
+                                {
+                                        Name:         "函数名称",
+                                        Key:          "function_name",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "请输入函数名称",
+                                        Description:  "云函数的名称",
+                                },
+                                {
+                                        Name:         "运行时",
+                                        Key:          "runtime",
+                                        Type:         "text",
+                                        DefaultValue: "nodejs18",
+                                        Placeholder:  "例如: nodejs18, python3.9",
+                                        Description:  "云函数的运行时环境，支持Node.js和Python",
+                                },
+                                {
+                                        Name:         "内存大小",
+                                        Key:          "memory_size",
+                                        Type:         "number",
+                                        DefaultValue: "128",
+                                        Placeholder:  "请输入内存大小(MB)",
+                                        Description:  "云函数的内存大小，单位为MB",
+                                },
+                                {
+                                        Name:         "超时时间",
+                                        Key:          "timeout",
+                                        Type:         "number",
+                                        DefaultValue: "30",
+                                        Placeholder:  "请输入超时时间(秒)",
+                                        Description:  "云函数的执行超时时间，单位为秒",
+                                },
+                                {
+                                        Name:         "日志保留天数",
+                                        Key:          "log_retention_days",
+                                        Type:         "number",
+                                        DefaultValue: "14",
+                                        Placeholder:  "请输入日志保留天数",
+                                        Description:  "函数日志组的保留天数",
+                                },
+                                {
+                                        Name:         "子网",
+                                        Key:          "subnets",
+                                        Type:         "text",
+                                        DefaultValue: "",
+                                        Placeholder:  "多个子网用逗号分隔",
+                                        Description:  "函数接入VPC使用的子网名称，留空时使用全部子网",
                                 },
                         },
                 })
         }
 
+        // 平台团队在模块目录中维护的Terraform模块组件
+        components = append(components, utils.ModuleCatalogComponents(providerParam)...)
+
         c.JSON(200, gin.H{
                 "success": true,
                 "data":    components,
@@ -22,3 +22,13 @@ func (h *ControllerDeploymentHandler) StartDeployment(c *gin.Context) {
 func (h *ControllerDeploymentHandler) GetDeploymentStatus(c *gin.Context) {
 	controllers.GetDeploymentStatus(c)
 }
+
+// GetDeploymentBundle 下载部署生成的Terraform配置包
+func (h *ControllerDeploymentHandler) GetDeploymentBundle(c *gin.Context) {
+	controllers.GetDeploymentBundle(c)
+}
+
+// RenderDeployment 渲染部署配置预览
+func (h *ControllerDeploymentHandler) RenderDeployment(c *gin.Context) {
+	controllers.RenderDeployment(c)
+}
@@ -16,4 +16,6 @@ type ProviderHandler interface {
 type DeploymentHandler interface {
 	StartDeployment(c *gin.Context)
 	GetDeploymentStatus(c *gin.Context)
+	GetDeploymentBundle(c *gin.Context)
+	RenderDeployment(c *gin.Context)
 }
@@ -5,6 +5,7 @@ import (
         "io"
         "os"
         "path/filepath"
+        "strings"
         "time"
 
         "github.com/gin-gonic/gin"
@@ -254,8 +255,8 @@ func main() {
         router.Use(cors.New(cors.Config{
                 AllowOrigins:     []string{"http://localhost", "http://localhost:8080", "http://127.0.0.1", "http://127.0.0.1:8080"},
                 AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-                AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"},
-                ExposeHeaders:    []string{"Content-Length"},
+                AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "X-Admin-Token"},
+                ExposeHeaders:    []string{"Content-Length", "Content-Disposition"},
                 AllowCredentials: true,
                 MaxAge:           12 * time.Hour,
         }))
@@ -279,7 +280,7 @@ func main() {
             c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
             c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
             c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, HEAD")
-            c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID")
+            c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID, X-Admin-Token")
             c.Writer.Header().Set("Access-Control-Max-Age", "86400") // 24小时内不再发送预检请求
             
             // 对于OPTIONS请求，立即返回200
@@ -309,6 +310,26 @@ func main() {
         Logger.Info("CORS配置完成 - 使用增强的自定义中间件，支持动态Origin和凭证")
         utils.LogInfo("CORS配置完成 - 使用增强的自定义中间件，支持动态Origin和凭证")
 
+        // 加载模块目录，目录中的Terraform模块作为组件提供给前端
+        catalogPath := os.Getenv("MODULE_CATALOG_PATH")
+        if catalogPath == "" {
+                catalogPath = utils.DefaultModuleCatalogPath
+        }
+        if err := utils.LoadModuleCatalog(catalogPath); err != nil {
+                if os.IsNotExist(err) {
+                        utils.LogInfo(fmt.Sprintf("未找到模块目录文件 %s，不加载模块组件", catalogPath))
+                } else {
+                        Logger.Errorf("加载模块目录失败: %v", err)
+                        utils.LogError(fmt.Sprintf("加载模块目录失败: %v", err))
+                }
+        }
+
+        // 部署时必须提供的标签键，多个键用逗号分隔，未设置时不要求
+        if keys := os.Getenv("REQUIRED_TAG_KEYS"); keys != "" {
+                utils.SetRequiredTagKeys(strings.Split(keys, ","))
+                utils.LogInfo(fmt.Sprintf("部署时必须提供的标签: %s", keys))
+        }
+
         // 设置API路由
         routes.SetupRoutes(router)
         Logger.Info("API路由设置完成")
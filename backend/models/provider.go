@@ -26,6 +26,9 @@ type VPC struct {
 	CIDR              string `json:"cidr"`
 	EnableDnsSupport  bool   `json:"enableDnsSupport,omitempty"`
 	EnableDnsHostnames bool  `json:"enableDnsHostnames,omitempty"`
+	EnableIpv6        bool   `json:"enableIpv6,omitempty"` // 申请云提供商分配的IPv6网段，开启双栈
+	DisplayName       string `json:"displayName,omitempty"` // 云上显示名称，为空时按命名规则生成
+	LogicalID         string `json:"-"`                     // 用户填写的原始名称，Name会被转换为合法的Terraform标识符
 }
 
 // Subnet 表示子网配置
@@ -35,6 +38,10 @@ type Subnet struct {
 	MapPublicIpOnLaunch bool   `json:"mapPublicIpOnLaunch,omitempty"`
 	VpcIndex            int    `json:"vpcIndex"`
 	AZ                  string `json:"az,omitempty"`
+	Tier                string `json:"tier,omitempty"` // public, private；未指定时根据MapPublicIpOnLaunch判断
+	EnableIpv6          bool   `json:"enableIpv6,omitempty"` // 从所属VPC的IPv6网段中划分/64，所属VPC必须开启IPv6
+	DisplayName         string `json:"displayName,omitempty"` // 云上显示名称，为空时按命名规则生成
+	LogicalID           string `json:"-"`                     // 用户填写的原始名称，Name会被转换为合法的Terraform标识符
 }
 
 // BucketLifecycleRule 表示存储桶生命周期规则
@@ -45,14 +52,101 @@ type BucketLifecycleRule struct {
 	TransitionDays int    `json:"transitionDays"`
 }
 
+// StorageBucket 表示对象存储桶配置
+type StorageBucket struct {
+	BucketName           string              `json:"bucketName"`
+	StorageClass         string              `json:"storageClass,omitempty"` // Standard, IA, Archive
+	PolicyType           string              `json:"policyType"`             // private, public-read, custom
+	CustomPolicy         string              `json:"customPolicy,omitempty"`
+	EnableVersioning     bool                `json:"enableVersioning,omitempty"`
+	Encryption           string              `json:"encryption,omitempty"` // none, AES256, KMS
+	KmsKeyId             string              `json:"kmsKeyId,omitempty"`
+	EnableLifecycleRules bool                `json:"enableLifecycleRules,omitempty"`
+	LifecycleRule        BucketLifecycleRule `json:"lifecycleRule"`
+}
+
+// SecurityGroupRule 表示安全组规则
+type SecurityGroupRule struct {
+	Direction   string `json:"direction"` // ingress, egress
+	Protocol    string `json:"protocol"`  // tcp, udp, icmp, all
+	FromPort    int    `json:"fromPort,omitempty"`
+	ToPort      int    `json:"toPort,omitempty"`
+	CidrBlock   string `json:"cidrBlock,omitempty"`
+	PeerGroup   string `json:"peerGroup,omitempty"` // 对端安全组名称，与CidrBlock二选一
+	Description string `json:"description,omitempty"`
+}
+
+// SecurityGroup 表示安全组配置
+type SecurityGroup struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	VpcIndex    int                 `json:"vpcIndex"`
+	Rules       []SecurityGroupRule `json:"rules"`
+	DisplayName string              `json:"displayName,omitempty"` // 云上显示名称，为空时按命名规则生成
+	LogicalID   string              `json:"-"`                     // 用户填写的原始名称，Name会被转换为合法的Terraform标识符
+}
+
+// VpcPeering 表示同一部署中两个VPC之间的对等连接，通过索引引用VPC
+type VpcPeering struct {
+	Name              string `json:"name"`
+	RequesterVpcIndex int    `json:"requesterVpcIndex"`
+	AccepterVpcIndex  int    `json:"accepterVpcIndex"`
+}
+
+// CrossCloudVpn 表示本部署中的VPC与另一云提供商上VPC之间的站点到站点VPN
+// 对端VPC可以是另一云上已有的VPC，也可以是多云部署中其他分段的VPC
+type CrossCloudVpn struct {
+	Name           string `json:"name"`
+	LocalVpcIndex  int    `json:"localVpcIndex"`
+	RemoteProvider string `json:"remoteProvider"`
+	RemoteRegion   string `json:"remoteRegion"`
+	RemoteVpcId    string `json:"remoteVpcId"`              // 对端VPC的ID，Azure为VNet的资源ID
+	RemoteVpcCidr  string `json:"remoteVpcCidr"`            // 对端VPC的网段
+	RemoteSubnetId string `json:"remoteSubnetId,omitempty"` // 对端VPN网关使用的子网，华为云必填
+	RemoteVpcName  string `json:"remoteVpcName,omitempty"`  // 多云部署中对端分段的VPC名称，设置后直接引用该VPC资源
+}
+
+// DnsRecord 表示私有DNS区域中的解析记录
+// Name为相对于区域的主机记录，使用 @ 表示区域顶点
+type DnsRecord struct {
+	Name   string   `json:"name"`
+	Type   string   `json:"type"` // A, AAAA, CNAME, TXT
+	TTL    int      `json:"ttl,omitempty"`
+	Values []string `json:"values"`
+}
+
+// TransitGatewayRoute 表示中转网关路由表中的静态路由
+type TransitGatewayRoute struct {
+	DestinationCidr string `json:"destinationCidr"`
+	Attachment      string `json:"attachment,omitempty"` // 下一跳挂载名称
+	Blackhole       bool   `json:"blackhole,omitempty"`
+}
+
+// TransitGatewayRouteTable 表示中转网关路由表
+type TransitGatewayRouteTable struct {
+	Name   string                `json:"name"`
+	Routes []TransitGatewayRoute `json:"routes,omitempty"`
+}
+
+// TransitGatewayAttachment 表示中转网关的VPC挂载，通过索引引用同一部署中的VPC
+type TransitGatewayAttachment struct {
+	Name         string   `json:"name"`
+	VpcIndex     int      `json:"vpcIndex"`
+	SubnetNames  []string `json:"subnetNames,omitempty"`  // 为空时使用该VPC下的子网，每个可用区一个
+	RouteTable   string   `json:"routeTable,omitempty"`   // 关联的路由表名称
+	Propagations []string `json:"propagations,omitempty"` // 传播到的路由表名称
+}
+
 // TransitGatewayConfig 表示Transit Gateway配置
 type TransitGatewayConfig struct {
-	RouteTableName    string `json:"routeTableName"`
-	DefaultRouteTable bool   `json:"defaultRouteTable"`
-	SubnetIds         string `json:"subnetIds"`
-	DnsSupport        bool   `json:"dnsSupport"`
-	Ipv6Support       bool   `json:"ipv6Support"`
-	AttachmentName    string `json:"attachmentName"`
+	RouteTableName    string                     `json:"routeTableName"`
+	DefaultRouteTable bool                       `json:"defaultRouteTable"`
+	SubnetIds         string                     `json:"subnetIds"`
+	DnsSupport        bool                       `json:"dnsSupport"`
+	Ipv6Support       bool                       `json:"ipv6Support"`
+	AttachmentName    string                     `json:"attachmentName"`
+	RouteTables       []TransitGatewayRouteTable `json:"routeTables"`
+	Attachments       []TransitGatewayAttachment `json:"attachments"`
 }
 
 // ComponentConfig 表示云组件配置
@@ -62,12 +156,131 @@ type ComponentConfig struct {
 	CustomBucketPolicy   string              `json:"customBucketPolicy"`
 	EnableLifecycleRules bool                `json:"enableLifecycleRules"`
 	LifecycleRule        BucketLifecycleRule `json:"lifecycleRule"`
+	StorageClass         string              `json:"storageClass"`
+	EnableVersioning     bool                `json:"enableVersioning"`
+	BucketEncryption     string              `json:"bucketEncryption"`
+	StorageBuckets       []StorageBucket     `json:"storageBuckets"`
+	SecurityGroups       []SecurityGroup     `json:"securityGroups"`
 	EnableRouteTables    bool                `json:"enableRouteTables"`
+	NatGatewayMode       string              `json:"natGatewayMode"` // single, per-az
+	VpcPeerings          []VpcPeering        `json:"vpcPeerings"`
+	CrossCloudVpns       []CrossCloudVpn     `json:"crossCloudVpns"`
 	EnableVpcAttachment  bool                `json:"enableVpcAttachment"`
 	TransitGatewayConfig TransitGatewayConfig `json:"transitGatewayConfig"`
 	TransitGatewayName   string              `json:"transitGatewayName"`
 }
 
+// IamPolicy 表示附加到IAM角色（用户组）的自定义策略
+// Document为云提供商原生格式的JSON策略文档，Azure为包含actions和notActions的角色权限
+type IamPolicy struct {
+	Name     string `json:"name"`
+	Persona  string `json:"persona"`
+	Document string `json:"document"`
+}
+
+// NamingConvention 表示资源显示名称的命名规则
+// Pattern支持 {env}、{region}、{provider}、{type}、{name}、{az} 占位符，例如 {env}-{region}-{type}-{name}
+type NamingConvention struct {
+	Pattern     string `json:"pattern,omitempty"`     // 为空时直接使用用户填写的名称
+	Environment string `json:"environment,omitempty"` // {env}的值，为空时使用environment标签
+}
+
+// SecurityBaseline 表示着陆区安全基线：操作审计、配置记录和VPC流日志
+// 所有日志投递到同一部署中创建的专用加密日志存储桶
+type SecurityBaseline struct {
+	Enabled          bool   `json:"enabled"`
+	LogBucketName    string `json:"logBucketName,omitempty"`    // 为空时按第一个VPC名称生成
+	LogRetentionDays int    `json:"logRetentionDays,omitempty"` // 日志保留天数，默认365天
+}
+
+// OrganizationConfig 表示组织结构和成员账号（订阅）开通
+// AWS为Organizations组织单元和SCP，Azure为管理组和Azure Policy分配，阿里云为资源目录文件夹和管控策略
+type OrganizationConfig struct {
+	Enabled            bool               `json:"enabled"`
+	CreateOrganization bool               `json:"createOrganization,omitempty"` // 管理账号尚未启用组织（资源目录）时创建
+	BillingScope       string             `json:"billingScope,omitempty"`       // Azure创建订阅使用的计费范围ID
+	AllowedRegions     []string           `json:"allowedRegions,omitempty"`     // allowed-regions防护策略允许的区域，默认为部署区域
+	Units              []OrganizationUnit `json:"units"`
+	Accounts           []VendedAccount    `json:"accounts"`
+}
+
+// OrganizationUnit 表示组织单元（管理组、资源目录文件夹）及其防护策略
+type OrganizationUnit struct {
+	Name       string            `json:"name"`
+	Parent     string            `json:"parent,omitempty"`     // 父组织单元名称，为空时位于根节点下
+	Guardrails []string          `json:"guardrails,omitempty"` // 内置防护策略，如 deny-leave-organization、allowed-regions
+	Policies   []GuardrailPolicy `json:"policies,omitempty"`
+}
+
+// GuardrailPolicy 表示自定义防护策略，Document为云提供商原生格式的策略文档
+// AWS为SCP，阿里云为管控策略，Azure为Azure Policy的策略规则
+type GuardrailPolicy struct {
+	Name     string `json:"name"`
+	Document string `json:"document"`
+}
+
+// VendedAccount 表示在组织单元中开通的成员账号（订阅）
+type VendedAccount struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"` // AWS账号的根用户邮箱
+	Unit  string `json:"unit,omitempty"`  // 所属组织单元名称，为空时位于根节点下
+}
+
+// TargetAccount 表示部署的目标账号（订阅），provider通过扮演角色访问该账号
+type TargetAccount struct {
+	AccountId   string `json:"accountId"`             // Azure为订阅ID
+	RoleName    string `json:"roleName,omitempty"`    // 为空时使用账号开通时创建的默认访问角色
+	SessionName string `json:"sessionName,omitempty"`
+	DomainName  string `json:"domainName,omitempty"` // 华为云目标账号的账号名，委托通过账号名指定
+}
+
+// ComponentProperty 表示组件在界面上可配置的属性
+type ComponentProperty struct {
+	Name         string `json:"name"`
+	Key          string `json:"key"`
+	Type         string `json:"type"` // text, number, boolean
+	DefaultValue string `json:"defaultValue"`
+	Placeholder  string `json:"placeholder"`
+	Description  string `json:"description"`
+}
+
+// Component 表示可部署的组件及其属性
+type Component struct {
+	Name        string              `json:"name"`
+	Value       string              `json:"value"`
+	Description string              `json:"description"`
+	Properties  []ComponentProperty `json:"properties"`
+}
+
+// ModuleVariable 表示模块组件声明的输入变量
+type ModuleVariable struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"` // string, number, bool, list, map
+	Description string      `json:"description"`
+	Default     interface{} `json:"default,omitempty"`
+	Required    bool        `json:"required"`
+	Wiring      string      `json:"wiring,omitempty"` // 由部署自动填充：vpc_id, vpc_cidr, subnet_id, subnet_ids, availability_zones, region, tags
+}
+
+// ModuleOutput 表示模块组件导出的输出
+type ModuleOutput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Sensitive   bool   `json:"sensitive"`
+}
+
+// ModuleComponent 表示由Terraform模块实现的目录组件，由平台团队在模块目录文件中维护
+type ModuleComponent struct {
+	Name        string           `json:"name"`
+	Value       string           `json:"value"`
+	Description string           `json:"description"`
+	Providers   []string         `json:"providers"` // 适用的云提供商，为空时适用于所有云提供商
+	Source      string           `json:"source"`    // 本地路径、git地址或模块仓库地址
+	Version     string           `json:"version"`
+	Variables   []ModuleVariable `json:"variables"`
+	Outputs     []ModuleOutput   `json:"outputs"`
+}
+
 // DeploymentConfig 表示部署配置
 type DeploymentConfig struct {
 	CloudProvider       string                      `json:"cloudProvider"`
@@ -80,6 +293,17 @@ type DeploymentConfig struct {
 	Components          []string                    `json:"components"`
 	ComponentProperties map[string]interface{}      `json:"componentProperties"`
 	ComponentConfig     ComponentConfig             `json:"componentConfig"`
+	SecurityBaseline    SecurityBaseline            `json:"securityBaseline"`
+	Organization        OrganizationConfig          `json:"organization"`
+	TargetAccount       TargetAccount               `json:"targetAccount"`
+	Tags                map[string]string           `json:"tags,omitempty"`         // 统一标签，合并到所有支持标签的资源中
+	Naming              NamingConvention            `json:"naming"`
+	DeploymentID        string                      `json:"deploymentId,omitempty"` // 部署ID，由服务端写入deployment-id标签
+
+	// 多云部署：Sections不为空时，每个分段是一个提供商/区域，生成到同一个Terraform根模块中
+	Sections      []DeploymentConfig `json:"sections,omitempty"`
+	ProviderAlias string             `json:"providerAlias,omitempty"` // 分段的provider别名，为空时按提供商和区域生成
+	Peers         []DeploymentConfig `json:"-"`                       // 同一部署文档中的所有分段，供跨云引用使用
 }
 
 // DeploymentStatus 表示部署状态
@@ -92,6 +316,24 @@ type DeploymentStatus struct {
 	Topology interface{} `json:"topology"`
 }
 
+// KubernetesNodePool 表示托管Kubernetes集群的节点池
+// MinSize和MaxSize不同时开启自动伸缩，Count为初始（期望）节点数
+type KubernetesNodePool struct {
+	Name         string `json:"name"`
+	InstanceType string `json:"instanceType,omitempty"` // 为空时使用各云的默认规格
+	Count        int    `json:"count,omitempty"`
+	MinSize      int    `json:"minSize,omitempty"`
+	MaxSize      int    `json:"maxSize,omitempty"`
+}
+
+// ValidationFinding 表示预览渲染时发现的问题
+type ValidationFinding struct {
+	Severity string `json:"severity"` // warning, error
+	Source   string `json:"source"`   // tags, generator, terraform
+	Message  string `json:"message"`
+	File     string `json:"file,omitempty"`
+}
+
 // TopologyNode 表示拓扑图中的节点
 type TopologyNode struct {
 	ID   string                 `json:"id"`
@@ -29,8 +29,14 @@ func SetupRoutes(router *gin.Engine) {
 		// 执行部署
 		api.POST("/deploy", deploymentHandler.StartDeployment)
 
+		// 渲染部署配置预览，不执行部署
+		api.POST("/render", deploymentHandler.RenderDeployment)
+
 		// 获取部署状态
 		api.GET("/deployment/status", deploymentHandler.GetDeploymentStatus)
+
+		// 下载部署生成的Terraform配置包
+		api.GET("/deployments/:id/bundle", deploymentHandler.GetDeploymentBundle)
 	}
 }
 
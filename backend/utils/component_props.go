@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// componentPropsMap 返回部署中已启用组件的属性，组件未启用时返回nil
+// 供需要读取其他组件属性的生成器使用，例如路由表需要读取对等连接组件的配置
+func componentPropsMap(config renderContext, component string) map[string]interface{} {
+	for _, name := range config.Components {
+		if name == component {
+			propsMap, _ := config.ComponentProperties[component].(map[string]interface{})
+			if propsMap == nil {
+				propsMap = make(map[string]interface{})
+			}
+			return propsMap
+		}
+	}
+	return nil
+}
+
+// getStringProp 读取字符串类型的组件属性，属性不存在或为空时返回默认值
+func getStringProp(propsMap map[string]interface{}, key, defaultValue string) string {
+	if value, ok := propsMap[key]; ok && value != nil {
+		if valueStr, ok := value.(string); ok && valueStr != "" {
+			return valueStr
+		}
+	}
+	return defaultValue
+}
+
+// getIntProp 读取数值类型的组件属性，兼容前端传入的数字和数字字符串
+func getIntProp(propsMap map[string]interface{}, key string, defaultValue int) int {
+	if value, ok := propsMap[key]; ok && value != nil {
+		switch v := value.(type) {
+		case float64:
+			return int(v)
+		case int:
+			return v
+		case string:
+			if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				return n
+			}
+		}
+	}
+	return defaultValue
+}
+
+// getBoolProp 读取布尔类型的组件属性，兼容 "true"/"false" 字符串
+func getBoolProp(propsMap map[string]interface{}, key string, defaultValue bool) bool {
+	if value, ok := propsMap[key]; ok && value != nil {
+		switch v := value.(type) {
+		case bool:
+			return v
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b
+			}
+		}
+	}
+	return defaultValue
+}
+
+// hasListProp 判断列表类型的组件属性是否已填写，用于区分未填写和格式无效的属性
+func hasListProp(propsMap map[string]interface{}, key string) bool {
+	value, ok := propsMap[key]
+	if !ok || value == nil {
+		return false
+	}
+	if valueStr, ok := value.(string); ok {
+		return strings.TrimSpace(valueStr) != ""
+	}
+	return true
+}
+
+// decodeListProp 将列表类型的组件属性解码到out中
+// 前端可能直接传入JSON数组，也可能传入JSON字符串，两种形式都支持
+func decodeListProp(propsMap map[string]interface{}, key string, out interface{}) bool {
+	value, ok := propsMap[key]
+	if !ok || value == nil {
+		return false
+	}
+
+	var data []byte
+	if valueStr, ok := value.(string); ok {
+		if strings.TrimSpace(valueStr) == "" {
+			return false
+		}
+		data = []byte(valueStr)
+	} else {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return false
+		}
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		LogWarn("解析组件属性 " + key + " 失败: " + err.Error())
+		return false
+	}
+	return true
+}
+
+// getStringListProp 读取字符串列表类型的组件属性
+// 支持JSON数组和逗号分隔的字符串两种形式
+func getStringListProp(propsMap map[string]interface{}, key string) []string {
+	value, ok := propsMap[key]
+	if !ok || value == nil {
+		return nil
+	}
+
+	var items []string
+	switch v := value.(type) {
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if itemStr, ok := item.(string); ok && strings.TrimSpace(itemStr) != "" {
+				items = append(items, strings.TrimSpace(itemStr))
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,23 @@
+package utils
+
+// knownRegions 各云提供商支持的区域，与控制台区域列表(GetRegions)保持一致
+// 用户填写的区域会写入provider配置块，生成前必须在该列表中
+var knownRegions = map[string][]string{
+	"aws":        {"us-east-1", "us-east-2", "us-west-1", "us-west-2", "ap-east-1", "ap-northeast-1"},
+	"azure":      {"eastus", "eastus2", "westus", "westus2", "eastasia", "southeastasia"},
+	"alicloud":   {"cn-qingdao", "cn-beijing", "cn-zhangjiakou", "cn-hangzhou", "cn-shanghai", "cn-shenzhen"},
+	"baidu":      {"bj", "gz", "su"},
+	"huawei":     {"cn-north-1", "cn-north-4", "cn-east-3", "cn-south-1", "ap-southeast-1"},
+	"tencent":    {"ap-guangzhou", "ap-shanghai", "ap-beijing", "ap-chengdu", "ap-chongqing", "ap-hongkong"},
+	"volcengine": {"cn-beijing", "cn-shanghai", "cn-guangzhou"},
+}
+
+// isKnownRegion 判断区域是否为云提供商支持的区域
+func isKnownRegion(provider, region string) bool {
+	for _, known := range knownRegions[provider] {
+		if known == region {
+			return true
+		}
+	}
+	return false
+}
@@ -7,61 +7,67 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/your-org/multi-cloud-landing-zone/models"
+	"github.com/multi-cloud-landing-zone/backend/models"
 )
 
-// GenerateTerraformConfig 生成Terraform配置
+// GenerateTerraformConfig 生成Terraform配置，生成过程中发现的问题只记录日志
 func GenerateTerraformConfig(config models.DeploymentConfig) string {
-	// 记录开始生成Terraform配置
-	LogInfo(fmt.Sprintf("开始为云提供商 %s 生成Terraform配置", config.CloudProvider))
-	
+	return generateTerraformConfig(renderContext{DeploymentConfig: config})
+}
+
+// generateTerraformConfig 使用渲染上下文生成Terraform配置
+func generateTerraformConfig(config renderContext) string {
 	// 记录详细的部署配置参数
 	configJSON, _ := json.MarshalIndent(config, "", "  ")
 	LogInfo(fmt.Sprintf("部署配置详情:\n%s", string(configJSON)))
 	
-	var terraformConfig strings.Builder
-	// 添加提供商配置
-	switch config.CloudProvider {
-	case "aws":
-		terraformConfig.WriteString(fmt.Sprintf(`provider "aws" {
-  region = "%s"
+	// 多云部署文档包含多个提供商/区域分段，每个分段使用带别名的provider
+	if len(config.Sections) > 0 {
+		return generateMultiProviderConfig(config)
+	}
+	
+	// 记录开始生成Terraform配置
+	LogInfo(fmt.Sprintf("开始为云提供商 %s 生成Terraform配置", config.CloudProvider))
+	config = applyNamingConvention(config)
+	
+	// 添加提供商配置，AWS通过default_tags统一打标签，其他云提供商的资源在模板中引用local.common_tags
+	tags := resolveTags(config)
+	providerBlock := generateProviderBlock(config.CloudProvider, config.Region, "", providerAssumeRole(config)+providerDefaultTags(config.CloudProvider, tags))
+	body := generateResourcesConfig(config)
+	return providerBlock + generateCommonTagsLocals(body, "common_tags", tags) + body
 }
-`, config.Region))
-	case "azure":
-		terraformConfig.WriteString(`provider "azurerm" {
-  features {}
+
+// componentSection 表示单个组件生成的配置，用于按组件拆分文件和子模块
+type componentSection struct {
+	Name      string
+	Body      string
+	Module    bool              // 模块目录中的组件，本身就是module块，不再包装为子模块
+	Section   string            // 多云部署中组件所属分段的provider别名，单云部署为空
+	Providers map[string]string // 多云部署中子模块使用的provider别名，键为provider名称
+	Tags      string            // 子模块tags变量的值，为空时为根模块的var.tags
 }
-`)
-	case "alicloud":
-		terraformConfig.WriteString(fmt.Sprintf(`provider "alicloud" {
-  region = "%s"
-}
-`, config.Region))
-	case "baidu":
-		terraformConfig.WriteString(fmt.Sprintf(`provider "baiducloud" {
-  region = "%s"
-}
-`, config.Region))
-	case "huawei":
-		terraformConfig.WriteString(fmt.Sprintf(`provider "huaweicloud" {
-  region = "%s"
-}
-`, config.Region))
-	case "tencent":
-		terraformConfig.WriteString(fmt.Sprintf(`provider "tencentcloud" {
-  region = "%s"
-}
-`, config.Region))
-	case "volcengine":
-		terraformConfig.WriteString(fmt.Sprintf(`provider "volcengine" {
-  region = "%s"
-}
-`, config.Region))
-	default:
-		terraformConfig.WriteString(fmt.Sprintf(`provider "%s" {
-  region = "%s"
-}
-`, config.CloudProvider, config.Region))
+
+// generateResourcesConfig 生成单个提供商/区域的VPC、子网、路由和组件配置，不包含provider配置块
+func generateResourcesConfig(config renderContext) string {
+	network, components := generateResourceSections(config)
+	var terraformConfig strings.Builder
+	terraformConfig.WriteString(network)
+	for _, component := range components {
+		terraformConfig.WriteString(component.Body)
+	}
+	return terraformConfig.String()
+}
+
+// generateResourceSections 生成网络等基础配置和每个组件的配置，同名组件的配置合并在一起
+func generateResourceSections(config renderContext) (string, []componentSection) {
+	var terraformConfig strings.Builder
+	
+	// 添加组织结构和账号开通配置，只开通账号时不生成网络资源
+	if config.Organization.Enabled {
+		terraformConfig.WriteString(generateOrganizationConfig(config))
+		if !hasNetworkConfig(config) {
+			return terraformConfig.String(), nil
+		}
 	}
 	
 	// 添加VPC配置
@@ -75,13 +81,13 @@ func GenerateTerraformConfig(config models.DeploymentConfig) string {
 				terraformConfig.WriteString(fmt.Sprintf(`resource "aws_vpc" "%s" {
   cidr_block           = "%s"
   enable_dns_support   = %t
-  enable_dns_hostnames = %t
+  enable_dns_hostnames = %t%s
   
   tags = {
     Name = "%s"
   }
 }
-`, vpc.Name, vpc.CIDR, vpc.EnableDnsSupport, vpc.EnableDnsHostnames, vpc.Name))
+`, vpc.Name, vpc.CIDR, vpc.EnableDnsSupport, vpc.EnableDnsHostnames, ipv6VpcArgs("aws", vpc), vpcDisplayName(vpc)))
 				
 				LogInfo(fmt.Sprintf("已生成VPC配置 %d: 名称=%s, CIDR=%s", i+1, vpc.Name, vpc.CIDR))
 			}
@@ -90,67 +96,101 @@ func GenerateTerraformConfig(config models.DeploymentConfig) string {
 			terraformConfig.WriteString(fmt.Sprintf(`resource "aws_vpc" "%s" {
   cidr_block           = "%s"
   enable_dns_support   = %t
-  enable_dns_hostnames = %t
+  enable_dns_hostnames = %t%s
   
   tags = {
     Name = "%s"
   }
 }
-`, config.VPC.Name, config.VPC.CIDR, config.VPC.EnableDnsSupport, config.VPC.EnableDnsHostnames, config.VPC.Name))
+`, config.VPC.Name, config.VPC.CIDR, config.VPC.EnableDnsSupport, config.VPC.EnableDnsHostnames, ipv6VpcArgs("aws", config.VPC), vpcDisplayName(config.VPC)))
 			
 			LogInfo(fmt.Sprintf("已生成单个VPC配置: 名称=%s, CIDR=%s", config.VPC.Name, config.VPC.CIDR))
 		}
-	} else if config.CloudProvider == "azure" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "azurerm_resource_group" "rg" {
+	} else {
+		if config.CloudProvider == "azure" {
+			terraformConfig.WriteString(fmt.Sprintf(`resource "azurerm_resource_group" "rg" {
   name     = "rg-%s"
   location = "%s"
+
+  tags = local.common_tags
 }
-resource "azurerm_virtual_network" "%s" {
+`, config.VPC.Name, config.Region))
+		}
+		
+		// 其他云提供商同样支持多个VPC，未提供AllVpcs时使用单个VPC配置
+		for _, vpc := range resolveVpcs(config) {
+			switch config.CloudProvider {
+			case "azure":
+				addressSpace := ""
+				if vpc.EnableIpv6 {
+					addressSpace = ipv6AddressSpace(vpc, -1)
+				}
+				terraformConfig.WriteString(fmt.Sprintf(`resource "azurerm_virtual_network" "%s" {
   name                = "%s"
-  address_space       = ["%s"]
+  address_space       = ["%s"%s]
   location            = azurerm_resource_group.rg.location
   resource_group_name = azurerm_resource_group.rg.name
+
+  tags = local.common_tags
 }
-`, config.VPC.Name, config.Region, config.VPC.Name, config.VPC.Name, config.VPC.CIDR))
-	} else if config.CloudProvider == "alicloud" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "alicloud_vpc" "%s" {
+`, vpc.Name, vpcDisplayName(vpc), vpc.CIDR, addressSpace))
+			case "alicloud":
+				terraformConfig.WriteString(fmt.Sprintf(`resource "alicloud_vpc" "%s" {
   vpc_name   = "%s"
-  cidr_block = "%s"
+  cidr_block = "%s"%s
+
+  tags = local.common_tags
 }
-`, config.VPC.Name, config.VPC.Name, config.VPC.CIDR))
-	} else if config.CloudProvider == "baidu" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "baiducloud_vpc" "%s" {
+`, vpc.Name, vpcDisplayName(vpc), vpc.CIDR, ipv6VpcArgs("alicloud", vpc)))
+			case "baidu":
+				terraformConfig.WriteString(fmt.Sprintf(`resource "baiducloud_vpc" "%s" {
   name       = "%s"
   cidr_block = "%s"
+
+  tags = local.common_tags
 }
-`, config.VPC.Name, config.VPC.Name, config.VPC.CIDR))
-	} else if config.CloudProvider == "huawei" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_vpc" "%s" {
+`, vpc.Name, vpcDisplayName(vpc), vpc.CIDR))
+			case "huawei":
+				terraformConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_vpc" "%s" {
   name        = "%s"
   cidr        = "%s"
   description = "VPC created by multi-cloud landing zone platform"
+
+  tags = local.common_tags
 }
-`, config.VPC.Name, config.VPC.Name, config.VPC.CIDR))
-	} else if config.CloudProvider == "tencent" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "tencentcloud_vpc" "%s" {
+`, vpc.Name, vpcDisplayName(vpc), vpc.CIDR))
+			case "tencent":
+				terraformConfig.WriteString(fmt.Sprintf(`resource "tencentcloud_vpc" "%s" {
   name       = "%s"
-  cidr_block = "%s"
+  cidr_block = "%s"%s
+
+  tags = local.common_tags
 }
-`, config.VPC.Name, config.VPC.Name, config.VPC.CIDR))
-	} else if config.CloudProvider == "volcengine" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "volcengine_vpc" "%s" {
+`, vpc.Name, vpcDisplayName(vpc), vpc.CIDR, ipv6VpcArgs("tencent", vpc)))
+			case "volcengine":
+				terraformConfig.WriteString(fmt.Sprintf(`resource "volcengine_vpc" "%s" {
   vpc_name   = "%s"
-  cidr_block = "%s"
+  cidr_block = "%s"%s
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
 }
-`, config.VPC.Name, config.VPC.Name, config.VPC.CIDR))
-	} else {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "%s_vpc" "%s" {
+`, vpc.Name, vpcDisplayName(vpc), vpc.CIDR, ipv6VpcArgs("volcengine", vpc)))
+			default:
+				terraformConfig.WriteString(fmt.Sprintf(`resource "%s_vpc" "%s" {
   name       = "%s"
   cidr_block = "%s"
 }
-`, config.CloudProvider, config.VPC.Name, config.VPC.Name, config.VPC.CIDR))
+`, config.CloudProvider, vpc.Name, vpcDisplayName(vpc), vpc.CIDR))
+			}
+			LogInfo(fmt.Sprintf("已生成VPC配置: 名称=%s, CIDR=%s", vpc.Name, vpc.CIDR))
+		}
 	}
-	LogInfo(fmt.Sprintf("已生成VPC配置: 名称=%s, CIDR=%s", config.VPC.Name, config.VPC.CIDR))
 	
 	// 添加子网配置
 	if config.CloudProvider == "aws" {
@@ -185,13 +225,13 @@ resource "azurerm_virtual_network" "%s" {
   vpc_id                  = aws_vpc.%s.id
   cidr_block              = "%s"
   availability_zone       = "%s"
-  map_public_ip_on_launch = %t
+  map_public_ip_on_launch = %t%s
   
   tags = {
     Name = "%s"
   }
 }
-`, subnet.Name, vpcName, subnet.CIDR, actualAZ, subnet.MapPublicIpOnLaunch, subnet.Name))
+`, subnet.Name, vpcName, subnet.CIDR, actualAZ, subnet.MapPublicIpOnLaunch, ipv6SubnetArgs(config, subnet), subnetDisplayName(subnet)))
 				
 				LogInfo(fmt.Sprintf("已生成子网配置 %d: 名称=%s, CIDR=%s, VPC=%s", i+1, subnet.Name, subnet.CIDR, vpcName))
 			}
@@ -204,100 +244,141 @@ resource "azurerm_virtual_network" "%s" {
   vpc_id                  = aws_vpc.%s.id
   cidr_block              = "%s"
   availability_zone       = "%s"
-  map_public_ip_on_launch = %t
+  map_public_ip_on_launch = %t%s
   
   tags = {
     Name = "%s"
   }
 }
-`, config.Subnet.Name, config.VPC.Name, config.Subnet.CIDR, actualAZ, config.Subnet.MapPublicIpOnLaunch, config.Subnet.Name))
+`, config.Subnet.Name, config.VPC.Name, config.Subnet.CIDR, actualAZ, config.Subnet.MapPublicIpOnLaunch, ipv6SubnetArgs(config, config.Subnet), subnetDisplayName(config.Subnet)))
 			
 			LogInfo(fmt.Sprintf("已生成单个子网配置: 名称=%s, CIDR=%s, VPC=%s", config.Subnet.Name, config.Subnet.CIDR, config.VPC.Name))
 		}
-	} else if config.CloudProvider == "azure" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "azurerm_subnet" "%s" {
+	} else {
+		// 其他云提供商同样支持多个子网，未提供AllSubnets时使用单个子网配置
+		for _, subnet := range resolveSubnets(config) {
+			vpcName := subnetVpc(config, subnet).Name
+			zone := subnetZone(config, subnet)
+			
+			switch config.CloudProvider {
+			case "azure":
+				addressPrefixes := ""
+				if vpc := subnetVpc(config, subnet); subnetIpv6Enabled(vpc, subnet) {
+					addressPrefixes = ipv6AddressSpace(vpc, subnetIpv6Index(config, subnet))
+				}
+				terraformConfig.WriteString(fmt.Sprintf(`resource "azurerm_subnet" "%s" {
   name                 = "%s"
   resource_group_name  = azurerm_resource_group.rg.name
   virtual_network_name = azurerm_virtual_network.%s.name
-  address_prefixes     = ["%s"]
+  address_prefixes     = ["%s"%s]
 }
-`, config.Subnet.Name, config.Subnet.Name, config.VPC.Name, config.Subnet.CIDR))
-	} else if config.CloudProvider == "alicloud" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "alicloud_vswitch" "%s" {
+`, subnet.Name, subnetDisplayName(subnet), vpcName, subnet.CIDR, addressPrefixes))
+			case "alicloud":
+				terraformConfig.WriteString(fmt.Sprintf(`resource "alicloud_vswitch" "%s" {
   vpc_id     = alicloud_vpc.%s.id
   cidr_block = "%s"
   zone_id    = "%s"
-  name       = "%s"
+  name       = "%s"%s
+
+  tags = local.common_tags
 }
-`, config.Subnet.Name, config.VPC.Name, config.Subnet.CIDR, config.AZ, config.Subnet.Name))
-	} else if config.CloudProvider == "baidu" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "baiducloud_subnet" "%s" {
+`, subnet.Name, vpcName, subnet.CIDR, zone, subnetDisplayName(subnet), ipv6SubnetArgs(config, subnet)))
+			case "baidu":
+				terraformConfig.WriteString(fmt.Sprintf(`resource "baiducloud_subnet" "%s" {
   name        = "%s"
   zone_name   = "%s"
   cidr        = "%s"
   vpc_id      = baiducloud_vpc.%s.id
   description = "Subnet created by multi-cloud landing zone platform"
-}
-`, config.Subnet.Name, config.Subnet.Name, config.AZ, config.Subnet.CIDR, config.VPC.Name))
-	} else if config.CloudProvider == "huawei" {
-		// 从CIDR中提取网关IP
-		cidrParts := strings.Split(config.Subnet.CIDR, "/")
-		ipParts := strings.Split(cidrParts[0], ".")
-		gatewayIP := fmt.Sprintf("%s.%s.%s.1", ipParts[0], ipParts[1], ipParts[2])
-		terraformConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_vpc_subnet" "%s" {
+
+  tags = local.common_tags
+}
+`, subnet.Name, subnetDisplayName(subnet), zone, subnet.CIDR, vpcName))
+			case "huawei":
+				// 从CIDR中提取网关IP
+				cidrParts := strings.Split(subnet.CIDR, "/")
+				ipParts := strings.Split(cidrParts[0], ".")
+				gatewayIP := fmt.Sprintf("%s.%s.%s.1", ipParts[0], ipParts[1], ipParts[2])
+				terraformConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_vpc_subnet" "%s" {
   name       = "%s"
   cidr       = "%s"
   gateway_ip = "%s"
-  vpc_id     = huaweicloud_vpc.%s.id
+  vpc_id     = huaweicloud_vpc.%s.id%s
+
+  tags = local.common_tags
 }
-`, config.Subnet.Name, config.Subnet.Name, config.Subnet.CIDR, gatewayIP, config.VPC.Name))
-	} else if config.CloudProvider == "tencent" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "tencentcloud_subnet" "%s" {
+`, subnet.Name, subnetDisplayName(subnet), subnet.CIDR, gatewayIP, vpcName, ipv6SubnetArgs(config, subnet)))
+			case "tencent":
+				terraformConfig.WriteString(fmt.Sprintf(`resource "tencentcloud_subnet" "%s" {
   name              = "%s"
   vpc_id            = tencentcloud_vpc.%s.id
   cidr_block        = "%s"
   availability_zone = "%s"
+
+  tags = local.common_tags
 }
-`, config.Subnet.Name, config.Subnet.Name, config.VPC.Name, config.Subnet.CIDR, config.AZ))
-	} else if config.CloudProvider == "volcengine" {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "volcengine_subnet" "%s" {
+`, subnet.Name, subnetDisplayName(subnet), vpcName, subnet.CIDR, zone))
+			case "volcengine":
+				terraformConfig.WriteString(fmt.Sprintf(`resource "volcengine_subnet" "%s" {
   subnet_name = "%s"
   cidr_block  = "%s"
   zone_id     = "%s"
-  vpc_id      = volcengine_vpc.%s.id
+  vpc_id      = volcengine_vpc.%s.id%s
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
 }
-`, config.Subnet.Name, config.Subnet.Name, config.Subnet.CIDR, config.AZ, config.VPC.Name))
-	} else {
-		terraformConfig.WriteString(fmt.Sprintf(`resource "%s_subnet" "%s" {
+`, subnet.Name, subnetDisplayName(subnet), subnet.CIDR, zone, vpcName, ipv6SubnetArgs(config, subnet)))
+			default:
+				terraformConfig.WriteString(fmt.Sprintf(`resource "%s_subnet" "%s" {
   name       = "%s"
   vpc_id     = %s_vpc.%s.id
   cidr_block = "%s"
   zone       = "%s"
 }
-`, config.CloudProvider, config.Subnet.Name, config.Subnet.Name, config.CloudProvider, config.VPC.Name, config.Subnet.CIDR, config.AZ))
+`, config.CloudProvider, subnet.Name, subnetDisplayName(subnet), config.CloudProvider, vpcName, subnet.CIDR, zone))
+			}
+			LogInfo(fmt.Sprintf("已生成子网配置: 名称=%s, CIDR=%s, VPC=%s", subnet.Name, subnet.CIDR, vpcName))
+		}
+	}
+	
+	// 开启IPv6时生成仅出方向IPv6网关、IPv6默认路由和网段输出
+	if deploymentHasIpv6(config) {
+		terraformConfig.WriteString(generateIpv6Config(config))
+	}
+	
+	// 启用路由表时生成互联网网关、NAT网关和按子网层级划分的路由表
+	if config.ComponentConfig.EnableRouteTables {
+		terraformConfig.WriteString(generateRoutingConfig(config))
+	}
+	
+	// 启用安全基线时生成操作审计、配置记录、VPC流日志和专用加密日志存储桶
+	if config.SecurityBaseline.Enabled {
+		terraformConfig.WriteString(generateSecurityBaselineConfig(config))
 	}
 	
 	// 添加组件配置
+	network := terraformConfig.Len()
+	var components []componentSection
+	componentIndex := make(map[string]int)
 	for _, component := range config.Components {
 		LogInfo(fmt.Sprintf("处理组件: %s", component))
+		start := terraformConfig.Len()
 		
 		// 获取组件属性
-		var propsMap map[string]interface{}
-		if config.ComponentProps != nil {
-			if props, ok := config.ComponentProps[component]; ok {
-				propsMap = props
-			}
-		}
-		if propsMap == nil {
-			propsMap = make(map[string]interface{})
-		}
+		propsMap := componentPropsMap(config, component)
 		
 		switch component {
 		case "ec2":
 			if config.CloudProvider == "aws" {
 				// 设置默认值
 				instanceType := "t2.micro"
-				amiId := "ami-0c55b159cbfafe1f0" // Amazon Linux 2 AMI ID
+				amiId := ""
 				
 				// 如果属性存在且不为nil，则使用属性值
 				if instType, ok := propsMap["instance_type"]; ok && instType != nil {
@@ -312,8 +393,21 @@ resource "azurerm_virtual_network" "%s" {
 					}
 				}
 				
+				if err := checkPropertyToken("instance_type", instanceType); err != nil {
+					reportError(config, fmt.Sprintf("EC2组件配置无效，跳过生成: %v", err))
+					break
+				}
+				
+				// 未指定AMI时按区域查找最新的Amazon Linux 2镜像，避免使用特定区域的固定AMI ID
+				amiRef := hclString(amiId)
+				if amiId == "" {
+					dataSource, ref := generateComputeImageDataSource("aws", "ec2_image", "amazon-linux", "2")
+					terraformConfig.WriteString(dataSource)
+					amiRef = ref
+				}
+				
 				terraformConfig.WriteString(fmt.Sprintf(`resource "aws_instance" "ec2" {
-  ami           = "%s"
+  ami           = %s
   instance_type = "%s"
   subnet_id     = aws_subnet.%s.id
   
@@ -321,81 +415,53 @@ resource "azurerm_virtual_network" "%s" {
     Name = "EC2 Instance"
   }
 }
-`, amiId, instanceType, config.Subnet.Name))
+`, amiRef, instanceType, config.Subnet.Name))
 			}
 			// 其他云提供商的EC2配置...
 			
 		case "rds":
+			// 托管数据库统一由数据库组件生成，凭据使用random_password而不是明文默认密码
 			if config.CloudProvider == "aws" {
-				// 设置默认值
-				instanceClass := "db.t2.micro"
-				engine := "mysql"
-				engineVersion := "5.7"
-				dbName := "mydb"
-				username := "admin"
-				password := "password123!"
-				
-				// 如果属性存在且不为nil，则使用属性值
-				if instClass, ok := propsMap["instance_class"]; ok && instClass != nil {
-					if instClassStr, ok := instClass.(string); ok && instClassStr != "" {
-						instanceClass = instClassStr
-					}
-				}
-				
-				if eng, ok := propsMap["engine"]; ok && eng != nil {
-					if engStr, ok := eng.(string); ok && engStr != "" {
-						engine = engStr
-					}
-				}
-				
-				if engVer, ok := propsMap["engine_version"]; ok && engVer != nil {
-					if engVerStr, ok := engVer.(string); ok && engVerStr != "" {
-						engineVersion = engVerStr
-					}
-				}
-				
-				if db, ok := propsMap["db_name"]; ok && db != nil {
-					if dbStr, ok := db.(string); ok && dbStr != "" {
-						dbName = dbStr
-					}
-				}
-				
-				if user, ok := propsMap["username"]; ok && user != nil {
-					if userStr, ok := user.(string); ok && userStr != "" {
-						username = userStr
-					}
+				// rds和database组件生成相同的资源，不能同时使用
+				if componentPropsMap(config, "database") != nil {
+					reportError(config, "rds组件和database组件生成相同的数据库资源，不能同时选择，请只使用database组件")
+					break
 				}
-				
-				if pass, ok := propsMap["password"]; ok && pass != nil {
-					if passStr, ok := pass.(string); ok && passStr != "" {
-						password = passStr
-					}
+				databaseConfig, err := generateDatabaseConfig(config, propsMap)
+				if err != nil {
+					reportError(config, fmt.Sprintf("托管数据库组件配置无效，跳过生成: %v", err))
 				}
-				
-				terraformConfig.WriteString(fmt.Sprintf(`resource "aws_db_subnet_group" "default" {
-  name       = "main"
-  subnet_ids = [aws_subnet.%s.id]
-  
-  tags = {
-    Name = "My DB subnet group"
-  }
-}
-
-resource "aws_db_instance" "default" {
-  allocated_storage    = 10
-  db_name              = "%s"
-  engine               = "%s"
-  engine_version       = "%s"
-  instance_class       = "%s"
-  username             = "%s"
-  password             = "%s"
-  parameter_group_name = "default.mysql5.7"
-  skip_final_snapshot  = true
-  db_subnet_group_name = aws_db_subnet_group.default.name
-}
-`, config.Subnet.Name, dbName, engine, engineVersion, instanceClass, username, password))
+				terraformConfig.WriteString(databaseConfig)
+			}
+			// 其他云提供商请使用database组件...
+			
+		case "database":
+			// 跨云托管数据库组件：RDS、Azure Database、ApsaraDB RDS、华为云RDS、TencentDB和火山引擎RDS
+			databaseConfig, err := generateDatabaseConfig(config, propsMap)
+			if err != nil {
+				reportError(config, fmt.Sprintf("托管数据库组件配置无效，跳过生成: %v", err))
 			}
-			// 其他云提供商的RDS配置...
+			terraformConfig.WriteString(databaseConfig)
+			
+		case "kubernetes":
+			// 跨云托管Kubernetes组件：EKS、AKS、ACK、CCE、TKE和VKE
+			terraformConfig.WriteString(generateKubernetesConfig(config, propsMap))
+			
+		case "bastion":
+			// 堡垒机组件：Azure Bastion和阿里云堡垒机使用托管服务，其他云在公有子网中创建加固的堡垒机实例
+			terraformConfig.WriteString(generateBastionConfig(config, propsMap))
+			
+		case "monitoring":
+			// 监控告警组件：为负载均衡、数据库和NAT网关创建指标告警，并发送到各云的通知目标
+			terraformConfig.WriteString(generateMonitoringConfig(config, propsMap))
+			
+		case "cache":
+			// 缓存组件：托管Redis部署在私有子网中，访问密码由random_password生成
+			terraformConfig.WriteString(generateCacheConfig(config, propsMap))
+			
+		case "message-queue":
+			// 消息队列组件：托管Kafka或RocketMQ部署在私有子网中，SASL或ACL用户的密码由random_password生成
+			terraformConfig.WriteString(generateMessageQueueConfig(config, propsMap))
 			
 		case "elb":
 			if config.CloudProvider == "aws" {
@@ -416,21 +482,12 @@ resource "aws_db_instance" "default" {
 					}
 				}
 				
-				terraformConfig.WriteString(fmt.Sprintf(`resource "aws_lb" "%s" {
-  name               = "%s"
-  internal           = false
-  load_balancer_type = "%s"
-  security_groups    = [aws_security_group.lb_sg.id]
-  subnets            = [aws_subnet.%s.id]
-  
-  enable_deletion_protection = false
-  
-  tags = {
-    Environment = "production"
-  }
-}
-
-resource "aws_security_group" "lb_sg" {
+				// 指定了security_groups时引用安全组组件中的安全组，否则创建默认的HTTP安全组
+				securityGroupRefs := resolveSecurityGroupRefs(config, propsMap)
+				lbSecurityGroup := ""
+				if len(securityGroupRefs) == 0 {
+					securityGroupRefs = []string{"aws_security_group.lb_sg.id"}
+					lbSecurityGroup = fmt.Sprintf(`resource "aws_security_group" "lb_sg" {
   name        = "allow_http"
   description = "Allow HTTP inbound traffic"
   vpc_id      = aws_vpc.%s.id
@@ -455,7 +512,20 @@ resource "aws_security_group" "lb_sg" {
   }
 }
 
-resource "aws_lb_listener" "front_end" {
+`, config.VPC.Name, listenerPort, listenerPort)
+				}
+				
+				terraformConfig.WriteString(fmt.Sprintf(`resource "aws_lb" "%s" {
+  name               = "%s"
+  internal           = false
+  load_balancer_type = "%s"
+  security_groups    = %s
+  subnets            = [aws_subnet.%s.id]
+  
+  enable_deletion_protection = false
+}
+
+%sresource "aws_lb_listener" "front_end" {
   load_balancer_arn = aws_lb.%s.arn
   port              = "%d"
   protocol          = "HTTP"
@@ -471,200 +541,28 @@ resource "aws_lb_target_group" "front_end" {
   protocol = "HTTP"
   vpc_id   = aws_vpc.%s.id
 }
-`, component, component, lbType, config.Subnet.Name, config.VPC.Name, listenerPort, listenerPort, component, listenerPort, config.VPC.Name))
+`, component, component, lbType, hclList(securityGroupRefs), config.Subnet.Name, lbSecurityGroup, component, listenerPort, config.VPC.Name))
 			}
 			// 其他云提供商的负载均衡器配置...
 			
 		case "transit-gateway":
-			if config.CloudProvider == "aws" {
-				// 创建Transit Gateway
-				// 设置默认值，避免nil值导致格式化错误
-				description := "Transit Gateway for multi-cloud connectivity"
-				autoAcceptSharedAttachments := "disable"
-				dnsSupport := "enable"
-				vpnEcmpSupport := "disable"
-				tgwName := "transit-gateway"
-				
-				// 如果属性存在且不为nil，则使用属性值
-				if desc, ok := propsMap["description"]; ok && desc != nil {
-					if descStr, ok := desc.(string); ok && descStr != "" {
-						description = descStr
-					}
-				}
-				
-				if autoAccept, ok := propsMap["auto_accept_shared_attachments"]; ok && autoAccept != nil {
-					if autoAcceptStr, ok := autoAccept.(string); ok && autoAcceptStr != "" {
-						autoAcceptSharedAttachments = autoAcceptStr
-					}
-				}
-				
-				if dns, ok := propsMap["dns_support"]; ok && dns != nil {
-					if dnsStr, ok := dns.(string); ok && dnsStr != "" {
-						dnsSupport = dnsStr
-					}
-				}
-				
-				if vpnEcmp, ok := propsMap["vpn_ecmp_support"]; ok && vpnEcmp != nil {
-					if vpnEcmpStr, ok := vpnEcmp.(string); ok && vpnEcmpStr != "" {
-						vpnEcmpSupport = vpnEcmpStr
-					}
-				}
-				
-				if name, ok := propsMap["name"]; ok && name != nil {
-					if nameStr, ok := name.(string); ok && nameStr != "" {
-						tgwName = nameStr
-					}
-				}
-				
-				// 使用ComponentConfig中的传输网关名称（如果存在）
-				if config.ComponentConfig.TransitGatewayName != "" {
-					tgwName = config.ComponentConfig.TransitGatewayName
-				}
-				
-				terraformConfig.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway" "tgw" {
-  description                     = "%s"
-  auto_accept_shared_attachments  = "%s"
-  dns_support                     = "%s"
-  vpn_ecmp_support                = "%s"
-  
-  tags = {
-    Name = "%s"
-  }
-}
-`, description, autoAcceptSharedAttachments, dnsSupport, vpnEcmpSupport, tgwName))
-				
-				// 处理中转网关挂载配置
-				// 检查ComponentConfig中是否有tgwAttachments数组
-				var tgwAttachmentsJSON []byte
-				if attachments, ok := propsMap["tgwAttachments"]; ok && attachments != nil {
-					// 记录中转网关挂载配置
-					attachmentsStr, ok := attachments.(string)
-					if ok && attachmentsStr != "" {
-						LogInfo(fmt.Sprintf("处理中转网关挂载配置: %s", attachmentsStr))
-						tgwAttachmentsJSON = []byte(attachmentsStr)
-					}
-				}
-				
-				// 解析中转网关挂载配置
-				if len(tgwAttachmentsJSON) > 0 {
-					var attachments []map[string]interface{}
-					if err := json.Unmarshal(tgwAttachmentsJSON, &attachments); err == nil {
-						for i, attachment := range attachments {
-							// 获取挂载配置
-							vpcId, _ := attachment["vpcId"].(string)
-							subnetIds, _ := attachment["subnetIds"].(string)
-							attachmentName, _ := attachment["name"].(string)
-							
-							if attachmentName == "" {
-								attachmentName = fmt.Sprintf("tgw-attachment-%d", i+1)
-							}
-							
-							// 生成中转网关挂载配置
-							terraformConfig.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway_vpc_attachment" "tgw_attachment_%d" {
-  transit_gateway_id = aws_ec2_transit_gateway.tgw.id
-  vpc_id             = %s
-  subnet_ids         = [%s]
-  
-  tags = {
-    Name = "%s"
-  }
-}
-`, i, vpcId, subnetIds, attachmentName))
-							
-							LogInfo(fmt.Sprintf("已生成中转网关挂载配置 %d: 名称=%s", i+1, attachmentName))
-						}
-					}
-				} else if config.ComponentConfig.EnableVpcAttachment {
-					// 使用ComponentConfig中的配置
-					tgwConfig := config.ComponentConfig.TransitGatewayConfig
-					dnsSupport := "disable"
-					ipv6Support := "disable"
-					attachmentName := "tgw-attachment"
-					
-					if tgwConfig.DnsSupport {
-						dnsSupport = "enable"
-					}
-					
-					if tgwConfig.Ipv6Support {
-						ipv6Support = "enable"
-					}
-					
-					if tgwConfig.AttachmentName != "" {
-						attachmentName = tgwConfig.AttachmentName
-					}
-					
-					// 为每个VPC创建一个传输网关挂载
-					if config.AllVpcs != nil && len(config.AllVpcs) > 0 {
-						for i, vpc := range config.AllVpcs {
-							// 确定子网ID
-							var subnetIds string
-							if config.AllSubnets != nil && len(config.AllSubnets) > 0 {
-								// 查找属于当前VPC的子网
-								var vpcSubnets []string
-								for _, subnet := range config.AllSubnets {
-									if subnet.VpcIndex == i {
-										vpcSubnets = append(vpcSubnets, fmt.Sprintf("aws_subnet.%s.id", subnet.Name))
-									}
-								}
-								
-								if len(vpcSubnets) > 0 {
-									subnetIds = strings.Join(vpcSubnets, ", ")
-								} else {
-									// 如果没有找到属于当前VPC的子网，使用第一个子网
-									subnetIds = fmt.Sprintf("aws_subnet.%s.id", config.AllSubnets[0].Name)
-								}
-							} else if tgwConfig.SubnetIds != "" {
-								subnetIds = tgwConfig.SubnetIds
-							} else {
-								subnetIds = fmt.Sprintf("aws_subnet.%s.id", config.Subnet.Name)
-							}
-							
-							terraformConfig.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway_vpc_attachment" "tgw_attachment_%d" {
-  transit_gateway_id = aws_ec2_transit_gateway.tgw.id
-  vpc_id             = aws_vpc.%s.id
-  subnet_ids         = [%s]
-  
-  dns_support                                     = "%s"
-  ipv6_support                                    = "%s"
-  transit_gateway_default_route_table_association = true
-  transit_gateway_default_route_table_propagation = true
-  
-  tags = {
-    Name = "%s-%d"
-  }
-}
-`, i, vpc.Name, subnetIds, dnsSupport, ipv6Support, attachmentName, i+1))
-							
-							LogInfo(fmt.Sprintf("已生成中转网关挂载配置 %d: 名称=%s-%d, VPC=%s", i+1, attachmentName, i+1, vpc.Name))
-						}
-					} else {
-						// 使用子网ID或默认使用当前子网
-						subnetIds := tgwConfig.SubnetIds
-						if subnetIds == "" {
-							subnetIds = fmt.Sprintf("aws_subnet.%s.id", config.Subnet.Name)
-						}
-						
-						terraformConfig.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway_vpc_attachment" "tgw_attachment" {
-  transit_gateway_id = aws_ec2_transit_gateway.tgw.id
-  vpc_id             = aws_vpc.%s.id
-  subnet_ids         = [%s]
-  
-  dns_support                                     = "%s"
-  ipv6_support                                    = "%s"
-  transit_gateway_default_route_table_association = true
-  transit_gateway_default_route_table_propagation = true
-  
-  tags = {
-    Name = "%s"
-  }
-}
-`, config.VPC.Name, subnetIds, dnsSupport, ipv6Support, attachmentName))
-						
-						LogInfo(fmt.Sprintf("已生成中转网关挂载配置: 名称=%s, VPC=%s", attachmentName, config.VPC.Name))
-					}
-				}
+			terraformConfig.WriteString(generateTransitGatewayConfig(config, propsMap))
+			
+		case "vpc-peering":
+			terraformConfig.WriteString(generateVpcPeeringConfig(config, propsMap))
+			
+		case "cross-cloud-vpn":
+			terraformConfig.WriteString(generateCrossCloudVpnConfig(config, propsMap))
+			
+		case "private-dns":
+			terraformConfig.WriteString(generatePrivateDnsConfig(config, propsMap))
+			
+		case "iam-baseline":
+			iamConfig, err := generateIamBaselineConfig(config, propsMap)
+			if err != nil {
+				reportError(config, fmt.Sprintf("IAM基线组件配置无效，跳过生成: %v", err))
 			}
-			// 其他云提供商的中转网关配置...
+			terraformConfig.WriteString(iamConfig)
 			
 		case "s3":
 			if config.CloudProvider == "aws" {
@@ -689,20 +587,23 @@ resource "aws_lb_target_group" "front_end" {
 							if bucketName == "" {
 								bucketName = fmt.Sprintf("my-bucket-%d", i+1)
 							}
+							if err := checkBucketName("aws", bucketName); err != nil {
+								reportError(config, fmt.Sprintf("存储桶名称无效，跳过生成: %v", err))
+								continue
+							}
 							
 							// 获取存储桶策略类型
 							policyType, _ := bucket["policyType"].(string)
 							
 							// 生成存储桶配置
 							terraformConfig.WriteString(fmt.Sprintf(`resource "aws_s3_bucket" "bucket_%d" {
-  bucket = "%s"
+  bucket = %s
   
   tags = {
-    Name        = "%s"
-    Environment = "Dev"
+    Name = %s
   }
 }
-`, i, bucketName, bucketName))
+`, i, hclString(bucketName), hclString(bucketName)))
 							
 							// 根据策略类型添加相应的配置
 							if policyType == "public-read" {
@@ -770,8 +671,7 @@ POLICY
   bucket = "%s"
   
   tags = {
-    Name        = "%s"
-    Environment = "Dev"
+    Name = "%s"
   }
 }
 `, bucketName, bucketName))
@@ -816,10 +716,41 @@ POLICY
 				}
 			}
 			// 其他云提供商的对象存储配置...
+
+		case "compute":
+			// 跨云计算实例组件，镜像通过各云的镜像数据源按操作系统解析
+			terraformConfig.WriteString(generateComputeConfig(config, propsMap))
+			
+		case "object-storage":
+			// 跨云对象存储组件：OSS、OBS、COS、BOS、TOS、Azure Blob和S3
+			terraformConfig.WriteString(generateObjectStorageConfig(config, propsMap))
+
+		case "security-group":
+			// 安全组组件：按结构化规则生成各云的安全组，其他组件可通过security_groups按名称引用
+			terraformConfig.WriteString(generateSecurityGroupConfig(config, propsMap))
+
+		case "lambda", "azure-functions", "function-compute", "functiongraph", "scf":
+			// 无服务器函数组件：Lambda、Azure Functions、函数计算、FunctionGraph和SCF
+			terraformConfig.WriteString(generateFunctionConfig(config, component, propsMap))
+
+		default:
+			// 模块目录中由平台团队维护的Terraform模块组件
+			if entry, ok := findModuleComponent(component); ok {
+				terraformConfig.WriteString(generateModuleComponentConfig(config, entry, propsMap))
+			}
+		}
+		
+		body := terraformConfig.String()[start:]
+		if index, exists := componentIndex[component]; exists {
+			components[index].Body += body
+		} else {
+			componentIndex[component] = len(components)
+			_, isModule := findModuleComponent(component)
+			components = append(components, componentSection{Name: component, Body: body, Module: isModule})
 		}
 	}
 	
-	return terraformConfig.String()
+	return terraformConfig.String()[:network], components
 }
 
 // SaveTerraformConfig 保存Terraform配置到文件
@@ -841,8 +772,19 @@ func SaveTerraformConfig(config string, filePath string) error {
 	return nil
 }
 
-// GenerateTopology 生成资源拓扑图
+// GenerateTopology 生成资源拓扑图，生成过程中发现的问题只记录日志
 func GenerateTopology(config models.DeploymentConfig) map[string]interface{} {
+	return generateTopology(renderContext{DeploymentConfig: config})
+}
+
+// generateTopology 使用渲染上下文生成资源拓扑图
+func generateTopology(config renderContext) map[string]interface{} {
+	// 多云部署将所有分段的资源显示在同一张拓扑图中
+	if len(config.Sections) > 0 {
+		return generateMultiCloudTopology(config)
+	}
+	config = applyNamingConvention(config)
+	
 	// 创建拓扑图数据结构
 	topology := map[string]interface{}{
 		"nodes": []map[string]interface{}{},
@@ -851,13 +793,14 @@ func GenerateTopology(config models.DeploymentConfig) map[string]interface{} {
 	
 	// 添加VPC节点
 	if config.AllVpcs != nil && len(config.AllVpcs) > 0 {
-		for i, vpc := range config.AllVpcs {
+		for _, vpc := range config.AllVpcs {
 			vpcNode := map[string]interface{}{
 				"id":   vpc.Name,
 				"type": "vpc",
 				"name": vpc.Name,
 				"data": map[string]interface{}{
 					"cidr": vpc.CIDR,
+					"ipv6": vpc.EnableIpv6,
 				},
 			}
 			topology["nodes"] = append(topology["nodes"].([]map[string]interface{}), vpcNode)
@@ -869,6 +812,7 @@ func GenerateTopology(config models.DeploymentConfig) map[string]interface{} {
 			"name": config.VPC.Name,
 			"data": map[string]interface{}{
 				"cidr": config.VPC.CIDR,
+				"ipv6": config.VPC.EnableIpv6,
 			},
 		}
 		topology["nodes"] = append(topology["nodes"].([]map[string]interface{}), vpcNode)
@@ -876,7 +820,7 @@ func GenerateTopology(config models.DeploymentConfig) map[string]interface{} {
 	
 	// 添加子网节点
 	if config.AllSubnets != nil && len(config.AllSubnets) > 0 {
-		for i, subnet := range config.AllSubnets {
+		for _, subnet := range config.AllSubnets {
 			subnetNode := map[string]interface{}{
 				"id":   subnet.Name,
 				"type": "subnet",
@@ -884,6 +828,7 @@ func GenerateTopology(config models.DeploymentConfig) map[string]interface{} {
 				"data": map[string]interface{}{
 					"cidr": subnet.CIDR,
 					"az":   subnet.AZ,
+					"ipv6": subnetIpv6Enabled(subnetVpc(config, subnet), subnet),
 				},
 			}
 			topology["nodes"] = append(topology["nodes"].([]map[string]interface{}), subnetNode)
@@ -912,6 +857,7 @@ func GenerateTopology(config models.DeploymentConfig) map[string]interface{} {
 			"data": map[string]interface{}{
 				"cidr": config.Subnet.CIDR,
 				"az":   config.AZ,
+				"ipv6": subnetIpv6Enabled(config.VPC, config.Subnet),
 			},
 		}
 		topology["nodes"] = append(topology["nodes"].([]map[string]interface{}), subnetNode)
@@ -924,6 +870,11 @@ func GenerateTopology(config models.DeploymentConfig) map[string]interface{} {
 		})
 	}
 	
+	// 添加IPv6网关节点
+	ipv6Nodes, ipv6Edges := ipv6TopologyNodes(config)
+	topology["nodes"] = append(topology["nodes"].([]map[string]interface{}), ipv6Nodes...)
+	topology["edges"] = append(topology["edges"].([]map[string]interface{}), ipv6Edges...)
+	
 	// 添加组件节点
 	for _, component := range config.Components {
 		// 创建组件节点
@@ -986,12 +937,48 @@ func getActualAwsAZ(region, az string) string {
 	return region + az
 }
 
-// LogInfo 记录信息日志
-func LogInfo(message string) {
-	// 实现日志记录逻辑
+// providerLocalNames 云提供商对应的Terraform provider名称，也是该提供商资源类型的前缀
+var providerLocalNames = map[string]string{
+	"aws":        "aws",
+	"azure":      "azurerm",
+	"alicloud":   "alicloud",
+	"baidu":      "baiducloud",
+	"huawei":     "huaweicloud",
+	"tencent":    "tencentcloud",
+	"volcengine": "volcengine",
+}
+
+// providerLocalName 返回云提供商的Terraform provider名称，未知提供商直接使用其名称
+func providerLocalName(provider string) string {
+	if name, ok := providerLocalNames[provider]; ok {
+		return name
+	}
+	return provider
+}
+
+// generateProviderBlock 生成云提供商的provider配置块，alias不为空时生成带别名的provider
+// assumeRole为访问目标账号的配置，由providerAssumeRole生成
+func generateProviderBlock(provider, region, alias, assumeRole string) string {
+	if provider == "azure" {
+		aliasLine := ""
+		if alias != "" {
+			aliasLine = fmt.Sprintf("  alias = \"%s\"\n", alias)
+		}
+		if assumeRole != "" {
+			aliasLine += strings.TrimPrefix(assumeRole, "\n") + "\n"
+		}
+		return fmt.Sprintf(`provider "azurerm" {
+%s  features {}
 }
+`, aliasLine)
+	}
 
-// LogError 记录错误日志
-func LogError(message string) {
-	// 实现日志记录逻辑
+	aliasLine := ""
+	if alias != "" {
+		aliasLine = fmt.Sprintf("  alias  = \"%s\"\n", alias)
+	}
+	return fmt.Sprintf(`provider "%s" {
+%s  region = "%s"%s
+}
+`, providerLocalName(provider), aliasLine, region, assumeRole)
 }
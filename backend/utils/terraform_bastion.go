@@ -0,0 +1,850 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// bastionPorts 堡垒机各访问协议对应的端口
+var bastionPorts = map[string]int{
+	"ssh": 22,
+	"rdp": 3389,
+}
+
+// managedBastionProviders 提供托管堡垒机服务的云提供商，这些云不创建堡垒机实例
+var managedBastionProviders = map[string]string{
+	"azure":    "Azure Bastion",
+	"alicloud": "Bastionhost",
+}
+
+// bastionCloudInit 堡垒机实例的加固配置：禁用root和密码登录，启用自动安全更新和fail2ban
+const bastionCloudInit = `locals {
+  bastion_cloud_init = <<-CLOUDINIT
+    #cloud-config
+    package_update: true
+    package_upgrade: true
+    packages:
+      - fail2ban
+      - unattended-upgrades
+    ssh_pwauth: false
+    disable_root: true
+    write_files:
+      - path: /etc/ssh/sshd_config.d/99-bastion.conf
+        content: |
+          PermitRootLogin no
+          PasswordAuthentication no
+          X11Forwarding no
+          MaxAuthTries 3
+          ClientAliveInterval 300
+          ClientAliveCountMax 2
+    runcmd:
+      - systemctl restart ssh || systemctl restart sshd
+      - systemctl enable --now fail2ban
+  CLOUDINIT
+}
+
+`
+
+// bastionSpec 表示堡垒机组件的解析结果
+type bastionSpec struct {
+	Name              string
+	Protocol          string
+	Port              int
+	AdminCidrs        []string
+	InstanceType      string
+	OsFamily          string
+	ImageId           string
+	KeyName           string
+	SessionManager    bool
+	BastionSubnetCidr string
+	Subnet            models.Subnet
+	Vpc               models.VPC
+}
+
+// generateBastionConfig 生成跨云堡垒机组件的Terraform配置
+// Azure和阿里云使用托管堡垒机服务，其他云在公有子网中创建加固的堡垒机实例并绑定EIP，入站只允许来自管理网段的SSH或RDP
+func generateBastionConfig(config renderContext, propsMap map[string]interface{}) string {
+	provider := config.CloudProvider
+	if _, ok := defaultInstanceTypes[provider]; !ok || provider == "baidu" {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持堡垒机组件", provider))
+		return ""
+	}
+
+	spec := bastionSpec{
+		Name:              strings.ToLower(getStringProp(propsMap, "name", "bastion")),
+		Protocol:          strings.ToLower(getStringProp(propsMap, "protocol", "ssh")),
+		InstanceType:      getStringProp(propsMap, "instance_type", defaultInstanceTypes[provider]),
+		OsFamily:          strings.ToLower(getStringProp(propsMap, "os_family", "ubuntu")),
+		ImageId:           getStringProp(propsMap, "image_id", ""),
+		KeyName:           getStringProp(propsMap, "key_name", ""),
+		SessionManager:    getBoolProp(propsMap, "session_manager", false),
+		BastionSubnetCidr: getStringProp(propsMap, "bastion_subnet_cidr", ""),
+	}
+	port, ok := bastionPorts[spec.Protocol]
+	if !ok {
+		reportWarn(config, fmt.Sprintf("不支持的堡垒机访问协议 %s，使用默认的 ssh", spec.Protocol))
+		spec.Protocol, port = "ssh", bastionPorts["ssh"]
+	}
+	spec.Port = port
+	if _, ok := defaultOsVersions[spec.OsFamily]; !ok || (spec.OsFamily == "amazon-linux" && provider != "aws") {
+		reportWarn(config, fmt.Sprintf("堡垒机不支持操作系统 %s，使用默认的 ubuntu", spec.OsFamily))
+		spec.OsFamily = "ubuntu"
+	}
+	if spec.SessionManager && provider != "aws" {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持会话管理角色，已忽略session_manager", provider))
+		spec.SessionManager = false
+	}
+
+	// 管理网段不允许对所有地址开放，只有AWS开启会话管理时允许不配置管理网段
+	for _, cidr := range getStringListProp(propsMap, "admin_cidrs") {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			reportError(config, fmt.Sprintf("堡垒机管理网段 %s 格式无效，已忽略", cidr))
+			continue
+		}
+		if ones, _ := network.Mask.Size(); ones == 0 {
+			reportError(config, fmt.Sprintf("堡垒机管理网段 %s 对所有地址开放，已忽略", cidr))
+			continue
+		}
+		spec.AdminCidrs = append(spec.AdminCidrs, network.String())
+	}
+	if len(spec.AdminCidrs) == 0 && !spec.SessionManager {
+		reportError(config, fmt.Sprintf("堡垒机 %s 没有有效的管理网段，跳过生成", spec.Name))
+		return ""
+	}
+
+	// 托管堡垒机通过所选子网确定VPC，堡垒机实例必须放在公有子网中
+	var subnets []models.Subnet
+	if name := getStringProp(propsMap, "subnet", ""); name != "" {
+		subnets = selectSubnets(config, []string{name})
+	} else {
+		for _, subnet := range resolveSubnets(config) {
+			if subnetTier(subnet) == "public" {
+				subnets = append(subnets, subnet)
+			}
+		}
+		if len(subnets) == 0 {
+			subnets = resolveSubnets(config)
+		}
+	}
+	spec.Subnet = subnets[0]
+	spec.Vpc = subnetVpc(config, spec.Subnet)
+	if _, managed := managedBastionProviders[provider]; !managed && subnetTier(spec.Subnet) != "public" {
+		reportError(config, fmt.Sprintf("堡垒机 %s 所在的子网 %s 不是公有子网，跳过生成", spec.Name, spec.Subnet.Name))
+		return ""
+	}
+
+	// 没有会话管理时需要密钥对登录，Windows实例在AWS以外使用随机生成的管理员密码
+	if spec.KeyName == "" && !spec.SessionManager && (provider == "aws" || spec.Protocol == "ssh") {
+		if _, managed := managedBastionProviders[provider]; !managed {
+			reportError(config, fmt.Sprintf("堡垒机 %s 未指定key_name，无法登录，跳过生成", spec.Name))
+			return ""
+		}
+	}
+	if spec.Protocol == "rdp" && spec.ImageId == "" && provider != "aws" {
+		if _, managed := managedBastionProviders[provider]; !managed {
+			reportError(config, fmt.Sprintf("云提供商 %s 的RDP堡垒机需要通过image_id指定Windows镜像，跳过生成", provider))
+			return ""
+		}
+	}
+
+	if service, managed := managedBastionProviders[provider]; managed {
+		LogInfo(fmt.Sprintf("云提供商 %s 使用托管堡垒机服务 %s", provider, service))
+	}
+
+	var bastionConfig strings.Builder
+	switch provider {
+	case "aws":
+		bastionConfig.WriteString(generateAwsBastion(spec))
+	case "azure":
+		bastionConfig.WriteString(generateAzureBastion(config, spec))
+	case "alicloud":
+		bastionConfig.WriteString(generateAlicloudBastion(spec))
+	case "huawei":
+		bastionConfig.WriteString(generateHuaweiBastion(config, spec))
+	case "tencent":
+		bastionConfig.WriteString(generateTencentBastion(spec))
+	case "volcengine":
+		bastionConfig.WriteString(generateVolcengineBastion(spec))
+	}
+	if bastionConfig.Len() == 0 {
+		return ""
+	}
+
+	LogInfo(fmt.Sprintf("已生成堡垒机配置: 名称=%s, 协议=%s, 子网=%s, 管理网段=%s",
+		spec.Name, spec.Protocol, spec.Subnet.Name, strings.Join(spec.AdminCidrs, ",")))
+	return bastionConfig.String()
+}
+
+// bastionAttribute 生成按宽度对齐的可选属性行
+func bastionAttribute(width int, key, value string) string {
+	return fmt.Sprintf("\n  %-*s = %s", width, key, value)
+}
+
+// bastionImage 返回堡垒机镜像的数据源和镜像ID引用，SSH堡垒机按操作系统查找，AWS的RDP堡垒机使用Windows Server镜像
+func bastionImage(provider string, spec bastionSpec) (string, string) {
+	if spec.ImageId != "" {
+		return "", fmt.Sprintf(`"%s"`, spec.ImageId)
+	}
+	if spec.Protocol == "rdp" {
+		return `data "aws_ami" "bastion_image" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["Windows_Server-2022-English-Full-Base-*"]
+  }
+}
+
+`, "data.aws_ami.bastion_image.id"
+	}
+	dataSource, ref := generateComputeImageDataSource(provider, "bastion_image", spec.OsFamily, defaultOsVersions[spec.OsFamily])
+	return dataSource + "\n", ref
+}
+
+// bastionPassword 生成Windows堡垒机的管理员密码
+func bastionPassword() string {
+	return `resource "random_password" "bastion" {
+  length           = 20
+  special          = true
+  override_special = "!#%^*()-_=+"
+  min_lower        = 2
+  min_upper        = 2
+  min_numeric      = 2
+  min_special      = 2
+}
+
+`
+}
+
+// bastionOutputs 生成堡垒机公网IP的输出，使用随机密码时同时以敏感输出导出密码
+func bastionOutputs(publicIp string, password bool) string {
+	outputs := fmt.Sprintf(`
+output "bastion_public_ip" {
+  value = %s
+}
+`, publicIp)
+	if password {
+		outputs += `
+output "bastion_admin_password" {
+  value     = random_password.bastion.result
+  sensitive = true
+}
+`
+	}
+	return outputs
+}
+
+// generateAwsBastion 生成AWS堡垒机实例、安全组和EIP，开启会话管理时附加AmazonSSMManagedInstanceCore实例角色
+func generateAwsBastion(spec bastionSpec) string {
+	var bastion strings.Builder
+
+	dataSource, imageRef := bastionImage("aws", spec)
+	bastion.WriteString(dataSource)
+
+	ingress := ""
+	if len(spec.AdminCidrs) > 0 {
+		ingress = fmt.Sprintf(`
+
+  ingress {
+    description = "%s from admin CIDRs"
+    from_port   = %d
+    to_port     = %d
+    protocol    = "tcp"
+    cidr_blocks = %s
+  }`, strings.ToUpper(spec.Protocol), spec.Port, spec.Port, hclStringList(spec.AdminCidrs))
+	}
+	bastion.WriteString(fmt.Sprintf(`resource "aws_security_group" "bastion" {
+  name        = "%s-sg"
+  description = "Allow %s to the bastion from admin CIDRs only"
+  vpc_id      = %s.id%s
+
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  tags = {
+    Name = "%s-sg"
+  }
+}
+
+`, spec.Name, strings.ToUpper(spec.Protocol), vpcAddress("aws", spec.Vpc.Name), ingress, spec.Name))
+
+	optional := ""
+	if spec.KeyName != "" {
+		optional += bastionAttribute(22, "key_name", fmt.Sprintf(`"%s"`, spec.KeyName))
+	}
+	if spec.SessionManager {
+		bastion.WriteString(fmt.Sprintf(`data "aws_partition" "bastion" {}
+
+resource "aws_iam_role" "bastion" {
+  name = "%s-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "ec2.amazonaws.com" }
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "bastion_ssm" {
+  role       = aws_iam_role.bastion.name
+  policy_arn = "arn:${data.aws_partition.bastion.partition}:iam::aws:policy/AmazonSSMManagedInstanceCore"
+}
+
+resource "aws_iam_instance_profile" "bastion" {
+  name = "%s-profile"
+  role = aws_iam_role.bastion.name
+}
+
+`, spec.Name, spec.Name))
+		optional += bastionAttribute(22, "iam_instance_profile", "aws_iam_instance_profile.bastion.name")
+	}
+	if spec.Protocol == "ssh" {
+		bastion.WriteString(bastionCloudInit)
+		optional += bastionAttribute(22, "user_data", "local.bastion_cloud_init")
+	}
+
+	bastion.WriteString(fmt.Sprintf(`resource "aws_instance" "bastion" {
+  ami                    = %s
+  instance_type          = "%s"
+  subnet_id              = %s.id
+  vpc_security_group_ids = [aws_security_group.bastion.id]%s
+
+  metadata_options {
+    http_endpoint = "enabled"
+    http_tokens   = "required"
+  }
+
+  root_block_device {
+    volume_size = 30
+    volume_type = "gp3"
+    encrypted   = true
+  }
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+resource "aws_eip" "bastion" {
+  domain   = "vpc"
+  instance = aws_instance.bastion.id
+
+  tags = {
+    Name = "%s-eip"
+  }
+}
+`, imageRef, spec.InstanceType, subnetAddress("aws", spec.Subnet.Name), optional, spec.Name, spec.Name))
+
+	bastion.WriteString(bastionOutputs("aws_eip.bastion.public_ip", false))
+	return bastion.String()
+}
+
+// generateAzureBastion 生成Azure Bastion，在所选子网的VNet中创建AzureBastionSubnet
+// 子网的网络安全组按Azure Bastion要求的规则生成，HTTPS入站只允许来自管理网段
+func generateAzureBastion(config renderContext, spec bastionSpec) string {
+	if spec.BastionSubnetCidr == "" {
+		reportError(config, "Azure Bastion需要通过bastion_subnet_cidr指定AzureBastionSubnet的网段，跳过生成")
+		return ""
+	}
+	_, bastionNet, err := net.ParseCIDR(spec.BastionSubnetCidr)
+	if err != nil {
+		reportError(config, fmt.Sprintf("AzureBastionSubnet的网段 %s 格式无效，跳过生成", spec.BastionSubnetCidr))
+		return ""
+	}
+	_, vnetNet, err := net.ParseCIDR(spec.Vpc.CIDR)
+	if ones, _ := bastionNet.Mask.Size(); ones > 26 || err != nil || !vnetNet.Contains(bastionNet.IP) {
+		reportError(config, fmt.Sprintf("AzureBastionSubnet的网段 %s 必须位于VNet %s 的网段 %s 内，且不小于/26，跳过生成", bastionNet.String(), spec.Vpc.Name, spec.Vpc.CIDR))
+		return ""
+	}
+	for _, subnet := range resolveSubnets(config) {
+		if subnetVpc(config, subnet).Name == spec.Vpc.Name && cidrsOverlap(bastionNet.String(), subnet.CIDR) {
+			reportError(config, fmt.Sprintf("AzureBastionSubnet的网段 %s 与子网 %s 的网段 %s 重叠，跳过生成", bastionNet.String(), subnet.Name, subnet.CIDR))
+			return ""
+		}
+	}
+
+	return fmt.Sprintf(`resource "azurerm_subnet" "bastion" {
+  name                 = "AzureBastionSubnet"
+  resource_group_name  = azurerm_resource_group.rg.name
+  virtual_network_name = %s.name
+  address_prefixes     = ["%s"]
+}
+
+resource "azurerm_network_security_group" "bastion" {
+  name                = "%s-nsg"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+
+  security_rule {
+    name                       = "AllowHttpsFromAdminCidrs"
+    priority                   = 120
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_range     = "443"
+    source_address_prefixes    = %s
+    destination_address_prefix = "*"
+  }
+
+  security_rule {
+    name                       = "AllowGatewayManagerInbound"
+    priority                   = 130
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_range     = "443"
+    source_address_prefix      = "GatewayManager"
+    destination_address_prefix = "*"
+  }
+
+  security_rule {
+    name                       = "AllowAzureLoadBalancerInbound"
+    priority                   = 140
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_range     = "443"
+    source_address_prefix      = "AzureLoadBalancer"
+    destination_address_prefix = "*"
+  }
+
+  security_rule {
+    name                       = "AllowBastionHostCommunicationInbound"
+    priority                   = 150
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "*"
+    source_port_range          = "*"
+    destination_port_ranges    = ["8080", "5701"]
+    source_address_prefix      = "VirtualNetwork"
+    destination_address_prefix = "VirtualNetwork"
+  }
+
+  security_rule {
+    name                       = "DenyAllInbound"
+    priority                   = 4096
+    direction                  = "Inbound"
+    access                     = "Deny"
+    protocol                   = "*"
+    source_port_range          = "*"
+    destination_port_range     = "*"
+    source_address_prefix      = "*"
+    destination_address_prefix = "*"
+  }
+
+  security_rule {
+    name                       = "Allow%sOutbound"
+    priority                   = 100
+    direction                  = "Outbound"
+    access                     = "Allow"
+    protocol                   = "*"
+    source_port_range          = "*"
+    destination_port_range     = "%d"
+    source_address_prefix      = "*"
+    destination_address_prefix = "VirtualNetwork"
+  }
+
+  security_rule {
+    name                       = "AllowAzureCloudOutbound"
+    priority                   = 110
+    direction                  = "Outbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_range     = "443"
+    source_address_prefix      = "*"
+    destination_address_prefix = "AzureCloud"
+  }
+
+  security_rule {
+    name                       = "AllowBastionHostCommunicationOutbound"
+    priority                   = 120
+    direction                  = "Outbound"
+    access                     = "Allow"
+    protocol                   = "*"
+    source_port_range          = "*"
+    destination_port_ranges    = ["8080", "5701"]
+    source_address_prefix      = "VirtualNetwork"
+    destination_address_prefix = "VirtualNetwork"
+  }
+
+  security_rule {
+    name                       = "AllowSessionInformationOutbound"
+    priority                   = 130
+    direction                  = "Outbound"
+    access                     = "Allow"
+    protocol                   = "*"
+    source_port_range          = "*"
+    destination_port_range     = "80"
+    source_address_prefix      = "*"
+    destination_address_prefix = "Internet"
+  }
+
+  tags = local.common_tags
+}
+
+resource "azurerm_subnet_network_security_group_association" "bastion" {
+  subnet_id                 = azurerm_subnet.bastion.id
+  network_security_group_id = azurerm_network_security_group.bastion.id
+}
+
+resource "azurerm_public_ip" "bastion" {
+  name                = "%s-pip"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+  allocation_method   = "Static"
+  sku                 = "Standard"
+
+  tags = local.common_tags
+}
+
+resource "azurerm_bastion_host" "bastion" {
+  name                = "%s"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+  sku                 = "Standard"
+  tunneling_enabled   = true
+
+  ip_configuration {
+    name                 = "configuration"
+    subnet_id            = azurerm_subnet.bastion.id
+    public_ip_address_id = azurerm_public_ip.bastion.id
+  }
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+
+  depends_on = [azurerm_subnet_network_security_group_association.bastion]
+}
+`, vpcAddress("azure", spec.Vpc.Name), bastionNet.String(), spec.Name, hclStringList(spec.AdminCidrs),
+		strings.ToUpper(spec.Protocol), spec.Port, spec.Name, spec.Name, spec.Name) + bastionOutputs("azurerm_public_ip.bastion.ip_address", false)
+}
+
+// generateAlicloudBastion 生成阿里云堡垒机（Bastionhost）实例，公网访问白名单和安全组只允许管理网段
+// 堡垒机服务自带公网访问入口，不需要单独绑定EIP
+func generateAlicloudBastion(spec bastionSpec) string {
+	// 堡垒机的Web控制台使用443端口，SSH和RDP运维分别使用60022和63389端口
+	opsPort := 60022
+	if spec.Protocol == "rdp" {
+		opsPort = 63389
+	}
+
+	var bastion strings.Builder
+	bastion.WriteString(fmt.Sprintf(`resource "alicloud_security_group" "bastion" {
+  name        = "%s-sg"
+  description = "Allow bastionhost access from admin CIDRs only"
+  vpc_id      = %s.id
+
+  tags = local.common_tags
+}
+`, spec.Name, vpcAddress("alicloud", spec.Vpc.Name)))
+
+	for i, cidr := range spec.AdminCidrs {
+		for _, port := range []int{443, opsPort} {
+			bastion.WriteString(fmt.Sprintf(`
+resource "alicloud_security_group_rule" "bastion_%d_%d" {
+  type              = "ingress"
+  security_group_id = alicloud_security_group.bastion.id
+  ip_protocol       = "tcp"
+  port_range        = "%d/%d"
+  nic_type          = "intranet"
+  policy            = "accept"
+  priority          = 1
+  cidr_ip           = "%s"
+}
+`, port, i+1, port, port, cidr))
+		}
+	}
+
+	bastion.WriteString(fmt.Sprintf(`
+resource "alicloud_bastionhost_instance" "bastion" {
+  description        = "%s"
+  license_code       = "bhah_ent_50_asset"
+  plan_code          = "cloudbastion"
+  storage            = "5"
+  bandwidth          = "5"
+  period             = 1
+  vswitch_id         = %s.id
+  security_group_ids = [alicloud_security_group.bastion.id]
+  public_white_list  = %s
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+output "bastion_id" {
+  value = alicloud_bastionhost_instance.bastion.id
+}
+`, spec.Name, subnetAddress("alicloud", spec.Subnet.Name), hclStringList(spec.AdminCidrs), spec.Name))
+	return bastion.String()
+}
+
+// generateHuaweiBastion 生成华为云堡垒机实例、安全组和EIP
+func generateHuaweiBastion(config renderContext, spec bastionSpec) string {
+	var bastion strings.Builder
+
+	dataSource, imageRef := bastionImage("huawei", spec)
+	bastion.WriteString(dataSource)
+	bastion.WriteString(fmt.Sprintf(`resource "huaweicloud_networking_secgroup" "bastion" {
+  name        = "%s-sg"
+  description = "Allow %s to the bastion from admin CIDRs only"
+}
+`, spec.Name, strings.ToUpper(spec.Protocol)))
+	for i, cidr := range spec.AdminCidrs {
+		bastion.WriteString(fmt.Sprintf(`
+resource "huaweicloud_networking_secgroup_rule" "bastion_%d" {
+  security_group_id = huaweicloud_networking_secgroup.bastion.id
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  protocol          = "tcp"
+  port_range_min    = %d
+  port_range_max    = %d
+  remote_ip_prefix  = "%s"
+}
+`, i+1, spec.Port, spec.Port, cidr))
+	}
+	bastion.WriteString("\n")
+
+	optional := ""
+	if spec.Protocol == "ssh" {
+		bastion.WriteString(bastionCloudInit)
+		optional = bastionAttribute(18, "key_pair", fmt.Sprintf(`"%s"`, spec.KeyName)) +
+			bastionAttribute(18, "user_data", "local.bastion_cloud_init")
+	} else {
+		bastion.WriteString(bastionPassword())
+		optional = bastionAttribute(18, "admin_pass", "random_password.bastion.result")
+	}
+
+	bastion.WriteString(fmt.Sprintf(`resource "huaweicloud_compute_instance" "bastion" {
+  name               = "%s"
+  image_id           = %s
+  flavor_id          = "%s"
+  availability_zone  = "%s"
+  security_group_ids = [huaweicloud_networking_secgroup.bastion.id]
+  system_disk_type   = "SSD"
+  system_disk_size   = 40%s
+
+  network {
+    uuid = %s.id
+  }
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "huaweicloud_vpc_eip" "bastion" {
+  publicip {
+    type = "5_bgp"
+  }
+
+  bandwidth {
+    name        = "%s-bandwidth"
+    size        = 5
+    share_type  = "PER"
+    charge_mode = "traffic"
+  }
+
+  tags = local.common_tags
+}
+
+resource "huaweicloud_compute_eip_associate" "bastion" {
+  public_ip   = huaweicloud_vpc_eip.bastion.address
+  instance_id = huaweicloud_compute_instance.bastion.id
+}
+`, spec.Name, imageRef, spec.InstanceType, subnetZone(config, spec.Subnet), optional,
+		subnetAddress("huawei", spec.Subnet.Name), spec.Name, spec.Name))
+
+	bastion.WriteString(bastionOutputs("huaweicloud_vpc_eip.bastion.address", spec.Protocol == "rdp"))
+	return bastion.String()
+}
+
+// generateTencentBastion 生成腾讯云堡垒机实例、安全组和EIP
+func generateTencentBastion(spec bastionSpec) string {
+	var bastion strings.Builder
+
+	dataSource, imageRef := bastionImage("tencent", spec)
+	bastion.WriteString(dataSource)
+
+	var ingress strings.Builder
+	for _, cidr := range spec.AdminCidrs {
+		ingress.WriteString(fmt.Sprintf(`
+  ingress {
+    action      = "ACCEPT"
+    protocol    = "TCP"
+    port        = "%d"
+    cidr_block  = "%s"
+    description = "%s from admin CIDR"
+  }
+`, spec.Port, cidr, strings.ToUpper(spec.Protocol)))
+	}
+	bastion.WriteString(fmt.Sprintf(`resource "tencentcloud_security_group" "bastion" {
+  name        = "%s-sg"
+  description = "Allow %s to the bastion from admin CIDRs only"
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_security_group_rule_set" "bastion" {
+  security_group_id = tencentcloud_security_group.bastion.id
+%s
+  egress {
+    action      = "ACCEPT"
+    protocol    = "ALL"
+    port        = "ALL"
+    cidr_block  = "0.0.0.0/0"
+    description = "Allow all outbound traffic"
+  }
+}
+
+`, spec.Name, strings.ToUpper(spec.Protocol), ingress.String()))
+
+	optional := ""
+	if spec.Protocol == "ssh" {
+		bastion.WriteString(bastionCloudInit)
+		optional = bastionAttribute(23, "key_ids", fmt.Sprintf(`["%s"]`, spec.KeyName)) +
+			bastionAttribute(23, "user_data_raw", "local.bastion_cloud_init")
+	} else {
+		bastion.WriteString(bastionPassword())
+		optional = bastionAttribute(23, "password", "random_password.bastion.result")
+	}
+
+	subnet := subnetAddress("tencent", spec.Subnet.Name)
+	bastion.WriteString(fmt.Sprintf(`resource "tencentcloud_instance" "bastion" {
+  instance_name           = "%s"
+  availability_zone       = %s.availability_zone
+  image_id                = %s
+  instance_type           = "%s"
+  vpc_id                  = %s.vpc_id
+  subnet_id               = %s.id
+  orderly_security_groups = [tencentcloud_security_group.bastion.id]
+  system_disk_type        = "CLOUD_PREMIUM"
+  system_disk_size        = 50%s
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "tencentcloud_eip" "bastion" {
+  name = "%s-eip"
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_eip_association" "bastion" {
+  eip_id      = tencentcloud_eip.bastion.id
+  instance_id = tencentcloud_instance.bastion.id
+}
+`, spec.Name, subnet, imageRef, spec.InstanceType, subnet, subnet, optional, spec.Name, spec.Name))
+
+	bastion.WriteString(bastionOutputs("tencentcloud_eip.bastion.public_ip", spec.Protocol == "rdp"))
+	return bastion.String()
+}
+
+// generateVolcengineBastion 生成火山引擎堡垒机实例、安全组和EIP
+func generateVolcengineBastion(spec bastionSpec) string {
+	var bastion strings.Builder
+
+	dataSource, imageRef := bastionImage("volcengine", spec)
+	bastion.WriteString(dataSource)
+	bastion.WriteString(fmt.Sprintf(`resource "volcengine_security_group" "bastion" {
+  security_group_name = "%s-sg"
+  description         = "Allow %s to the bastion from admin CIDRs only"
+  vpc_id              = %s.id
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+`, spec.Name, strings.ToUpper(spec.Protocol), vpcAddress("volcengine", spec.Vpc.Name)))
+	for i, cidr := range spec.AdminCidrs {
+		bastion.WriteString(fmt.Sprintf(`
+resource "volcengine_security_group_rule" "bastion_%d" {
+  security_group_id = volcengine_security_group.bastion.id
+  direction         = "ingress"
+  protocol          = "tcp"
+  port_start        = %d
+  port_end          = %d
+  policy            = "accept"
+  priority          = 1
+  cidr_ip           = "%s"
+}
+`, i+1, spec.Port, spec.Port, cidr))
+	}
+	bastion.WriteString("\n")
+
+	optional := ""
+	if spec.Protocol == "ssh" {
+		bastion.WriteString(bastionCloudInit)
+		optional = bastionAttribute(20, "key_pair_name", fmt.Sprintf(`"%s"`, spec.KeyName)) +
+			bastionAttribute(20, "user_data", "base64encode(local.bastion_cloud_init)")
+	} else {
+		bastion.WriteString(bastionPassword())
+		optional = bastionAttribute(20, "password", "random_password.bastion.result")
+	}
+
+	bastion.WriteString(fmt.Sprintf(`resource "volcengine_ecs_instance" "bastion" {
+  instance_name        = "%s"
+  image_id             = %s
+  instance_type        = "%s"
+  subnet_id            = %s.id
+  security_group_ids   = [volcengine_security_group.bastion.id]
+  instance_charge_type = "PostPaid"
+  system_volume_type   = "ESSD_PL0"
+  system_volume_size   = 40%s
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+
+resource "volcengine_eip_address" "bastion" {
+  name         = "%s-eip"
+  billing_type = "PostPaidByTraffic"
+  bandwidth    = 5
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+
+resource "volcengine_eip_associate" "bastion" {
+  allocation_id = volcengine_eip_address.bastion.id
+  instance_id   = volcengine_ecs_instance.bastion.id
+  instance_type = "EcsInstance"
+}
+`, spec.Name, imageRef, spec.InstanceType, subnetAddress("volcengine", spec.Subnet.Name), optional, spec.Name))
+
+	bastion.WriteString(bastionOutputs("volcengine_eip_address.bastion.eip_address", spec.Protocol == "rdp"))
+	return bastion.String()
+}
@@ -0,0 +1,455 @@
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PlanSummaryFile 部署时保存的执行计划文本，随配置包一起下载
+const PlanSummaryFile = "plan.txt"
+
+// BundleOptions 控制配置包的格式和内容
+type BundleOptions struct {
+	Format       string // zip 或 tar.gz
+	IncludeState bool   // 是否包含状态文件，只允许管理员使用
+}
+
+// bundleSensitiveKeywords 名称中包含这些关键字的参数和变量按敏感值处理，不区分大小写
+var bundleSensitiveKeywords = []string{"password", "secret", "psk", "preshared_key", "private_key", "access_key", "token", "connection_string"}
+
+// bundleAttributeRegexp 匹配块中的参数行，包括嵌套块和多行对象中的参数
+var bundleAttributeRegexp = regexp.MustCompile(`^([ \t]+)([A-Za-z0-9_]+)(\s*=\s*)(.*?)\s*$`)
+
+// bundleTfvarsEntryRegexp 匹配tfvars中的顶层赋值
+var bundleTfvarsEntryRegexp = regexp.MustCompile(`^([A-Za-z0-9_-]+)\s*=\s*(.*?)\s*$`)
+
+// bundleHeredocRegexp 匹配heredoc的开始标记
+var bundleHeredocRegexp = regexp.MustCompile(`^<<-?([A-Za-z_][A-Za-z0-9_]*)$`)
+
+// bundleStringLiteralRegexp 匹配单行的字符串字面量
+var bundleStringLiteralRegexp = regexp.MustCompile(`^"(?:[^"\\]|\\.)*"$`)
+
+// bundleSensitiveFlagRegexp 匹配变量块中的sensitive = true
+var bundleSensitiveFlagRegexp = regexp.MustCompile(`(?m)^\s+sensitive\s*=\s*true\s*$`)
+
+// bundleVariableRegexp 匹配variables.tf中的变量名称和描述
+var bundleVariableRegexp = regexp.MustCompile(`(?s)variable "([A-Za-z0-9_-]+)" \{\n(.*?)\n\}`)
+
+// bundleDescriptionRegexp 匹配变量块中的description参数
+var bundleDescriptionRegexp = regexp.MustCompile(`(?m)^\s+description\s+= "((?:[^"\\]|\\.)*)"$`)
+
+// bundlePlanLineRegexp 匹配执行计划的汇总行
+var bundlePlanLineRegexp = regexp.MustCompile(`(?m)^(Plan: .*|No changes\..*)$`)
+
+// ansiEscapeRegexp 匹配Terraform输出中的颜色控制字符
+var ansiEscapeRegexp = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// bundleSensitiveVariable 从配置中提取出的敏感变量
+type bundleSensitiveVariable struct {
+	Name   string
+	Module string // 所在子模块的目录名，根模块为空
+}
+
+// SavePlanSummary 保存去掉颜色控制字符的执行计划输出，供下载配置包时使用
+func SavePlanSummary(output string, workDir string) error {
+	return SaveTerraformConfig(ansiEscapeRegexp.ReplaceAllString(output, ""), filepath.Join(workDir, PlanSummaryFile))
+}
+
+// BuildTerraformBundle 将部署工作目录中的配置打包，返回压缩包内容和文件名
+// 包含配置文件、依赖锁文件、执行计划摘要和说明文档；配置中的敏感字面量替换为变量，
+// 二进制执行计划和.terraform目录不会打包，状态文件只有IncludeState为true时打包
+func BuildTerraformBundle(workDir, deploymentID string, options BundleOptions) ([]byte, string, error) {
+	files, err := collectBundleFiles(workDir, options.IncludeState)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(files) == 0 {
+		return nil, "", fmt.Errorf("部署 %s 没有可下载的Terraform配置", deploymentID)
+	}
+
+	sensitive := redactBundleFiles(files)
+	files["README.md"] = []byte(renderBundleReadme(files, deploymentID, sensitive, options.IncludeState))
+
+	var archive []byte
+	name := fmt.Sprintf("deployment-%s", deploymentID)
+	switch options.Format {
+	case "", "zip":
+		archive, err = writeZipBundle(files, name)
+		name += ".zip"
+	case "tar.gz", "tgz":
+		archive, err = writeTarGzBundle(files, name)
+		name += ".tar.gz"
+	default:
+		return nil, "", fmt.Errorf("不支持的压缩格式 %s，可选值为 zip 或 tar.gz", options.Format)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	LogInfo(fmt.Sprintf("已打包部署 %s 的Terraform配置: 文件=%d, 敏感变量=%d, 包含状态=%t", deploymentID, len(files), len(sensitive), options.IncludeState))
+	return archive, name, nil
+}
+
+// collectBundleFiles 读取工作目录中需要打包的文件，返回以/分隔的相对路径到内容的映射
+func collectBundleFiles(workDir string, includeState bool) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.Walk(workDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(workDir, filePath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if info.IsDir() {
+			// provider插件和模块缓存可以通过terraform init重新下载
+			if rel == ".terraform" || (rel == "terraform.tfstate.d" && !includeState) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !bundleIncludes(rel, includeState) {
+			return nil
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		files[rel] = content
+		return nil
+	})
+	if err != nil {
+		LogError(fmt.Sprintf("读取部署目录 %s 失败: %v", workDir, err))
+		return nil, err
+	}
+	return files, nil
+}
+
+// bundleIncludes 判断文件是否需要打包，二进制执行计划中包含明文的敏感值，不会打包
+func bundleIncludes(rel string, includeState bool) bool {
+	base := path.Base(rel)
+	switch {
+	case strings.HasSuffix(base, ".tf"), strings.HasSuffix(base, ".tfvars"):
+		return true
+	case base == ".terraform.lock.hcl", rel == PlanSummaryFile:
+		return true
+	case strings.HasSuffix(base, ".tfstate"), strings.HasSuffix(base, ".tfstate.backup"):
+		return includeState
+	}
+	return false
+}
+
+// redactBundleFiles 将配置中敏感参数的字面量替换为sensitive变量，并删除tfvars中的敏感变量值
+// 子模块中的敏感变量同时在根模块中声明，并通过模块调用传入
+func redactBundleFiles(files map[string][]byte) []bundleSensitiveVariable {
+	var sensitive []bundleSensitiveVariable
+	declared := make(map[string]map[string]bool)
+	sensitiveVariables := bundleSensitiveVariables(string(files["variables.tf"]))
+	for _, rel := range sortedBundleNames(files) {
+		if strings.HasSuffix(rel, ".tfvars") {
+			files[rel] = []byte(redactBundleTfvars(string(files[rel]), sensitiveVariables))
+			continue
+		}
+		if !strings.HasSuffix(rel, ".tf") {
+			continue
+		}
+
+		module := ""
+		if parts := strings.Split(rel, "/"); len(parts) == 3 && parts[0] == "modules" {
+			module = parts[1]
+		} else if len(parts) > 1 {
+			continue
+		}
+
+		var redacted strings.Builder
+		for _, block := range parseHclBlocks(string(files[rel])) {
+			text := block.Text
+			if block.Kind == "resource" || block.Kind == "data" || block.Kind == "provider" {
+				text = redactBundleBlock(block, func(attribute string) string {
+					name := strings.Trim(strings.ReplaceAll(strings.Join([]string{block.Type, block.Name, attribute}, "_"), "-", "_"), "_")
+					if declared[module] == nil {
+						declared[module] = make(map[string]bool)
+					}
+					if !declared[module][name] {
+						declared[module][name] = true
+						sensitive = append(sensitive, bundleSensitiveVariable{Name: name, Module: module})
+					}
+					return name
+				})
+			}
+			redacted.WriteString(text)
+		}
+		files[rel] = []byte(redacted.String())
+	}
+
+	// 声明提取出的变量，子模块的变量由根模块传入
+	inputs := make(map[string][]string)
+	for _, variable := range sensitive {
+		declaration := fmt.Sprintf("\nvariable %q {\n  description = \"Sensitive value removed from the downloaded bundle\"\n  type        = string\n  sensitive   = true\n}\n", variable.Name)
+		if variable.Module == "" {
+			files["variables.tf"] = append(files["variables.tf"], declaration...)
+			continue
+		}
+		files["modules/"+variable.Module+"/variables.tf"] = append(files["modules/"+variable.Module+"/variables.tf"], declaration...)
+		rootName := variable.Module + "_" + variable.Name
+		files["variables.tf"] = append(files["variables.tf"], strings.Replace(declaration, fmt.Sprintf("%q", variable.Name), fmt.Sprintf("%q", rootName), 1)...)
+		inputs[variable.Module] = append(inputs[variable.Module], variable.Name)
+	}
+	for module, names := range inputs {
+		width := 0
+		for _, name := range names {
+			if len(name) > width {
+				width = len(name)
+			}
+		}
+		var lines strings.Builder
+		lines.WriteString("\n")
+		for _, name := range names {
+			lines.WriteString(fmt.Sprintf("  %-*s = var.%s_%s\n", width, name, module, name))
+		}
+		call := string(files[module+".tf"])
+		if end := strings.LastIndex(call, "}"); end >= 0 {
+			files[module+".tf"] = []byte(call[:end] + lines.String() + call[end:])
+		}
+	}
+	return sensitive
+}
+
+// redactBundleBlock 将块中敏感参数的字面量值（字符串或heredoc）替换为变量引用，
+// declare返回参数对应的变量名；引用其他资源或包含插值的值不是明文，保持原样
+func redactBundleBlock(block hclBlock, declare func(attribute string) string) string {
+	lines := strings.SplitAfter(block.Text, "\n")
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\n")
+		match := bundleAttributeRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		end, literal := bundleValueEnd(lines, i, match[4])
+		if literal && bundleSensitiveAttribute(block.Type, match[2]) {
+			lines[i] = match[1] + match[2] + match[3] + "var." + declare(match[2]) + lines[i][len(line):]
+			for j := i + 1; j <= end; j++ {
+				lines[j] = ""
+			}
+		}
+		// heredoc中的内容不是参数，多行对象中的参数继续检查
+		if bundleHeredocRegexp.MatchString(match[4]) {
+			i = end
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// redactBundleTfvars 删除tfvars中敏感变量的赋值，包括跨多行的值
+func redactBundleTfvars(tfvars string, sensitiveVariables map[string]bool) string {
+	lines := strings.SplitAfter(tfvars, "\n")
+	var redacted strings.Builder
+	for i := 0; i < len(lines); i++ {
+		match := bundleTfvarsEntryRegexp.FindStringSubmatch(strings.TrimRight(lines[i], "\n"))
+		if match == nil {
+			redacted.WriteString(lines[i])
+			continue
+		}
+		end, _ := bundleValueEnd(lines, i, match[2])
+		if !sensitiveVariables[match[1]] && !bundleSensitiveName(match[1]) {
+			redacted.WriteString(strings.Join(lines[i:end+1], ""))
+		}
+		i = end
+	}
+	return redacted.String()
+}
+
+// bundleSensitiveVariables 返回variables.tf中声明为sensitive的变量
+func bundleSensitiveVariables(variables string) map[string]bool {
+	names := make(map[string]bool)
+	for _, variable := range bundleVariableRegexp.FindAllStringSubmatch(variables, -1) {
+		if bundleSensitiveFlagRegexp.MatchString(variable[2]) {
+			names[variable[1]] = true
+		}
+	}
+	return names
+}
+
+// bundleSensitiveAttribute 判断资源参数是否敏感，provider标记为敏感的属性和名称包含敏感关键字的参数都按敏感处理
+func bundleSensitiveAttribute(resourceType, attribute string) bool {
+	return sensitiveAttributes[attribute] || sensitiveAttributes[resourceType+"."+attribute] || bundleSensitiveName(attribute)
+}
+
+// bundleSensitiveName 判断名称是否包含敏感关键字
+func bundleSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, keyword := range bundleSensitiveKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// bundleValueEnd 返回从第start行开始的值所在的最后一行，以及值是否为不含插值的字面量
+// heredoc到结束标记为止，对象和列表到括号闭合为止
+func bundleValueEnd(lines []string, start int, value string) (int, bool) {
+	if match := bundleHeredocRegexp.FindStringSubmatch(value); match != nil {
+		for end := start + 1; end < len(lines); end++ {
+			if strings.TrimSpace(lines[end]) == match[1] {
+				return end, !hasInterpolation(strings.Join(lines[start+1:end], ""))
+			}
+		}
+		return start, false
+	}
+	if bundleStringLiteralRegexp.MatchString(value) {
+		return start, !hasInterpolation(value)
+	}
+
+	end, depth := start, bracketDepth(value)
+	for depth > 0 && end+1 < len(lines) {
+		end++
+		depth += bracketDepth(lines[end])
+	}
+	return end, false
+}
+
+// bracketDepth 统计一行中未闭合的括号数量，忽略字符串中的括号
+func bracketDepth(line string) int {
+	depth, inString := 0, false
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{' || c == '[' || c == '(':
+			depth++
+		case c == '}' || c == ']' || c == ')':
+			depth--
+		}
+	}
+	return depth
+}
+
+// hasInterpolation 判断文本中是否包含未转义的Terraform插值或指令
+func hasInterpolation(text string) bool {
+	text = strings.NewReplacer("$${", "", "%%{", "").Replace(text)
+	return strings.Contains(text, "${") || strings.Contains(text, "%{")
+}
+
+// renderBundleReadme 生成说明文档，列出根模块的输入变量和执行计划摘要
+func renderBundleReadme(files map[string][]byte, deploymentID string, sensitive []bundleSensitiveVariable, includeState bool) string {
+	var readme strings.Builder
+	readme.WriteString(fmt.Sprintf("# Terraform configuration for deployment %s\n\n", deploymentID))
+	readme.WriteString(fmt.Sprintf("Generated by multi-cloud-landing-zone on %s.\n\n", time.Now().UTC().Format(time.RFC3339)))
+	readme.WriteString("## Usage\n\n```bash\nterraform init\nterraform plan\nterraform apply\n```\n\n")
+	readme.WriteString("Provider plugins are not included; `terraform init` downloads the versions pinned in `.terraform.lock.hcl`.\n\n")
+
+	readme.WriteString("## Inputs\n\n")
+	tfvars := string(files["terraform.tfvars"])
+	variables := bundleVariableRegexp.FindAllStringSubmatch(string(files["variables.tf"]), -1)
+	if len(variables) == 0 {
+		readme.WriteString("This configuration has no input variables.\n\n")
+	} else {
+		readme.WriteString("| Name | Description | Sensitive | Set in terraform.tfvars |\n|------|-------------|-----------|-------------------------|\n")
+		for _, variable := range variables {
+			description := ""
+			if match := bundleDescriptionRegexp.FindStringSubmatch(variable[2]); match != nil {
+				description = match[1]
+			}
+			isSensitive := bundleSensitiveFlagRegexp.MatchString(variable[2])
+			inTfvars := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(variable[1]) + `\s*=`).MatchString(tfvars)
+			readme.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", variable[1], description, yesNo(isSensitive), yesNo(inTfvars)))
+		}
+		readme.WriteString("\n")
+	}
+	if len(sensitive) > 0 {
+		readme.WriteString("Sensitive values were removed from this bundle. Provide them before planning, for example with `TF_VAR_<name>` environment variables.\n\n")
+	}
+
+	readme.WriteString("## Plan summary\n\n")
+	if plan, ok := files[PlanSummaryFile]; ok {
+		if match := bundlePlanLineRegexp.Find(ansiEscapeRegexp.ReplaceAll(plan, nil)); match != nil {
+			readme.WriteString(fmt.Sprintf("%s\n\n", match))
+		}
+		readme.WriteString(fmt.Sprintf("The full plan output recorded during deployment is in `%s`.\n\n", PlanSummaryFile))
+	} else {
+		readme.WriteString("No plan was recorded for this deployment.\n\n")
+	}
+
+	if includeState {
+		readme.WriteString("## State\n\nThis bundle contains Terraform state files, which may hold secrets in plain text. Store it securely.\n")
+	} else {
+		readme.WriteString("## State\n\nState files are not included.\n")
+	}
+	return readme.String()
+}
+
+// yesNo 将布尔值显示为yes或no
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// writeZipBundle 将文件写入zip压缩包，所有文件放在name目录下
+func writeZipBundle(files map[string][]byte, name string) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, rel := range sortedBundleNames(files) {
+		header := &zip.FileHeader{Name: name + "/" + rel, Method: zip.Deflate, Modified: time.Now()}
+		header.SetMode(0644)
+		entry, err := writer.CreateHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := entry.Write(files[rel]); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// writeTarGzBundle 将文件写入tar.gz压缩包，所有文件放在name目录下
+func writeTarGzBundle(files map[string][]byte, name string) ([]byte, error) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, rel := range sortedBundleNames(files) {
+		header := &tar.Header{Name: name + "/" + rel, Mode: 0644, Size: int64(len(files[rel])), ModTime: time.Now()}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tarWriter.Write(files[rel]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// sortedBundleNames 返回排序后的文件路径，保证压缩包内容的顺序稳定
+func sortedBundleNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactBundleFiles(t *testing.T) {
+	files := map[string][]byte{
+		"main.tf": []byte(`resource "aws_db_instance" "database" {
+  identifier = "app"
+  password   = "hunter2"
+}
+
+resource "azurerm_function_app" "api" {
+  app_settings = {
+    DB_PASSWORD = "hunter2"
+    REGION      = "eastus"
+  }
+  connection_string = <<EOT
+Server=db;Password=hunter2
+EOT
+}
+
+resource "aws_instance" "web" {
+  user_data = <<EOT
+password = "not an attribute"
+EOT
+  secret_key = random_password.key.result
+  auth_token = "token-${var.suffix}"
+}
+`),
+		"variables.tf": []byte(`variable "db_admin" {
+  description = "Database administrator"
+  type        = string
+  sensitive   = true
+}
+`),
+		"terraform.tfvars": []byte("region = \"us-east-1\"\ndb_admin = \"root\"\napi_token = <<EOT\nabc\nEOT\ntags = {\n  owner = \"net-team\"\n}\n"),
+	}
+	sensitive := redactBundleFiles(files)
+
+	main := string(files["main.tf"])
+	for _, want := range []string{
+		"  password   = var.aws_db_instance_database_password\n",
+		"    DB_PASSWORD = var.azurerm_function_app_api_DB_PASSWORD\n",
+		"  connection_string = var.azurerm_function_app_api_connection_string\n}",
+		"password = \"not an attribute\"\n",
+		"  secret_key = random_password.key.result\n",
+		"  auth_token = \"token-${var.suffix}\"\n",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.tf does not contain %q:\n%s", want, main)
+		}
+	}
+	if strings.Contains(main, "Password=hunter2") || strings.Contains(main, `"hunter2"`) {
+		t.Errorf("main.tf still contains a sensitive literal:\n%s", main)
+	}
+	if len(sensitive) != 3 {
+		t.Errorf("redactBundleFiles() extracted %d variables, want 3: %v", len(sensitive), sensitive)
+	}
+
+	if got, want := string(files["terraform.tfvars"]), "region = \"us-east-1\"\ntags = {\n  owner = \"net-team\"\n}\n"; got != want {
+		t.Errorf("terraform.tfvars =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,446 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// defaultCacheEngineVersions 各云提供商默认的Redis版本
+var defaultCacheEngineVersions = map[string]string{
+	"aws":      "7.0",
+	"azure":    "6",
+	"alicloud": "7.0",
+	"huawei":   "6.0",
+	"tencent":  "6.2",
+}
+
+// defaultCacheNodeTypes 各云提供商默认的Redis规格，华为云和腾讯云按容量选择规格
+var defaultCacheNodeTypes = map[string]string{
+	"aws":      "cache.t3.medium",
+	"azure":    "C1",
+	"alicloud": "redis.master.small.default",
+	"huawei":   "",
+	"tencent":  "",
+}
+
+// tencentRedisTypeIds 腾讯云Redis标准架构版本对应的type_id
+var tencentRedisTypeIds = map[string]int{
+	"4.0": 6,
+	"5.0": 8,
+	"6.2": 15,
+	"7.0": 17,
+}
+
+// azureRedisSkuRegexp Azure Redis规格，例如 C1 或 P2
+var azureRedisSkuRegexp = regexp.MustCompile(`^([CP])([0-6])$`)
+
+// cacheSpec 表示缓存组件的解析结果
+type cacheSpec struct {
+	Name           string
+	EngineVersion  string
+	NodeType       string
+	Capacity       int // 缓存容量（GB），华为云和腾讯云使用
+	MultiAz        bool
+	SecondaryZone  string
+	Port           int
+	SecurityGroups []string // 安全组组件中定义的安全组引用
+	Subnets        []models.Subnet
+	Zones          []string
+}
+
+// generateCacheConfig 生成托管Redis缓存组件的Terraform配置：ElastiCache、Azure Cache for Redis、阿里云Redis、DCS和TencentDB for Redis
+// 缓存只部署在私有子网中，访问密码由random_password生成；Azure不支持自定义密码，使用自动生成的访问密钥
+func generateCacheConfig(config renderContext, propsMap map[string]interface{}) string {
+	provider := config.CloudProvider
+	if _, ok := defaultCacheEngineVersions[provider]; !ok {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持缓存组件", provider))
+		return ""
+	}
+
+	spec := cacheSpec{
+		Name:           strings.ToLower(getStringProp(propsMap, "name", "landing-zone-redis")),
+		EngineVersion:  getStringProp(propsMap, "engine_version", defaultCacheEngineVersions[provider]),
+		NodeType:       getStringProp(propsMap, "node_type", defaultCacheNodeTypes[provider]),
+		Capacity:       getIntProp(propsMap, "capacity", 1),
+		MultiAz:        getBoolProp(propsMap, "multi_az", true),
+		SecondaryZone:  getStringProp(propsMap, "secondary_zone", ""),
+		Port:           6379,
+		SecurityGroups: resolveSecurityGroupRefs(config, propsMap),
+		Subnets:        selectPrivateSubnets(config, getStringListProp(propsMap, "subnets")),
+	}
+	// 华为云和腾讯云默认按容量选择规格，规格为空时不校验
+	err := checkPropertyToken("engine_version", spec.EngineVersion)
+	if err == nil && spec.NodeType != "" {
+		err = checkPropertyToken("node_type", spec.NodeType)
+	}
+	if err == nil && spec.SecondaryZone != "" {
+		err = checkPropertyToken("secondary_zone", spec.SecondaryZone)
+	}
+	if err != nil {
+		reportError(config, fmt.Sprintf("缓存 %s 的配置无效，跳过生成: %v", spec.Name, err))
+		return ""
+	}
+	if len(spec.Subnets) == 0 {
+		reportError(config, fmt.Sprintf("缓存 %s 没有可用的私有子网，跳过生成", spec.Name))
+		return ""
+	}
+	if spec.Capacity < 1 {
+		spec.Capacity = 1
+	}
+
+	// 备可用区默认使用第二个子网所在的可用区，与托管数据库组件一致
+	spec.Zones = databaseSubnetZones(config, spec.Subnets)
+	if spec.SecondaryZone == "" && len(spec.Zones) > 1 {
+		spec.SecondaryZone = spec.Zones[1]
+	}
+	if spec.MultiAz && spec.SecondaryZone == "" && provider != "aws" && provider != "azure" {
+		reportWarn(config, fmt.Sprintf("缓存 %s 未指定备可用区(secondary_zone)，将部署为单可用区实例", spec.Name))
+		spec.MultiAz = false
+	}
+
+	var cacheConfig strings.Builder
+	if provider != "azure" {
+		cacheConfig.WriteString(`resource "random_password" "cache" {
+  length           = 24
+  special          = true
+  override_special = "!#^*-_=+"
+  min_lower        = 2
+  min_upper        = 2
+  min_numeric      = 2
+  min_special      = 2
+}
+
+`)
+	}
+
+	vpc := subnetVpc(config, spec.Subnets[0])
+	switch provider {
+	case "aws":
+		cacheConfig.WriteString(generateAwsCache(config, spec, vpc))
+	case "azure":
+		cacheConfig.WriteString(generateAzureCache(config, spec, vpc))
+	case "alicloud":
+		cacheConfig.WriteString(generateAlicloudCache(config, spec, vpc))
+	case "huawei":
+		cacheConfig.WriteString(generateHuaweiCache(config, spec, vpc))
+	case "tencent":
+		cacheConfig.WriteString(generateTencentCache(config, spec, vpc))
+	}
+
+	if provider != "azure" {
+		cacheConfig.WriteString(`
+output "cache_auth_token" {
+  value     = random_password.cache.result
+  sensitive = true
+}
+`)
+	}
+
+	LogInfo(fmt.Sprintf("已生成缓存配置: 名称=%s, Redis %s, 多可用区=%t, 子网=%d", spec.Name, spec.EngineVersion, spec.MultiAz, len(spec.Subnets)))
+	return cacheConfig.String()
+}
+
+// generateAwsCache 生成ElastiCache Redis复制组，开启传输加密和AUTH令牌
+func generateAwsCache(config renderContext, spec cacheSpec, vpc models.VPC) string {
+	subnetIds := make([]string, 0, len(spec.Subnets))
+	for _, subnet := range spec.Subnets {
+		subnetIds = append(subnetIds, subnetAddress("aws", subnet.Name)+".id")
+	}
+
+	// 多可用区需要子网覆盖至少两个可用区，否则只保留同可用区的副本
+	numCacheClusters, multiAzEnabled := 1, false
+	if spec.MultiAz {
+		numCacheClusters = 2
+		multiAzEnabled = len(spec.Zones) > 1
+		if !multiAzEnabled {
+			reportWarn(config, fmt.Sprintf("缓存 %s 的子网只覆盖了1个可用区，副本将部署在同一可用区", spec.Name))
+		}
+	}
+
+	// 未引用安全组组件中的安全组时，创建只允许VPC内访问Redis端口的安全组
+	securityGroupRefs := spec.SecurityGroups
+	securityGroupConfig := ""
+	if len(securityGroupRefs) == 0 {
+		securityGroupRefs = []string{"aws_security_group.cache_sg.id"}
+		securityGroupConfig = fmt.Sprintf(`resource "aws_security_group" "cache_sg" {
+  name        = "%s-sg"
+  description = "Allow Redis access from within the VPC"
+  vpc_id      = %s.id
+
+  ingress {
+    description = "Redis from VPC"
+    from_port   = %d
+    to_port     = %d
+    protocol    = "tcp"
+    cidr_blocks = ["%s"]
+  }
+
+  tags = {
+    Name = "%s-sg"
+  }
+}
+
+`, hclStringContent(spec.Name), vpcAddress("aws", vpc.Name), spec.Port, spec.Port, vpc.CIDR, hclStringContent(spec.Name))
+	}
+
+	return fmt.Sprintf(`resource "aws_elasticache_subnet_group" "cache" {
+  name       = "%s-subnet-group"
+  subnet_ids = %s
+
+  tags = {
+    Name = "%s-subnet-group"
+  }
+}
+
+%sresource "aws_elasticache_replication_group" "cache" {
+  replication_group_id       = "%s"
+  description                = "Redis cache for the landing zone"
+  engine                     = "redis"
+  engine_version             = "%s"
+  node_type                  = "%s"
+  port                       = %d
+  num_cache_clusters         = %d
+  automatic_failover_enabled = %t
+  multi_az_enabled           = %t
+  subnet_group_name          = aws_elasticache_subnet_group.cache.name
+  security_group_ids         = %s
+  at_rest_encryption_enabled = true
+  transit_encryption_enabled = true
+  auth_token                 = random_password.cache.result
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+output "cache_endpoint" {
+  value = aws_elasticache_replication_group.cache.primary_endpoint_address
+}
+`, hclStringContent(spec.Name), hclList(subnetIds), hclStringContent(spec.Name), securityGroupConfig,
+		hclStringContent(spec.Name), spec.EngineVersion, spec.NodeType, spec.Port, numCacheClusters, spec.MultiAz,
+		multiAzEnabled, hclList(securityGroupRefs), hclStringContent(spec.Name))
+}
+
+// generateAzureCache 生成Azure Cache for Redis，关闭公网访问并通过私有终结点接入私有子网
+func generateAzureCache(config renderContext, spec cacheSpec, vpc models.VPC) string {
+	matches := azureRedisSkuRegexp.FindStringSubmatch(strings.ToUpper(spec.NodeType))
+	if matches == nil {
+		reportWarn(config, fmt.Sprintf("Azure Redis规格 %s 无效，使用 C1", spec.NodeType))
+		matches = []string{"C1", "C", "1"}
+	}
+	family, capacity := matches[1], matches[2]
+	// Basic没有副本，多可用区时使用带副本的Standard；P系列规格只能使用Premium
+	skuName := "Basic"
+	if family == "P" {
+		skuName = "Premium"
+	} else if spec.MultiAz {
+		skuName = "Standard"
+	}
+
+	return fmt.Sprintf(`resource "azurerm_redis_cache" "cache" {
+  name                          = "%s"
+  location                      = azurerm_resource_group.rg.location
+  resource_group_name           = azurerm_resource_group.rg.name
+  sku_name                      = "%s"
+  family                        = "%s"
+  capacity                      = %s
+  redis_version                 = "%s"
+  enable_non_ssl_port           = false
+  minimum_tls_version           = "1.2"
+  public_network_access_enabled = false
+
+  tags = local.common_tags
+}
+
+%s
+output "cache_endpoint" {
+  value = azurerm_redis_cache.cache.hostname
+}
+
+output "cache_auth_token" {
+  value     = azurerm_redis_cache.cache.primary_access_key
+  sensitive = true
+}
+`, hclStringContent(spec.Name), skuName, family, capacity, spec.EngineVersion,
+		generateAzurePrivateEndpoint("cache", hclStringContent(spec.Name), "azurerm_redis_cache.cache", "redisCache",
+			"privatelink.redis.cache.windows.net", spec.Subnets[0], vpc))
+}
+
+// generateAzurePrivateEndpoint 在私有子网中为PaaS服务创建私有终结点，并通过专用DNS区域解析到私有地址
+// 缓存和消息队列组件共用
+func generateAzurePrivateEndpoint(label, name, resourceAddress, subresource, dnsZone string, subnet models.Subnet, vpc models.VPC) string {
+	return fmt.Sprintf(`resource "azurerm_private_dns_zone" "%s" {
+  name                = "%s"
+  resource_group_name = azurerm_resource_group.rg.name
+
+  tags = local.common_tags
+}
+
+resource "azurerm_private_dns_zone_virtual_network_link" "%s" {
+  name                  = "%s-dns-link"
+  private_dns_zone_name = azurerm_private_dns_zone.%s.name
+  virtual_network_id    = %s.id
+  resource_group_name   = azurerm_resource_group.rg.name
+
+  tags = local.common_tags
+}
+
+resource "azurerm_private_endpoint" "%s" {
+  name                = "%s-pe"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+  subnet_id           = %s.id
+
+  private_service_connection {
+    name                           = "%s-psc"
+    private_connection_resource_id = %s.id
+    subresource_names              = ["%s"]
+    is_manual_connection           = false
+  }
+
+  private_dns_zone_group {
+    name                 = "%s"
+    private_dns_zone_ids = [azurerm_private_dns_zone.%s.id]
+  }
+
+  tags = local.common_tags
+}
+`, label, dnsZone, label, name, label, vpcAddress("azure", vpc.Name),
+		label, name, subnetAddress("azure", subnet.Name), name, resourceAddress, subresource, label, label)
+}
+
+// generateAlicloudCache 生成阿里云Redis实例，只允许VPC网段访问
+func generateAlicloudCache(config renderContext, spec cacheSpec, vpc models.VPC) string {
+	zoneLines := fmt.Sprintf(`
+  zone_id           = "%s"`, spec.Zones[0])
+	if spec.MultiAz {
+		zoneLines += fmt.Sprintf(`
+  secondary_zone_id = "%s"`, spec.SecondaryZone)
+	}
+
+	// 阿里云Redis实例只能绑定一个安全组
+	securityGroupLine := ""
+	if len(spec.SecurityGroups) > 0 {
+		if len(spec.SecurityGroups) > 1 {
+			reportWarn(config, "阿里云Redis实例只能绑定一个安全组，仅使用第一个")
+		}
+		securityGroupLine = fmt.Sprintf(`
+  security_group_id = %s`, spec.SecurityGroups[0])
+	}
+
+	return fmt.Sprintf(`resource "alicloud_kvstore_instance" "cache" {
+  db_instance_name  = "%s"
+  instance_type     = "Redis"
+  engine_version    = "%s"
+  instance_class    = "%s"%s
+  vswitch_id        = %s.id
+  password          = random_password.cache.result
+  security_ips      = ["%s"]%s
+  payment_type      = "PostPaid"
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+output "cache_endpoint" {
+  value = alicloud_kvstore_instance.cache.connection_domain
+}
+`, hclStringContent(spec.Name), spec.EngineVersion, spec.NodeType, zoneLines, subnetAddress("alicloud", spec.Subnets[0].Name),
+		vpc.CIDR, securityGroupLine, hclStringContent(spec.Name))
+}
+
+// generateHuaweiCache 生成华为云DCS Redis实例，通过白名单只允许VPC网段访问
+func generateHuaweiCache(config renderContext, spec cacheSpec, vpc models.VPC) string {
+	availabilityZones := []string{config.AZ}
+	flavor := spec.NodeType
+	if spec.MultiAz {
+		availabilityZones = append(availabilityZones, spec.SecondaryZone)
+		if flavor == "" {
+			flavor = fmt.Sprintf("redis.ha.xu1.large.r2.%d", spec.Capacity)
+		}
+	} else if flavor == "" {
+		flavor = fmt.Sprintf("redis.single.xu1.large.%d", spec.Capacity)
+	}
+	if len(spec.SecurityGroups) > 0 {
+		reportWarn(config, "华为云DCS Redis 4.0及以上版本不支持安全组，已忽略security_groups，使用VPC网段白名单")
+	}
+
+	return fmt.Sprintf(`resource "huaweicloud_dcs_instance" "cache" {
+  name               = "%s"
+  engine             = "Redis"
+  engine_version     = "%s"
+  capacity           = %d
+  flavor             = "%s"
+  availability_zones = %s
+  vpc_id             = %s.id
+  subnet_id          = %s.id
+  port               = %d
+  password           = random_password.cache.result
+
+  whitelists {
+    group_name = "vpc"
+    ip_address = ["%s"]
+  }
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+output "cache_endpoint" {
+  value = huaweicloud_dcs_instance.cache.domain_name
+}
+`, hclStringContent(spec.Name), spec.EngineVersion, spec.Capacity, flavor, hclStringList(availabilityZones),
+		vpcAddress("huawei", vpc.Name), subnetAddress("huawei", spec.Subnets[0].Name), spec.Port, vpc.CIDR, hclStringContent(spec.Name))
+}
+
+// generateTencentCache 生成TencentDB for Redis标准架构实例，副本部署在备可用区
+func generateTencentCache(config renderContext, spec cacheSpec, vpc models.VPC) string {
+	typeId, ok := tencentRedisTypeIds[spec.EngineVersion]
+	if !ok {
+		reportWarn(config, fmt.Sprintf("腾讯云Redis不支持版本 %s，使用 6.2", spec.EngineVersion))
+		typeId = tencentRedisTypeIds["6.2"]
+	}
+	replicaZone := config.AZ
+	if spec.MultiAz {
+		replicaZone = spec.SecondaryZone
+	}
+	if spec.NodeType != "" {
+		reportWarn(config, "腾讯云Redis按容量(capacity)选择规格，已忽略node_type")
+	}
+
+	securityGroupLine := ""
+	if len(spec.SecurityGroups) > 0 {
+		securityGroupLine = fmt.Sprintf(`
+  security_groups    = %s`, hclList(spec.SecurityGroups))
+	}
+
+	return fmt.Sprintf(`resource "tencentcloud_redis_instance" "cache" {
+  name               = "%s"
+  availability_zone  = "%s"
+  type_id            = %d
+  mem_size           = %d
+  redis_shard_num    = 1
+  redis_replicas_num = 1
+  replica_zone_ids   = ["%s"]
+  vpc_id             = %s.id
+  subnet_id          = %s.id
+  port               = %d
+  password           = random_password.cache.result
+  charge_type        = "POSTPAID"%s
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+output "cache_endpoint" {
+  value = tencentcloud_redis_instance.cache.ip
+}
+`, hclStringContent(spec.Name), config.AZ, typeId, spec.Capacity*1024, replicaZone, vpcAddress("tencent", vpc.Name),
+		subnetAddress("tencent", spec.Subnets[0].Name), spec.Port, securityGroupLine, hclStringContent(spec.Name))
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCacheConfigUserStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		region   string
+		props    map[string]interface{}
+		want     string
+		wantErr  bool
+	}{
+		{"aws name is escaped", "aws", "us-east-1", map[string]interface{}{"name": `redis" } x = "${file("/etc/passwd")}`}, `replication_group_id       = "redis\" } x = \"$${file(\"/etc/passwd\")}"`, false},
+		{"huawei default node type", "huawei", "cn-north-4", map[string]interface{}{}, `resource "huaweicloud_dcs_instance" "cache"`, false},
+		{"engine version with quotes", "aws", "us-east-1", map[string]interface{}{"engine_version": `7.0" }`}, "", true},
+		{"node type with spaces", "alicloud", "cn-hangzhou", map[string]interface{}{"node_type": "redis master"}, "", true},
+		{"secondary zone with quotes", "alicloud", "cn-hangzhou", map[string]interface{}{"secondary_zone": `cn-hangzhou-b"`}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig(tt.provider, tt.region))
+			body := generateCacheConfig(config, tt.props)
+			if got := HasErrorFindings(config.findings.Findings()); got != tt.wantErr {
+				t.Fatalf("error findings = %v, want %v: %v", got, tt.wantErr, config.findings.Findings())
+			}
+			if tt.wantErr {
+				if body != "" {
+					t.Errorf("invalid cache should not be rendered:\n%s", body)
+				}
+				return
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("config does not contain %q:\n%s", tt.want, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,610 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// defaultInstanceTypes 各云提供商默认的实例规格
+var defaultInstanceTypes = map[string]string{
+	"aws":        "t3.micro",
+	"azure":      "Standard_B1s",
+	"alicloud":   "ecs.g6.large",
+	"baidu":      "bcc.g5.c2m8",
+	"huawei":     "s6.small.1",
+	"tencent":    "S5.MEDIUM2",
+	"volcengine": "ecs.g1ie.large",
+}
+
+// defaultOsVersions 各操作系统默认的版本
+var defaultOsVersions = map[string]string{
+	"ubuntu":       "22.04",
+	"debian":       "12",
+	"centos":       "7.9",
+	"amazon-linux": "2023",
+}
+
+// osDisplayNames 操作系统在各云镜像名称中的写法
+var osDisplayNames = map[string]string{
+	"ubuntu": "Ubuntu",
+	"debian": "Debian",
+	"centos": "CentOS",
+}
+
+// computeSpec 表示计算实例组件的解析结果
+type computeSpec struct {
+	Name             string
+	InstanceType     string
+	InstanceCount    int
+	OsFamily         string
+	OsVersion        string
+	ImageId          string
+	KeyName          string
+	SshPublicKey     string
+	SecurityGroupIds []string
+	SecurityGroups   []string // 安全组组件中定义的安全组引用
+	SystemDiskSize   int
+	Subnets          []models.Subnet
+}
+
+// generateComputeConfig 生成跨云计算实例组件的Terraform配置
+func generateComputeConfig(config renderContext, propsMap map[string]interface{}) string {
+	provider := config.CloudProvider
+	if _, ok := defaultInstanceTypes[provider]; !ok {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持计算实例组件", provider))
+		return ""
+	}
+
+	spec := computeSpec{
+		Name:             getStringProp(propsMap, "name", "compute"),
+		InstanceType:     getStringProp(propsMap, "instance_type", defaultInstanceTypes[provider]),
+		InstanceCount:    getIntProp(propsMap, "instance_count", 1),
+		OsFamily:         strings.ToLower(getStringProp(propsMap, "os_family", "ubuntu")),
+		ImageId:          getStringProp(propsMap, "image_id", ""),
+		KeyName:          getStringProp(propsMap, "key_name", ""),
+		SshPublicKey:     getStringProp(propsMap, "ssh_public_key", ""),
+		SecurityGroupIds: getStringListProp(propsMap, "security_group_ids"),
+		SecurityGroups:   resolveSecurityGroupRefs(config, propsMap),
+		SystemDiskSize:   getIntProp(propsMap, "system_disk_size", 40),
+		Subnets:          selectPrivateSubnets(config, getStringListProp(propsMap, "subnets")),
+	}
+	if len(spec.Subnets) == 0 {
+		reportError(config, fmt.Sprintf("计算实例 %s 没有可用的私有子网，跳过生成", spec.Name))
+		return ""
+	}
+	if _, ok := defaultOsVersions[spec.OsFamily]; !ok {
+		reportWarn(config, fmt.Sprintf("不支持的操作系统 %s，使用默认的 ubuntu", spec.OsFamily))
+		spec.OsFamily = "ubuntu"
+	}
+	if spec.OsFamily == "amazon-linux" && provider != "aws" {
+		reportWarn(config, fmt.Sprintf("操作系统 amazon-linux 仅支持AWS，云提供商 %s 使用默认的 ubuntu", provider))
+		spec.OsFamily = "ubuntu"
+	}
+	spec.OsVersion = getStringProp(propsMap, "os_version", defaultOsVersions[spec.OsFamily])
+	err := checkPropertyToken("instance_type", spec.InstanceType)
+	if err == nil {
+		err = checkPropertyToken("os_version", spec.OsVersion)
+	}
+	if err != nil {
+		reportError(config, fmt.Sprintf("计算实例 %s 的配置无效，跳过生成: %v", spec.Name, err))
+		return ""
+	}
+	if spec.InstanceCount < 1 {
+		spec.InstanceCount = 1
+	}
+
+	var computeConfig strings.Builder
+
+	// 未指定镜像ID时，通过镜像数据源按操作系统和版本查找
+	imageRef := hclString(spec.ImageId)
+	if spec.ImageId == "" && provider != "azure" {
+		dataSource, ref := generateComputeImageDataSource(provider, "compute_image", spec.OsFamily, spec.OsVersion)
+		computeConfig.WriteString(dataSource)
+		imageRef = ref
+	}
+
+	// 未指定安全组时，创建仅允许出站流量的默认安全组
+	securityGroupRefs := append([]string{}, spec.SecurityGroups...)
+	for _, id := range spec.SecurityGroupIds {
+		securityGroupRefs = append(securityGroupRefs, hclString(id))
+	}
+	if len(securityGroupRefs) == 0 && provider != "azure" {
+		vpc := subnetVpc(config, spec.Subnets[0])
+		sgConfig, sgRef := generateComputeDefaultSecurityGroup(provider, hclStringContent(spec.Name), vpcAddress(provider, vpc.Name))
+		computeConfig.WriteString(sgConfig)
+		securityGroupRefs = append(securityGroupRefs, sgRef)
+	}
+
+	// 实例按 count.index 轮流放置到所选子网中
+	subnetIds := make([]string, 0, len(spec.Subnets))
+	for _, subnet := range spec.Subnets {
+		subnetIds = append(subnetIds, subnetAddress(provider, subnet.Name)+".id")
+	}
+	subnetRef := fmt.Sprintf("element(%s, count.index)", hclList(subnetIds))
+
+	switch provider {
+	case "aws":
+		keyLine := ""
+		if spec.KeyName != "" {
+			keyLine = fmt.Sprintf("\n  key_name               = %s", hclString(spec.KeyName))
+		}
+		computeConfig.WriteString(fmt.Sprintf(`resource "aws_instance" "compute" {
+  count                  = %d
+  ami                    = %s
+  instance_type          = "%s"
+  subnet_id              = %s
+  vpc_security_group_ids = %s%s
+
+  root_block_device {
+    volume_size = %d
+    volume_type = "gp3"
+    encrypted   = true
+  }
+
+  tags = {
+    Name = "%s-${count.index + 1}"
+  }
+}
+`, spec.InstanceCount, imageRef, spec.InstanceType, subnetRef, hclList(securityGroupRefs), keyLine,
+			spec.SystemDiskSize, hclStringContent(spec.Name)))
+
+	case "azure":
+		computeConfig.WriteString(generateAzureCompute(config, spec, subnetRef))
+
+	case "alicloud":
+		zones := make([]string, 0, len(spec.Subnets))
+		for _, subnet := range spec.Subnets {
+			zones = append(zones, subnetAddress(provider, subnet.Name)+".zone_id")
+		}
+		keyLine := ""
+		if spec.KeyName != "" {
+			keyLine = fmt.Sprintf("\n  key_name             = %s", hclString(spec.KeyName))
+		}
+		computeConfig.WriteString(fmt.Sprintf(`resource "alicloud_instance" "compute" {
+  count                = %d
+  instance_name        = "%s-${count.index + 1}"
+  availability_zone    = element(%s, count.index)
+  vswitch_id           = %s
+  image_id             = %s
+  instance_type        = "%s"
+  security_groups      = %s
+  system_disk_category = "cloud_essd"
+  system_disk_size     = %d%s
+
+  tags = merge(local.common_tags, {
+    Name = "%s-${count.index + 1}"
+  })
+}
+`, spec.InstanceCount, hclStringContent(spec.Name), hclList(zones), subnetRef, imageRef, spec.InstanceType,
+			hclList(securityGroupRefs), spec.SystemDiskSize, keyLine, hclStringContent(spec.Name)))
+
+	case "baidu":
+		zones := make([]string, 0, len(spec.Subnets))
+		for _, subnet := range spec.Subnets {
+			zones = append(zones, subnetAddress(provider, subnet.Name)+".zone_name")
+		}
+		keyLine := ""
+		if spec.KeyName != "" {
+			keyLine = fmt.Sprintf("\n  keypair_id           = %s", hclString(spec.KeyName))
+		}
+		computeConfig.WriteString(fmt.Sprintf(`resource "baiducloud_instance" "compute" {
+  count                = %d
+  name                 = "%s-${count.index + 1}"
+  availability_zone    = element(%s, count.index)
+  subnet_id            = %s
+  image_id             = %s
+  instance_spec        = "%s"
+  root_disk_size_in_gb = %d
+  security_groups      = %s%s
+
+  billing = {
+    payment_timing = "Postpaid"
+  }
+
+  tags = local.common_tags
+}
+`, spec.InstanceCount, hclStringContent(spec.Name), hclList(zones), subnetRef, imageRef, spec.InstanceType,
+			spec.SystemDiskSize, hclList(securityGroupRefs), keyLine))
+
+	case "huawei":
+		zones := make([]string, 0, len(spec.Subnets))
+		for _, subnet := range spec.Subnets {
+			zones = append(zones, fmt.Sprintf(`"%s"`, subnetZone(config, subnet)))
+		}
+		keyLine := ""
+		if spec.KeyName != "" {
+			keyLine = fmt.Sprintf("\n  key_pair           = %s", hclString(spec.KeyName))
+		}
+		computeConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_compute_instance" "compute" {
+  count              = %d
+  name               = "%s-${count.index + 1}"
+  image_id           = %s
+  flavor_id          = "%s"
+  availability_zone  = element(%s, count.index)
+  security_group_ids = %s
+  system_disk_type   = "SSD"
+  system_disk_size   = %d%s
+
+  network {
+    uuid = %s
+  }
+
+  tags = merge(local.common_tags, {
+    Name = "%s-${count.index + 1}"
+  })
+}
+`, spec.InstanceCount, hclStringContent(spec.Name), imageRef, spec.InstanceType, hclList(zones),
+			hclList(securityGroupRefs), spec.SystemDiskSize, keyLine, subnetRef, hclStringContent(spec.Name)))
+
+	case "tencent":
+		zones := make([]string, 0, len(spec.Subnets))
+		vpcIds := make([]string, 0, len(spec.Subnets))
+		for _, subnet := range spec.Subnets {
+			zones = append(zones, subnetAddress(provider, subnet.Name)+".availability_zone")
+			vpcIds = append(vpcIds, subnetAddress(provider, subnet.Name)+".vpc_id")
+		}
+		keyLine := ""
+		if spec.KeyName != "" {
+			keyLine = fmt.Sprintf("\n  key_ids                 = [%s]", hclString(spec.KeyName))
+		}
+		computeConfig.WriteString(fmt.Sprintf(`resource "tencentcloud_instance" "compute" {
+  count                   = %d
+  instance_name           = "%s-${count.index + 1}"
+  availability_zone       = element(%s, count.index)
+  image_id                = %s
+  instance_type           = "%s"
+  vpc_id                  = element(%s, count.index)
+  subnet_id               = %s
+  orderly_security_groups = %s
+  system_disk_type        = "CLOUD_PREMIUM"
+  system_disk_size        = %d%s
+
+  tags = merge(local.common_tags, {
+    Name = "%s-${count.index + 1}"
+  })
+}
+`, spec.InstanceCount, hclStringContent(spec.Name), hclList(zones), imageRef, spec.InstanceType, hclList(vpcIds),
+			subnetRef, hclList(securityGroupRefs), spec.SystemDiskSize, keyLine, hclStringContent(spec.Name)))
+
+	case "volcengine":
+		keyLine := ""
+		if spec.KeyName != "" {
+			keyLine = fmt.Sprintf("\n  key_pair_name        = %s", hclString(spec.KeyName))
+		}
+		computeConfig.WriteString(fmt.Sprintf(`resource "volcengine_ecs_instance" "compute" {
+  count                = %d
+  instance_name        = "%s-${count.index + 1}"
+  image_id             = %s
+  instance_type        = "%s"
+  subnet_id            = %s
+  security_group_ids   = %s
+  instance_charge_type = "PostPaid"
+  system_volume_type   = "ESSD_PL0"
+  system_volume_size   = %d%s
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+`, spec.InstanceCount, hclStringContent(spec.Name), imageRef, spec.InstanceType, subnetRef,
+			hclList(securityGroupRefs), spec.SystemDiskSize, keyLine))
+	}
+
+	LogInfo(fmt.Sprintf("已生成计算实例配置: 名称=%s, 数量=%d, 规格=%s, 操作系统=%s %s, 子网数=%d",
+		spec.Name, spec.InstanceCount, spec.InstanceType, spec.OsFamily, spec.OsVersion, len(spec.Subnets)))
+
+	return computeConfig.String()
+}
+
+// generateComputeImageDataSource 生成按操作系统和版本查找镜像的数据源，返回数据源配置和镜像ID引用
+func generateComputeImageDataSource(provider, label, osFamily, osVersion string) (string, string) {
+	displayName := osDisplayNames[osFamily]
+	underscoreVersion := strings.ReplaceAll(osVersion, ".", "_")
+
+	switch provider {
+	case "aws":
+		owner := "099720109477"
+		namePattern := fmt.Sprintf("ubuntu/images/hvm-ssd*/ubuntu-*-%s-amd64-server-*", osVersion)
+		switch osFamily {
+		case "debian":
+			owner = "136693071363"
+			namePattern = fmt.Sprintf("debian-%s-amd64-*", osVersion)
+		case "centos":
+			owner = "125523088429"
+			namePattern = fmt.Sprintf("CentOS Linux %s x86_64*", strings.Split(osVersion, ".")[0])
+		case "amazon-linux":
+			owner = "amazon"
+			namePattern = "al2023-ami-2023.*-x86_64"
+			if osVersion == "2" {
+				namePattern = "amzn2-ami-hvm-*-x86_64-gp2"
+			}
+		}
+		return fmt.Sprintf(`data "aws_ami" "%s" {
+  most_recent = true
+  owners      = ["%s"]
+
+  filter {
+    name   = "name"
+    values = ["%s"]
+  }
+
+  filter {
+    name   = "architecture"
+    values = ["x86_64"]
+  }
+}
+`, label, owner, namePattern), fmt.Sprintf("data.aws_ami.%s.id", label)
+
+	case "alicloud":
+		return fmt.Sprintf(`data "alicloud_images" "%s" {
+  name_regex  = "^%s_%s"
+  owners      = "system"
+  most_recent = true
+}
+`, label, osFamily, underscoreVersion), fmt.Sprintf("data.alicloud_images.%s.images[0].id", label)
+
+	case "baidu":
+		return fmt.Sprintf(`data "baiducloud_images" "%s" {
+  image_type = "System"
+  os_name    = "%s"
+  name_regex = "^%s"
+}
+`, label, displayName, osVersion), fmt.Sprintf("data.baiducloud_images.%s.images[0].id", label)
+
+	case "huawei":
+		return fmt.Sprintf(`data "huaweicloud_images_image" "%s" {
+  name_regex  = "^%s %s"
+  visibility  = "public"
+  most_recent = true
+}
+`, label, displayName, osVersion), fmt.Sprintf("data.huaweicloud_images_image.%s.id", label)
+
+	case "tencent":
+		return fmt.Sprintf(`data "tencentcloud_images" "%s" {
+  image_type = ["PUBLIC_IMAGE"]
+  os_name    = "%s %s"
+}
+`, label, osFamily, osVersion), fmt.Sprintf("data.tencentcloud_images.%s.images[0].image_id", label)
+
+	case "volcengine":
+		return fmt.Sprintf(`data "volcengine_images" "%s" {
+  os_type    = "Linux"
+  visibility = "public"
+  name_regex = "^%s %s"
+}
+`, label, displayName, osVersion), fmt.Sprintf("data.volcengine_images.%s.images[0].image_id", label)
+	}
+
+	return "", ""
+}
+
+// generateComputeDefaultSecurityGroup 生成计算实例默认安全组（仅允许出站），返回安全组配置和ID引用
+func generateComputeDefaultSecurityGroup(provider, name, vpcRef string) (string, string) {
+	switch provider {
+	case "aws":
+		return fmt.Sprintf(`resource "aws_security_group" "compute_sg" {
+  name        = "%s-sg"
+  description = "Default security group for %s instances"
+  vpc_id      = %s.id
+
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  tags = {
+    Name = "%s-sg"
+  }
+}
+`, name, name, vpcRef, name), "aws_security_group.compute_sg.id"
+
+	case "alicloud":
+		return fmt.Sprintf(`resource "alicloud_security_group" "compute_sg" {
+  name        = "%s-sg"
+  description = "Default security group for %s instances"
+  vpc_id      = %s.id
+
+  tags = local.common_tags
+}
+`, name, name, vpcRef), "alicloud_security_group.compute_sg.id"
+
+	case "baidu":
+		return fmt.Sprintf(`resource "baiducloud_security_group" "compute_sg" {
+  name        = "%s-sg"
+  description = "Default security group for %s instances"
+  vpc_id      = %s.id
+
+  tags = local.common_tags
+}
+
+resource "baiducloud_security_group_rule" "compute_sg_egress" {
+  security_group_id = baiducloud_security_group.compute_sg.id
+  remark            = "allow all outbound"
+  protocol          = "all"
+  port_range        = ""
+  direction         = "egress"
+  dest_ip           = "all"
+}
+`, name, name, vpcRef), "baiducloud_security_group.compute_sg.id"
+
+	case "huawei":
+		return fmt.Sprintf(`resource "huaweicloud_networking_secgroup" "compute_sg" {
+  name        = "%s-sg"
+  description = "Default security group for %s instances"
+}
+`, name, name), "huaweicloud_networking_secgroup.compute_sg.id"
+
+	case "tencent":
+		return fmt.Sprintf(`resource "tencentcloud_security_group" "compute_sg" {
+  name        = "%s-sg"
+  description = "Default security group for %s instances"
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_security_group_lite_rule" "compute_sg_rules" {
+  security_group_id = tencentcloud_security_group.compute_sg.id
+  egress            = ["ACCEPT#0.0.0.0/0#ALL#ALL"]
+}
+`, name, name), "tencentcloud_security_group.compute_sg.id"
+
+	case "volcengine":
+		return fmt.Sprintf(`resource "volcengine_security_group" "compute_sg" {
+  security_group_name = "%s-sg"
+  description         = "Default security group for %s instances"
+  vpc_id              = %s.id
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+`, name, name, vpcRef), "volcengine_security_group.compute_sg.id"
+	}
+
+	return "", ""
+}
+
+// generateAzureCompute 生成Azure虚拟机配置（网卡、网络安全组和Linux虚拟机）
+func generateAzureCompute(config renderContext, spec computeSpec, subnetRef string) string {
+	var azureConfig strings.Builder
+
+	// Azure虚拟机需要SSH公钥，未提供时生成密钥对并保存在状态中
+	publicKeyRef := hclString(spec.SshPublicKey)
+	if spec.SshPublicKey == "" {
+		if spec.KeyName != "" {
+			reportWarn(config, "Azure虚拟机不支持按名称引用密钥对，请通过 ssh_public_key 提供公钥内容，将自动生成密钥对")
+		}
+		azureConfig.WriteString(`resource "tls_private_key" "compute_ssh" {
+  algorithm = "RSA"
+  rsa_bits  = 4096
+}
+`)
+		publicKeyRef = "tls_private_key.compute_ssh.public_key_openssh"
+	}
+
+	// Azure通过镜像市场的发布者/产品/SKU引用镜像
+	publisher, offer, sku := "Canonical", "0001-com-ubuntu-server-jammy", "22_04-lts-gen2"
+	switch spec.OsFamily {
+	case "ubuntu":
+		switch spec.OsVersion {
+		case "20.04":
+			offer, sku = "0001-com-ubuntu-server-focal", "20_04-lts-gen2"
+		case "24.04":
+			offer, sku = "ubuntu-24_04-lts", "server"
+		}
+	case "debian":
+		publisher, offer, sku = "Debian", "debian-"+spec.OsVersion, spec.OsVersion+"-gen2"
+	case "centos":
+		publisher, offer, sku = "OpenLogic", "CentOS", strings.ReplaceAll(spec.OsVersion, ".", "_")+"-gen2"
+	}
+
+	imageBlock := fmt.Sprintf(`  source_image_reference {
+    publisher = "%s"
+    offer     = "%s"
+    sku       = "%s"
+    version   = "latest"
+  }`, publisher, offer, sku)
+	if spec.ImageId != "" {
+		imageBlock = fmt.Sprintf(`  source_image_id = %s`, hclString(spec.ImageId))
+	}
+
+	securityGroupRefs := append([]string{}, spec.SecurityGroups...)
+	for _, id := range spec.SecurityGroupIds {
+		securityGroupRefs = append(securityGroupRefs, hclString(id))
+	}
+
+	var securityGroupRef string
+	if len(securityGroupRefs) > 0 {
+		if len(securityGroupRefs) > 1 {
+			reportWarn(config, "Azure网卡只能绑定一个网络安全组，仅使用第一个")
+		}
+		securityGroupRef = securityGroupRefs[0]
+	} else {
+		azureConfig.WriteString(fmt.Sprintf(`resource "azurerm_network_security_group" "compute_nsg" {
+  name                = "%s-nsg"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+
+  tags = local.common_tags
+}
+`, hclStringContent(spec.Name)))
+		securityGroupRef = "azurerm_network_security_group.compute_nsg.id"
+	}
+
+	azureConfig.WriteString(fmt.Sprintf(`resource "azurerm_network_interface" "compute" {
+  count               = %d
+  name                = "%s-nic-${count.index + 1}"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+
+  ip_configuration {
+    name                          = "internal"
+    subnet_id                     = %s
+    private_ip_address_allocation = "Dynamic"
+  }
+
+  tags = local.common_tags
+}
+
+resource "azurerm_network_interface_security_group_association" "compute" {
+  count                     = %d
+  network_interface_id      = azurerm_network_interface.compute[count.index].id
+  network_security_group_id = %s
+}
+
+resource "azurerm_linux_virtual_machine" "compute" {
+  count                 = %d
+  name                  = "%s-${count.index + 1}"
+  resource_group_name   = azurerm_resource_group.rg.name
+  location              = azurerm_resource_group.rg.location
+  size                  = "%s"
+  admin_username        = "azureuser"
+  network_interface_ids = [azurerm_network_interface.compute[count.index].id]
+
+  admin_ssh_key {
+    username   = "azureuser"
+    public_key = %s
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Premium_LRS"
+    disk_size_gb         = %d
+  }
+
+%s
+
+  tags = merge(local.common_tags, {
+    Name = "%s-${count.index + 1}"
+  })
+}
+`, spec.InstanceCount, hclStringContent(spec.Name), subnetRef, spec.InstanceCount,
+		securityGroupRef, spec.InstanceCount, hclStringContent(spec.Name), spec.InstanceType,
+		publicKeyRef, spec.SystemDiskSize, imageBlock, hclStringContent(spec.Name)))
+
+	// 引用安全组组件中的安全组时，同时加入对应的应用程序安全组，使对端安全组规则生效
+	for _, ref := range spec.SecurityGroups {
+		groupName := strings.TrimSuffix(strings.TrimPrefix(ref, "azurerm_network_security_group."), ".id")
+		azureConfig.WriteString(fmt.Sprintf(`
+resource "azurerm_network_interface_application_security_group_association" "compute_%s" {
+  count                         = %d
+  network_interface_id          = azurerm_network_interface.compute[count.index].id
+  application_security_group_id = azurerm_application_security_group.%s.id
+}
+`, groupName, spec.InstanceCount, groupName))
+	}
+
+	return azureConfig.String()
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateComputeConfigSubnets(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		region   string
+		props    map[string]interface{}
+		want     string
+	}{
+		{"aws all private subnets", "aws", "us-east-1", nil, "subnet_id              = element([aws_subnet.app-a.id, aws_subnet.app-b.id], count.index)"},
+		{"aws named subnets skip public and other vpc", "aws", "us-east-1", map[string]interface{}{"subnets": "pub,app-b,other-a"}, "subnet_id              = element([aws_subnet.app-b.id], count.index)"},
+		{"alicloud all private subnets", "alicloud", "cn-hangzhou", nil, "vswitch_id           = element([alicloud_vswitch.app-a.id, alicloud_vswitch.app-b.id], count.index)"},
+		{"public subnet only", "aws", "us-east-1", map[string]interface{}{"subnets": "pub"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			props := tt.props
+			if props == nil {
+				props = map[string]interface{}{}
+			}
+			body := generateComputeConfig(testRenderContext(testNetworkConfig(tt.provider, tt.region)), props)
+			if tt.want == "" {
+				if body != "" {
+					t.Errorf("expected no config, got:\n%s", body)
+				}
+				return
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("config does not contain %q:\n%s", tt.want, body)
+			}
+		})
+	}
+}
+
+func TestGenerateComputeConfigUserStrings(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]interface{}
+		want  string
+	}{
+		{"name is escaped", map[string]interface{}{"name": `web" } resource "null_resource" "pwn" { x = "${file("/etc/passwd")}`}, `Name = "web\" } resource \"null_resource\" \"pwn\" { x = \"$${file(\"/etc/passwd\")}-${count.index + 1}"`},
+		{"key name is escaped", map[string]interface{}{"key_name": `ops"key`}, `key_name               = "ops\"key"`},
+		{"instance type with quotes is rejected", map[string]interface{}{"instance_type": `t3.micro" }`}, ""},
+		{"os version with spaces is rejected", map[string]interface{}{"os_version": "22.04 LTS"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := generateComputeConfig(testRenderContext(testNetworkConfig("aws", "us-east-1")), tt.props)
+			if tt.want == "" {
+				if body != "" {
+					t.Errorf("expected no config, got:\n%s", body)
+				}
+				return
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("config does not contain %q:\n%s", tt.want, body)
+			}
+			if strings.Contains(body, `resource "null_resource"`) {
+				t.Errorf("user input created a resource:\n%s", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,809 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// defaultDatabaseEngineVersions 各数据库引擎默认的版本
+var defaultDatabaseEngineVersions = map[string]string{
+	"mysql":    "8.0",
+	"postgres": "15",
+	"mariadb":  "10.6",
+}
+
+// defaultDatabaseInstanceClasses 各云提供商默认的数据库实例规格
+var defaultDatabaseInstanceClasses = map[string]string{
+	"aws":        "db.t3.medium",
+	"azure":      "GP_Standard_D2ds_v4",
+	"alicloud":   "mysql.n2.medium.2c",
+	"huawei":     "rds.mysql.n1.large.2",
+	"tencent":    "4000",
+	"volcengine": "rds.mysql.1c2g",
+}
+
+// defaultPostgresInstanceClasses PostgreSQL引擎与MySQL规格命名不同的云提供商
+var defaultPostgresInstanceClasses = map[string]string{
+	"alicloud":   "pg.n2.2c.2m",
+	"huawei":     "rds.pg.n1.large.2",
+	"volcengine": "rds.postgres.1c2g",
+}
+
+// databaseSpec 表示托管数据库组件的解析结果
+type databaseSpec struct {
+	Name             string
+	Engine           string
+	EngineVersion    string
+	InstanceClass    string
+	AllocatedStorage int
+	DbName           string
+	Username         string
+	MultiAz          bool
+	SecondaryZone    string
+	BackupDays       int
+	Port             int
+	SecurityGroups   []string // 安全组组件中定义的安全组引用
+	Subnets          []models.Subnet
+}
+
+// databaseIdentifierRegexp 数据库实例名称只能包含小写字母、数字和连字符，并以字母开头
+var databaseIdentifierRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]{0,62}$`)
+
+// databaseObjectNameRegexp 数据库名和管理员用户名只能包含字母、数字和下划线，并以字母开头
+var databaseObjectNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,62}$`)
+
+// azurePostgresStorageSizes Azure PostgreSQL灵活服务器允许的存储大小（MB）
+var azurePostgresStorageSizes = []int{32768, 65536, 131072, 262144, 524288, 1048576, 2097152, 4193280, 4194304, 8388608, 16777216, 33553408}
+
+// generateDatabaseConfig 生成跨云托管数据库组件的Terraform配置
+// 数据库密码由random_password生成，只保存在Terraform状态中，组件配置无效时返回错误
+func generateDatabaseConfig(config renderContext, propsMap map[string]interface{}) (string, error) {
+	provider := config.CloudProvider
+	if _, ok := defaultDatabaseInstanceClasses[provider]; !ok {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持托管数据库组件", provider))
+		return "", nil
+	}
+
+	spec := databaseSpec{
+		Name:             strings.ToLower(getStringProp(propsMap, "name", "landing-zone-db")),
+		Engine:           normalizeDatabaseEngine(getStringProp(propsMap, "engine", "mysql")),
+		AllocatedStorage: getIntProp(propsMap, "allocated_storage", 50),
+		DbName:           getStringProp(propsMap, "db_name", "appdb"),
+		Username:         getStringProp(propsMap, "username", "dbadmin"),
+		MultiAz:          getBoolProp(propsMap, "multi_az", true),
+		SecondaryZone:    getStringProp(propsMap, "secondary_zone", ""),
+		BackupDays:       getIntProp(propsMap, "backup_retention_days", 7),
+		SecurityGroups:   resolveSecurityGroupRefs(config, propsMap),
+		Subnets:          selectPrivateSubnets(config, getStringListProp(propsMap, "subnets")),
+	}
+	if len(spec.Subnets) == 0 {
+		return "", fmt.Errorf("托管数据库 %s 没有可用的私有子网", spec.Name)
+	}
+	if spec.AllocatedStorage <= 0 {
+		return "", fmt.Errorf("托管数据库 %s 的存储容量 %d 无效", spec.Name, spec.AllocatedStorage)
+	}
+	spec.EngineVersion = getStringProp(propsMap, "engine_version", defaultDatabaseEngineVersions[spec.Engine])
+	defaultInstanceClass := defaultDatabaseInstanceClasses[provider]
+	if postgresClass, ok := defaultPostgresInstanceClasses[provider]; ok && spec.Engine == "postgres" {
+		defaultInstanceClass = postgresClass
+	}
+	spec.InstanceClass = getStringProp(propsMap, "instance_class", defaultInstanceClass)
+	if err := checkDatabaseSpec(spec); err != nil {
+		return "", err
+	}
+	spec.Port = 3306
+	if spec.Engine == "postgres" {
+		spec.Port = 5432
+	}
+	if _, ok := propsMap["password"]; ok {
+		reportWarn(config, "托管数据库组件不再接受明文密码，已忽略 password 属性，密码将由random_password生成")
+	}
+	if spec.Engine == "mariadb" && provider != "aws" {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 不支持 mariadb 引擎，使用 mysql", provider))
+		spec.Engine = "mysql"
+		spec.EngineVersion = defaultDatabaseEngineVersions["mysql"]
+	}
+
+	// AWS数据库子网组无论是否多可用区部署都必须覆盖至少2个可用区，阿里云多可用区实例同样需要2个可用区的交换机
+	zones := databaseSubnetZones(config, spec.Subnets)
+	if provider == "aws" && len(zones) < 2 {
+		return "", fmt.Errorf("托管数据库 %s 的私有子网只覆盖了 %d 个可用区，RDS子网组至少需要2个不同可用区的子网", spec.Name, len(zones))
+	}
+	if spec.MultiAz && provider == "alicloud" && len(zones) < 2 {
+		return "", fmt.Errorf("托管数据库 %s 的私有子网只覆盖了 %d 个可用区，多可用区部署至少需要2个不同可用区的子网", spec.Name, len(zones))
+	}
+	if spec.SecondaryZone == "" && len(zones) > 1 {
+		spec.SecondaryZone = zones[1]
+	}
+	if spec.MultiAz && spec.SecondaryZone == "" && provider != "aws" && provider != "azure" {
+		reportWarn(config, fmt.Sprintf("托管数据库 %s 未指定备可用区(secondary_zone)，将部署为单可用区实例", spec.Name))
+		spec.MultiAz = false
+	}
+
+	var databaseConfig strings.Builder
+
+	databaseConfig.WriteString(`resource "random_password" "database" {
+  length           = 24
+  special          = true
+  override_special = "!#%^*()-_=+"
+  min_lower        = 2
+  min_upper        = 2
+  min_numeric      = 2
+  min_special      = 2
+}
+`)
+
+	vpc := subnetVpc(config, spec.Subnets[0])
+	switch provider {
+	case "aws":
+		databaseConfig.WriteString(generateAwsDatabase(spec, vpc))
+	case "azure":
+		databaseConfig.WriteString(generateAzureDatabase(config, spec, vpc))
+	case "alicloud":
+		databaseConfig.WriteString(generateAlicloudDatabase(spec, vpc, zones))
+	case "huawei":
+		databaseConfig.WriteString(generateHuaweiDatabase(config, spec, vpc))
+	case "tencent":
+		databaseConfig.WriteString(generateTencentDatabase(config, spec, vpc))
+	case "volcengine":
+		databaseConfig.WriteString(generateVolcengineDatabase(config, spec))
+	}
+
+	databaseConfig.WriteString(`output "database_password" {
+  value     = random_password.database.result
+  sensitive = true
+}
+`)
+
+	LogInfo(fmt.Sprintf("已生成托管数据库配置: 名称=%s, 引擎=%s %s, 多可用区=%t", spec.Name, spec.Engine, spec.EngineVersion, spec.MultiAz))
+	return databaseConfig.String(), nil
+}
+
+// normalizeDatabaseEngine 将数据库引擎规范化为 mysql、postgres 或 mariadb
+func normalizeDatabaseEngine(engine string) string {
+	switch strings.ToLower(strings.TrimSpace(engine)) {
+	case "postgres", "postgresql", "pg":
+		return "postgres"
+	case "mariadb":
+		return "mariadb"
+	default:
+		return "mysql"
+	}
+}
+
+// databaseParameterFamily 根据引擎和版本推导参数组族，例如 mysql8.0、postgres15
+func databaseParameterFamily(engine, version string) string {
+	parts := strings.Split(version, ".")
+	if engine == "postgres" {
+		return "postgres" + parts[0]
+	}
+	if len(parts) > 1 {
+		return engine + parts[0] + "." + parts[1]
+	}
+	return engine + parts[0] + ".0"
+}
+
+// databaseSubnetZones 返回子网覆盖的不同可用区，按出现顺序
+func databaseSubnetZones(config renderContext, subnets []models.Subnet) []string {
+	var zones []string
+	seen := map[string]bool{}
+	for _, subnet := range subnets {
+		zone := subnetZone(config, subnet)
+		if zone != "" && !seen[zone] {
+			seen[zone] = true
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// checkDatabaseSpec 检查数据库组件中标识类的属性，这些属性会渲染到配置中，不符合格式时返回错误
+func checkDatabaseSpec(spec databaseSpec) error {
+	if !databaseIdentifierRegexp.MatchString(spec.Name) {
+		return fmt.Errorf("托管数据库名称 %q 无效，只能包含小写字母、数字和连字符，以字母开头且不超过63个字符", spec.Name)
+	}
+	if !databaseObjectNameRegexp.MatchString(spec.DbName) {
+		return fmt.Errorf("托管数据库 %s 的数据库名 %q 无效，只能包含字母、数字和下划线，以字母开头且不超过63个字符", spec.Name, spec.DbName)
+	}
+	if !databaseObjectNameRegexp.MatchString(spec.Username) {
+		return fmt.Errorf("托管数据库 %s 的用户名 %q 无效，只能包含字母、数字和下划线，以字母开头且不超过63个字符", spec.Name, spec.Username)
+	}
+	err := checkPropertyToken("engine_version", spec.EngineVersion)
+	if err == nil {
+		err = checkPropertyToken("instance_class", spec.InstanceClass)
+	}
+	if err == nil && spec.SecondaryZone != "" {
+		err = checkPropertyToken("secondary_zone", spec.SecondaryZone)
+	}
+	if err != nil {
+		return fmt.Errorf("托管数据库 %s 的配置无效: %v", spec.Name, err)
+	}
+	return nil
+}
+
+// generateAwsDatabase 生成AWS RDS配置
+func generateAwsDatabase(spec databaseSpec, vpc models.VPC) string {
+	subnetIds := make([]string, 0, len(spec.Subnets))
+	for _, subnet := range spec.Subnets {
+		subnetIds = append(subnetIds, subnetAddress("aws", subnet.Name)+".id")
+	}
+
+	// 未引用安全组组件中的安全组时，创建只允许VPC内访问数据库端口的安全组
+	securityGroupRefs := spec.SecurityGroups
+	securityGroupConfig := ""
+	if len(securityGroupRefs) == 0 {
+		securityGroupRefs = []string{"aws_security_group.database_sg.id"}
+		securityGroupConfig = fmt.Sprintf(`resource "aws_security_group" "database_sg" {
+  name        = "%s-sg"
+  description = "Allow database access from within the VPC"
+  vpc_id      = %s.id
+
+  ingress {
+    description = "Database from VPC"
+    from_port   = %d
+    to_port     = %d
+    protocol    = "tcp"
+    cidr_blocks = ["%s"]
+  }
+
+  tags = {
+    Name = "%s-sg"
+  }
+}
+
+`, hclStringContent(spec.Name), vpcAddress("aws", vpc.Name), spec.Port, spec.Port, vpc.CIDR, hclStringContent(spec.Name))
+	}
+
+	return fmt.Sprintf(`resource "aws_db_subnet_group" "database" {
+  name       = "%s-subnet-group"
+  subnet_ids = %s
+
+  tags = {
+    Name = "%s-subnet-group"
+  }
+}
+
+resource "aws_db_parameter_group" "database" {
+  name   = "%s-params"
+  family = "%s"
+
+  tags = {
+    Name = "%s-params"
+  }
+}
+
+%sresource "aws_db_instance" "database" {
+  identifier                = "%s"
+  engine                    = "%s"
+  engine_version            = "%s"
+  instance_class            = "%s"
+  allocated_storage         = %d
+  storage_type              = "gp3"
+  storage_encrypted         = true
+  db_name                   = "%s"
+  username                  = "%s"
+  password                  = random_password.database.result
+  port                      = %d
+  parameter_group_name      = aws_db_parameter_group.database.name
+  db_subnet_group_name      = aws_db_subnet_group.database.name
+  vpc_security_group_ids    = %s
+  multi_az                  = %t
+  publicly_accessible       = false
+  backup_retention_period   = %d
+  skip_final_snapshot       = false
+  final_snapshot_identifier = "%s-final"
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+output "database_endpoint" {
+  value = aws_db_instance.database.endpoint
+}
+`, hclStringContent(spec.Name), hclList(subnetIds), hclStringContent(spec.Name),
+		hclStringContent(spec.Name), databaseParameterFamily(spec.Engine, spec.EngineVersion), hclStringContent(spec.Name),
+		securityGroupConfig,
+		hclStringContent(spec.Name), spec.Engine, spec.EngineVersion, spec.InstanceClass, spec.AllocatedStorage, hclStringContent(spec.DbName),
+		hclStringContent(spec.Username), spec.Port, hclList(securityGroupRefs), spec.MultiAz, spec.BackupDays, hclStringContent(spec.Name), hclStringContent(spec.Name))
+}
+
+// generateAzureDatabase 生成Azure Database灵活服务器配置，通过委派子网和专用DNS区域接入VNet
+func generateAzureDatabase(config renderContext, spec databaseSpec, vpc models.VPC) string {
+	serverType := "mysql"
+	delegation := "Microsoft.DBforMySQL/flexibleServers"
+	version := spec.EngineVersion
+	if version == "8.0" {
+		version = "8.0.21"
+	}
+	if spec.Engine == "postgres" {
+		serverType = "postgresql"
+		delegation = "Microsoft.DBforPostgreSQL/flexibleServers"
+	}
+	dnsZoneSuffix := "mysql.database.azure.com"
+	if spec.Engine == "postgres" {
+		dnsZoneSuffix = "postgres.database.azure.com"
+	}
+
+	// 灵活服务器需要专用的委派子网，默认从VNet地址空间中划出最后一个/24
+	subnetCidr := fmt.Sprintf(`cidrsubnet("%s", 8, 254)`, vpc.CIDR)
+	highAvailability := ""
+	if spec.MultiAz {
+		highAvailability = `
+
+  high_availability {
+    mode = "ZoneRedundant"
+  }`
+	}
+
+	var databaseConfig strings.Builder
+	databaseConfig.WriteString(fmt.Sprintf(`resource "azurerm_subnet" "database" {
+  name                 = "%s-subnet"
+  resource_group_name  = azurerm_resource_group.rg.name
+  virtual_network_name = %s.name
+  address_prefixes     = [%s]
+
+  delegation {
+    name = "flexible-server"
+    service_delegation {
+      name    = "%s"
+      actions = ["Microsoft.Network/virtualNetworks/subnets/join/action"]
+    }
+  }
+}
+
+resource "azurerm_private_dns_zone" "database" {
+  name                = "%s.private.%s"
+  resource_group_name = azurerm_resource_group.rg.name
+
+  tags = local.common_tags
+}
+
+resource "azurerm_private_dns_zone_virtual_network_link" "database" {
+  name                  = "%s-dns-link"
+  private_dns_zone_name = azurerm_private_dns_zone.database.name
+  virtual_network_id    = %s.id
+  resource_group_name   = azurerm_resource_group.rg.name
+
+  tags = local.common_tags
+}
+`, hclStringContent(spec.Name), vpcAddress("azure", vpc.Name), subnetCidr, delegation,
+		hclStringContent(spec.Name), dnsZoneSuffix, hclStringContent(spec.Name), vpcAddress("azure", vpc.Name)))
+
+	if spec.Engine == "postgres" {
+		databaseConfig.WriteString(fmt.Sprintf(`
+resource "azurerm_postgresql_flexible_server" "database" {
+  name                          = "%s"
+  resource_group_name           = azurerm_resource_group.rg.name
+  location                      = azurerm_resource_group.rg.location
+  version                       = "%s"
+  sku_name                      = "%s"
+  storage_mb                    = %d
+  administrator_login           = "%s"
+  administrator_password        = random_password.database.result
+  delegated_subnet_id           = azurerm_subnet.database.id
+  private_dns_zone_id           = azurerm_private_dns_zone.database.id
+  public_network_access_enabled = false
+  backup_retention_days         = %d
+  zone                          = "1"%s
+
+  tags = local.common_tags
+
+  depends_on = [azurerm_private_dns_zone_virtual_network_link.database]
+}
+
+resource "azurerm_postgresql_flexible_server_database" "database" {
+  name      = "%s"
+  server_id = azurerm_postgresql_flexible_server.database.id
+  charset   = "UTF8"
+  collation = "en_US.utf8"
+}
+`, hclStringContent(spec.Name), version, spec.InstanceClass, azurePostgresStorageSize(config, spec), hclStringContent(spec.Username),
+			spec.BackupDays, highAvailability, hclStringContent(spec.DbName)))
+	} else {
+		databaseConfig.WriteString(fmt.Sprintf(`
+resource "azurerm_mysql_flexible_server" "database" {
+  name                   = "%s"
+  resource_group_name    = azurerm_resource_group.rg.name
+  location               = azurerm_resource_group.rg.location
+  version                = "%s"
+  sku_name               = "%s"
+  administrator_login    = "%s"
+  administrator_password = random_password.database.result
+  delegated_subnet_id    = azurerm_subnet.database.id
+  private_dns_zone_id    = azurerm_private_dns_zone.database.id
+  backup_retention_days  = %d
+  zone                   = "1"%s
+
+  storage {
+    size_gb = %d
+  }
+
+  tags = local.common_tags
+
+  depends_on = [azurerm_private_dns_zone_virtual_network_link.database]
+}
+
+resource "azurerm_mysql_flexible_database" "database" {
+  name                = "%s"
+  resource_group_name = azurerm_resource_group.rg.name
+  server_name         = azurerm_mysql_flexible_server.database.name
+  charset             = "utf8mb4"
+  collation           = "utf8mb4_unicode_ci"
+}
+`, hclStringContent(spec.Name), version, spec.InstanceClass, hclStringContent(spec.Username), spec.BackupDays, highAvailability,
+			spec.AllocatedStorage, hclStringContent(spec.DbName)))
+	}
+
+	databaseConfig.WriteString(fmt.Sprintf(`
+output "database_endpoint" {
+  value = azurerm_%s_flexible_server.database.fqdn
+}
+`, serverType))
+
+	return databaseConfig.String()
+}
+
+// azurePostgresStorageSize 返回不小于存储容量的最小允许存储大小，PostgreSQL灵活服务器只支持固定的几种存储大小
+func azurePostgresStorageSize(config renderContext, spec databaseSpec) int {
+	requested := spec.AllocatedStorage * 1024
+	for _, size := range azurePostgresStorageSizes {
+		if size >= requested {
+			if size != requested {
+				reportWarn(config, fmt.Sprintf("Azure PostgreSQL不支持 %dGB 的存储容量，已调整为 %dMB", spec.AllocatedStorage, size))
+			}
+			return size
+		}
+	}
+	largest := azurePostgresStorageSizes[len(azurePostgresStorageSizes)-1]
+	reportWarn(config, fmt.Sprintf("Azure PostgreSQL的存储容量最大为 %dMB，已调整", largest))
+	return largest
+}
+
+// generateAlicloudDatabase 生成阿里云RDS配置，主备实例分布在不同可用区的交换机上
+func generateAlicloudDatabase(spec databaseSpec, vpc models.VPC, zones []string) string {
+	engine, accountType := "MySQL", "Super"
+	if spec.Engine == "postgres" {
+		engine = "PostgreSQL"
+		accountType = "Normal"
+	}
+	engineVersion := spec.EngineVersion
+	if spec.Engine == "postgres" && !strings.Contains(engineVersion, ".") {
+		engineVersion += ".0"
+	}
+
+	vswitchIds := make([]string, 0, len(spec.Subnets))
+	for _, subnet := range spec.Subnets {
+		vswitchIds = append(vswitchIds, subnetAddress("alicloud", subnet.Name)+".id")
+	}
+
+	zoneLines := fmt.Sprintf(`
+  zone_id                  = "%s"`, zones[0])
+	category := "Basic"
+	if spec.MultiAz {
+		category = "HighAvailability"
+		zoneLines += fmt.Sprintf(`
+  zone_id_slave_a          = "%s"`, spec.SecondaryZone)
+	}
+
+	securityGroupLine := ""
+	if len(spec.SecurityGroups) > 0 {
+		securityGroupLine = fmt.Sprintf(`
+  security_group_ids       = %s`, hclList(spec.SecurityGroups))
+	}
+
+	return fmt.Sprintf(`resource "alicloud_db_instance" "database" {
+  instance_name            = "%s"
+  engine                   = "%s"
+  engine_version           = "%s"
+  instance_type            = "%s"
+  instance_storage         = %d
+  db_instance_storage_type = "cloud_essd"
+  instance_charge_type     = "Postpaid"
+  category                 = "%s"
+  vswitch_id               = join(",", %s)%s
+  security_ips             = ["%s"]%s
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "alicloud_db_account" "database" {
+  db_instance_id   = alicloud_db_instance.database.id
+  account_name     = "%s"
+  account_password = random_password.database.result
+  account_type     = "%s"
+}
+
+resource "alicloud_db_database" "database" {
+  instance_id = alicloud_db_instance.database.id
+  name        = "%s"
+}
+
+output "database_endpoint" {
+  value = alicloud_db_instance.database.connection_string
+}
+`, hclStringContent(spec.Name), engine, engineVersion, spec.InstanceClass, spec.AllocatedStorage, category,
+		hclList(vswitchIds), zoneLines, vpc.CIDR, securityGroupLine, hclStringContent(spec.Name), hclStringContent(spec.Username), accountType, hclStringContent(spec.DbName))
+}
+
+// generateHuaweiDatabase 生成华为云RDS配置，主备节点分布在两个可用区
+func generateHuaweiDatabase(config renderContext, spec databaseSpec, vpc models.VPC) string {
+	datastoreType, databaseResource := "MySQL", "huaweicloud_rds_mysql_database"
+	if spec.Engine == "postgres" {
+		datastoreType, databaseResource = "PostgreSQL", "huaweicloud_rds_pg_database"
+	}
+
+	flavor := spec.InstanceClass
+	availabilityZones := []string{subnetZone(config, spec.Subnets[0])}
+	haLine := ""
+	if spec.MultiAz {
+		if !strings.HasSuffix(flavor, ".ha") {
+			flavor += ".ha"
+		}
+		availabilityZones = append(availabilityZones, spec.SecondaryZone)
+		haLine = `
+  ha_replication_mode = "semisync"`
+		if spec.Engine == "postgres" {
+			haLine = `
+  ha_replication_mode = "async"`
+		}
+	}
+
+	characterSet := "utf8mb4"
+	if spec.Engine == "postgres" {
+		characterSet = "UTF8"
+	}
+
+	// 华为云RDS只能绑定一个安全组，未引用安全组组件时创建只允许VPC内访问的安全组
+	securityGroupRef := "huaweicloud_networking_secgroup.database_sg.id"
+	securityGroupConfig := ""
+	if len(spec.SecurityGroups) > 0 {
+		if len(spec.SecurityGroups) > 1 {
+			reportWarn(config, "华为云RDS实例只能绑定一个安全组，仅使用第一个")
+		}
+		securityGroupRef = spec.SecurityGroups[0]
+	} else {
+		securityGroupConfig = fmt.Sprintf(`resource "huaweicloud_networking_secgroup" "database_sg" {
+  name        = "%s-sg"
+  description = "Allow database access from within the VPC"
+}
+
+resource "huaweicloud_networking_secgroup_rule" "database_sg_ingress" {
+  security_group_id = huaweicloud_networking_secgroup.database_sg.id
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  protocol          = "tcp"
+  port_range_min    = %d
+  port_range_max    = %d
+  remote_ip_prefix  = "%s"
+}
+
+`, hclStringContent(spec.Name), spec.Port, spec.Port, vpc.CIDR)
+	}
+
+	subnet := spec.Subnets[0]
+	return fmt.Sprintf(`%sresource "huaweicloud_rds_parametergroup" "database" {
+  name = "%s-params"
+
+  datastore {
+    type    = "%s"
+    version = "%s"
+  }
+}
+
+resource "huaweicloud_rds_instance" "database" {
+  name                = "%s"
+  flavor              = "%s"
+  vpc_id              = %s.id
+  subnet_id           = %s.id
+  security_group_id   = %s
+  availability_zone   = %s
+  param_group_id      = huaweicloud_rds_parametergroup.database.id%s
+
+  db {
+    type     = "%s"
+    version  = "%s"
+    password = random_password.database.result
+    port     = %d
+  }
+
+  volume {
+    type = "CLOUDSSD"
+    size = %d
+  }
+
+  backup_strategy {
+    start_time = "03:00-04:00"
+    keep_days  = %d
+  }
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "%s" "database" {
+  instance_id   = huaweicloud_rds_instance.database.id
+  name          = "%s"
+  character_set = "%s"
+}
+
+output "database_endpoint" {
+  value = huaweicloud_rds_instance.database.private_ips[0]
+}
+`, securityGroupConfig, hclStringContent(spec.Name), strings.ToLower(datastoreType), spec.EngineVersion,
+		hclStringContent(spec.Name), flavor, vpcAddress("huawei", vpc.Name), subnetAddress("huawei", subnet.Name), securityGroupRef,
+		hclStringList(availabilityZones), haLine, datastoreType, spec.EngineVersion, spec.Port,
+		spec.AllocatedStorage, spec.BackupDays, hclStringContent(spec.Name), databaseResource, hclStringContent(spec.DbName), characterSet)
+}
+
+// generateTencentDatabase 生成TencentDB配置，备节点部署在备可用区
+func generateTencentDatabase(config renderContext, spec databaseSpec, vpc models.VPC) string {
+	subnet := spec.Subnets[0]
+	zone := subnetZone(config, subnet)
+
+	if spec.Engine == "postgres" {
+		standbyZone := zone
+		if spec.MultiAz {
+			standbyZone = spec.SecondaryZone
+		}
+		return fmt.Sprintf(`resource "tencentcloud_postgresql_instance" "database" {
+  name              = "%s"
+  availability_zone = "%s"
+  charge_type       = "POSTPAID_BY_HOUR"
+  vpc_id            = %s.id
+  subnet_id         = %s.id
+  engine_version    = "%s"
+  root_user         = "%s"
+  root_password     = random_password.database.result
+  charset           = "UTF8"
+  memory            = 4
+  storage           = %d
+
+  db_node_set {
+    role = "Primary"
+    zone = "%s"
+  }
+
+  db_node_set {
+    role = "Standby"
+    zone = "%s"
+  }
+
+  backup_plan {
+    base_backup_retention_period = %d
+  }
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+output "database_endpoint" {
+  value = tencentcloud_postgresql_instance.database.private_access_ip
+}
+`, hclStringContent(spec.Name), zone, vpcAddress("tencent", vpc.Name), subnetAddress("tencent", subnet.Name),
+			spec.EngineVersion, hclStringContent(spec.Username), spec.AllocatedStorage, zone, standbyZone,
+			spec.BackupDays, hclStringContent(spec.Name))
+	}
+
+	slaveLines := ""
+	if spec.MultiAz {
+		slaveLines = fmt.Sprintf(`
+  slave_deploy_mode = 1
+  slave_sync_mode   = 1
+  first_slave_zone  = "%s"`, spec.SecondaryZone)
+	}
+
+	return fmt.Sprintf(`resource "tencentcloud_mysql_param_template" "database" {
+  name           = "%s-params"
+  description    = "Parameter template for %s"
+  engine_version = "%s"
+}
+
+resource "tencentcloud_mysql_instance" "database" {
+  instance_name     = "%s"
+  engine_version    = "%s"
+  charge_type       = "POSTPAID"
+  availability_zone = "%s"
+  vpc_id            = %s.id
+  subnet_id         = %s.id
+  mem_size          = %s
+  volume_size       = %d
+  internet_service  = 0
+  root_password     = random_password.database.result
+  param_template_id = tencentcloud_mysql_param_template.database.template_id%s
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "tencentcloud_mysql_account" "database" {
+  mysql_id = tencentcloud_mysql_instance.database.id
+  name     = "%s"
+  password = random_password.database.result
+  host     = "%%"
+}
+
+resource "tencentcloud_mysql_database" "database" {
+  instance_id        = tencentcloud_mysql_instance.database.id
+  db_name            = "%s"
+  character_set_name = "utf8mb4"
+}
+
+output "database_endpoint" {
+  value = tencentcloud_mysql_instance.database.intranet_ip
+}
+`, hclStringContent(spec.Name), hclStringContent(spec.Name), spec.EngineVersion, hclStringContent(spec.Name), spec.EngineVersion, zone,
+		vpcAddress("tencent", vpc.Name), subnetAddress("tencent", subnet.Name), spec.InstanceClass,
+		spec.AllocatedStorage, slaveLines, hclStringContent(spec.Name), hclStringContent(spec.Username), hclStringContent(spec.DbName))
+}
+
+// generateVolcengineDatabase 生成火山引擎RDS配置，主备节点分布在主备可用区
+func generateVolcengineDatabase(config renderContext, spec databaseSpec) string {
+	subnet := spec.Subnets[0]
+	zone := subnetZone(config, subnet)
+	secondaryZone := zone
+	if spec.MultiAz {
+		secondaryZone = spec.SecondaryZone
+	}
+
+	resourceType, engineVersion := "volcengine_rds_mysql_instance", "MySQL_"+strings.ReplaceAll(spec.EngineVersion, ".", "_")
+	accountResource, databaseResource := "volcengine_rds_mysql_account", "volcengine_rds_mysql_database"
+	if spec.Engine == "postgres" {
+		resourceType, engineVersion = "volcengine_rds_postgresql_instance", "PostgreSQL_"+strings.Split(spec.EngineVersion, ".")[0]
+		accountResource, databaseResource = "volcengine_rds_postgresql_account", "volcengine_rds_postgresql_database"
+	}
+
+	return fmt.Sprintf(`resource "%s" "database" {
+  instance_name     = "%s"
+  db_engine_version = "%s"
+  node_spec         = "%s"
+  primary_zone_id   = "%s"
+  secondary_zone_id = "%s"
+  storage_space     = %d
+  subnet_id         = %s.id
+
+  charge_info {
+    charge_type = "PostPaid"
+  }
+
+  tags {
+    key   = "Name"
+    value = "%s"
+  }
+
+  dynamic "tags" {
+    for_each = { for key, value in local.common_tags : key => value if key != "Name" }
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+
+resource "%s" "database" {
+  instance_id      = %s.database.id
+  account_name     = "%s"
+  account_password = random_password.database.result
+  account_type     = "Super"
+}
+
+resource "%s" "database" {
+  instance_id = %s.database.id
+  db_name     = "%s"
+}
+
+output "database_instance_id" {
+  value = %s.database.id
+}
+`, resourceType, hclStringContent(spec.Name), engineVersion, spec.InstanceClass, zone, secondaryZone,
+		spec.AllocatedStorage, subnetAddress("volcengine", subnet.Name), hclStringContent(spec.Name),
+		accountResource, resourceType, hclStringContent(spec.Username), databaseResource, resourceType, hclStringContent(spec.DbName), resourceType)
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// testRenderContext 返回带问题收集器的渲染上下文，用于检查生成器记录的问题
+func testRenderContext(config models.DeploymentConfig) renderContext {
+	return renderContext{DeploymentConfig: config, findings: &findingCollector{}}
+}
+
+// testNetworkConfig 返回两个VPC的部署：main包含公有子网pub和私有子网app-a、app-b，other包含私有子网other-a
+func testNetworkConfig(provider, region string) models.DeploymentConfig {
+	return models.DeploymentConfig{
+		CloudProvider: provider,
+		Region:        region,
+		AZ:            region + "a",
+		VPC:           models.VPC{Name: "main", CIDR: "10.0.0.0/16"},
+		AllVpcs: []models.VPC{
+			{Name: "main", CIDR: "10.0.0.0/16"},
+			{Name: "other", CIDR: "10.1.0.0/16"},
+		},
+		AllSubnets: []models.Subnet{
+			{Name: "pub", CIDR: "10.0.0.0/24", AZ: region + "a", Tier: "public"},
+			{Name: "app-a", CIDR: "10.0.1.0/24", AZ: region + "a", Tier: "private"},
+			{Name: "app-b", CIDR: "10.0.2.0/24", AZ: region + "b", Tier: "private"},
+			{Name: "other-a", CIDR: "10.1.1.0/24", AZ: region + "c", Tier: "private", VpcIndex: 1},
+		},
+	}
+}
+
+func TestGenerateDatabaseConfigSubnets(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		region   string
+		props    map[string]interface{}
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "aws uses private subnets of one vpc",
+			provider: "aws",
+			region:   "us-east-1",
+			want:     []string{"subnet_ids = [aws_subnet.app-a.id, aws_subnet.app-b.id]"},
+		},
+		{
+			name:     "aws single zone still needs two zones",
+			provider: "aws",
+			region:   "us-east-1",
+			props:    map[string]interface{}{"multi_az": false, "subnets": "app-a"},
+			wantErr:  true,
+		},
+		{
+			name:     "aws public subnet only",
+			provider: "aws",
+			region:   "us-east-1",
+			props:    map[string]interface{}{"subnets": "pub"},
+			wantErr:  true,
+		},
+		{
+			name:     "tencent primary zone follows subnet",
+			provider: "tencent",
+			region:   "ap-guangzhou",
+			props:    map[string]interface{}{"subnets": "app-b,app-a"},
+			want:     []string{`availability_zone = "ap-guangzhoub"`, "subnet_id         = tencentcloud_subnet.app-b.id", `first_slave_zone  = "ap-guangzhoua"`},
+		},
+		{
+			name:     "azure postgres storage size",
+			provider: "azure",
+			region:   "eastus",
+			props:    map[string]interface{}{"engine": "postgres", "allocated_storage": float64(50)},
+			want:     []string{"storage_mb                    = 65536"},
+		},
+		{
+			name:     "name breaking out of the string is rejected",
+			provider: "aws",
+			region:   "us-east-1",
+			props:    map[string]interface{}{"name": `x" } resource "null_resource" "pwn" { x = "${file("/etc/passwd")}`},
+			wantErr:  true,
+		},
+		{
+			name:     "db name with quotes is rejected",
+			provider: "alicloud",
+			region:   "cn-hangzhou",
+			props:    map[string]interface{}{"db_name": `app"db`},
+			wantErr:  true,
+		},
+		{
+			name:     "instance class with spaces is rejected",
+			provider: "aws",
+			region:   "us-east-1",
+			props:    map[string]interface{}{"instance_class": "db.t3 medium"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			props := tt.props
+			if props == nil {
+				props = map[string]interface{}{}
+			}
+			body, err := generateDatabaseConfig(testRenderContext(testNetworkConfig(tt.provider, tt.region)), props)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateDatabaseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+			for _, unwanted := range []string{".pub.id", ".other-a.id"} {
+				if strings.Contains(body, unwanted) {
+					t.Errorf("config references %s", unwanted)
+				}
+			}
+		})
+	}
+}
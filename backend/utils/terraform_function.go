@@ -0,0 +1,583 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// functionComponents 各云提供商对应的无服务器函数组件
+var functionComponents = map[string]string{
+	"aws":      "lambda",
+	"azure":    "azure-functions",
+	"alicloud": "function-compute",
+	"huawei":   "functiongraph",
+	"tencent":  "scf",
+}
+
+// functionRuntimeRegexp 用于从运行时字符串中解析语言和版本，例如 nodejs18.x、python3.9
+var functionRuntimeRegexp = regexp.MustCompile(`^(node|nodejs|node\.js|python)\s*([0-9]+(\.[0-9]+)?)?`)
+
+// functionNameRules 各云提供商函数名称允许的字符和长度，函数名称还用于角色、日志组等相关资源的名称
+var functionNameRules = map[string]*regexp.Regexp{
+	"aws":      regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`),
+	"azure":    regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]{0,58}[a-zA-Z0-9]$`),
+	"alicloud": regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]{0,127}$`),
+	"huawei":   regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]{0,59}$`),
+	"tencent":  regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]{0,58}[a-zA-Z0-9]$`),
+}
+
+// huaweiNodeRuntimes 和 tencentNodeRuntimes 将Node.js主版本映射为对应云的运行时名称
+var huaweiNodeRuntimes = map[string]string{"14": "Node.js14.18", "16": "Node.js16.17", "18": "Node.js18.15"}
+var tencentNodeRuntimes = map[string]string{"14": "Nodejs14.18", "16": "Nodejs16.13", "18": "Nodejs18.15"}
+
+// functionSpec 表示无服务器函数组件的解析结果
+type functionSpec struct {
+	Name             string
+	Language         string // nodejs 或 python
+	LanguageVersion  string // 例如 18 或 3.9
+	MemorySize       int
+	Timeout          int
+	LogRetentionDays int
+	SecurityGroups   []string // 安全组组件中定义的安全组引用
+	Subnets          []models.Subnet
+}
+
+// generateFunctionConfig 生成无服务器函数组件的Terraform配置
+// 包括函数本身、执行角色、日志组、VPC接入以及由archive_file打包的占位代码
+func generateFunctionConfig(config renderContext, component string, propsMap map[string]interface{}) string {
+	provider := config.CloudProvider
+	if functionComponents[provider] != component {
+		reportWarn(config, fmt.Sprintf("组件 %s 不适用于云提供商 %s，跳过生成", component, provider))
+		return ""
+	}
+
+	spec := functionSpec{
+		Name:             getStringProp(propsMap, "function_name", "landing-zone-function"),
+		MemorySize:       getIntProp(propsMap, "memory_size", 128),
+		Timeout:          getIntProp(propsMap, "timeout", 30),
+		LogRetentionDays: getIntProp(propsMap, "log_retention_days", 14),
+		SecurityGroups:   resolveSecurityGroupRefs(config, propsMap),
+		Subnets:          selectPrivateSubnets(config, getStringListProp(propsMap, "subnets")),
+	}
+	if !functionNameRules[provider].MatchString(spec.Name) {
+		reportError(config, fmt.Sprintf("无服务器函数名称 %q 不符合 %s 的命名规则，跳过生成", spec.Name, provider))
+		return ""
+	}
+	if len(spec.Subnets) == 0 {
+		reportError(config, fmt.Sprintf("无服务器函数 %s 没有可用的私有子网，跳过生成", spec.Name))
+		return ""
+	}
+	spec.Language, spec.LanguageVersion = parseFunctionRuntime(config, getStringProp(propsMap, "runtime", "nodejs18.x"))
+
+	var functionConfig strings.Builder
+	functionConfig.WriteString(generateFunctionPackage(spec, provider == "azure"))
+
+	vpc := subnetVpc(config, spec.Subnets[0])
+	switch provider {
+	case "aws":
+		functionConfig.WriteString(generateAwsFunction(spec, vpc))
+	case "azure":
+		functionConfig.WriteString(generateAzureFunction(spec, vpc))
+	case "alicloud":
+		functionConfig.WriteString(generateAlicloudFunction(config, spec, vpc))
+	case "huawei":
+		functionConfig.WriteString(generateHuaweiFunction(spec, vpc, config.Region))
+	case "tencent":
+		functionConfig.WriteString(generateTencentFunction(spec, vpc))
+	}
+
+	LogInfo(fmt.Sprintf("已生成无服务器函数配置: 名称=%s, 运行时=%s%s, 内存=%dMB", spec.Name, spec.Language, spec.LanguageVersion, spec.MemorySize))
+	return functionConfig.String()
+}
+
+// parseFunctionRuntime 将运行时字符串解析为语言和版本，无法识别时使用Node.js 18
+func parseFunctionRuntime(config renderContext, runtime string) (string, string) {
+	match := functionRuntimeRegexp.FindStringSubmatch(strings.ToLower(strings.TrimSpace(runtime)))
+	if match == nil {
+		reportWarn(config, fmt.Sprintf("不支持的函数运行时 %s，使用 nodejs18", runtime))
+		return "nodejs", "18"
+	}
+	if match[1] == "python" {
+		if match[2] == "" || !strings.Contains(match[2], ".") {
+			return "python", "3.9"
+		}
+		return "python", match[2]
+	}
+	version := strings.Split(match[2], ".")[0]
+	if version == "" {
+		version = "18"
+	}
+	return "nodejs", version
+}
+
+// generateFunctionPackage 生成函数占位代码包，部署后可替换为实际代码
+func generateFunctionPackage(spec functionSpec, azureLayout bool) string {
+	filename := "index.js"
+	content := `exports.handler = async (event, context) => {
+  return { statusCode: 200, body: "Hello from the landing zone" };
+};`
+	if spec.Language == "python" {
+		filename = "index.py"
+		content = `def handler(event, context):
+    return {"statusCode": 200, "body": "Hello from the landing zone"}`
+	}
+
+	// Azure Functions需要host.json以及每个函数目录下的function.json
+	extraSources := ""
+	if azureLayout {
+		scriptFile := filename
+		entryPoint := "handler"
+		extraSources = fmt.Sprintf(`
+
+  source {
+    content  = jsonencode({ version = "2.0" })
+    filename = "host.json"
+  }
+
+  source {
+    content = jsonencode({
+      scriptFile = "%s"
+      entryPoint = "%s"
+      bindings = [
+        { authLevel = "function", type = "httpTrigger", direction = "in", name = "req", methods = ["get", "post"] },
+        { type = "http", direction = "out", name = "$return" }
+      ]
+    })
+    filename = "handler/function.json"
+  }`, scriptFile, entryPoint)
+		filename = "handler/" + filename
+	}
+
+	return fmt.Sprintf(`data "archive_file" "function_package" {
+  type        = "zip"
+  output_path = "${path.module}/function_package.zip"
+
+  source {
+    content  = <<CODE
+%s
+CODE
+    filename = "%s"
+  }%s
+}
+
+`, content, filename, extraSources)
+}
+
+// generateAwsFunction 生成AWS Lambda配置
+func generateAwsFunction(spec functionSpec, vpc models.VPC) string {
+	runtime := "nodejs" + spec.LanguageVersion + ".x"
+	if spec.Language == "python" {
+		runtime = "python" + spec.LanguageVersion
+	}
+
+	subnetIds := make([]string, 0, len(spec.Subnets))
+	for _, subnet := range spec.Subnets {
+		subnetIds = append(subnetIds, subnetAddress("aws", subnet.Name)+".id")
+	}
+
+	// 未引用安全组组件中的安全组时，创建仅允许出站的默认安全组
+	securityGroupRefs := spec.SecurityGroups
+	securityGroupConfig := ""
+	if len(securityGroupRefs) == 0 {
+		securityGroupRefs = []string{"aws_security_group.function_sg.id"}
+		securityGroupConfig = fmt.Sprintf(`resource "aws_security_group" "function_sg" {
+  name        = "%s-sg"
+  description = "Security group for Lambda function %s"
+  vpc_id      = %s.id
+
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  tags = {
+    Name = "%s-sg"
+  }
+}
+
+`, hclStringContent(spec.Name), hclStringContent(spec.Name), vpcAddress("aws", vpc.Name), hclStringContent(spec.Name))
+	}
+
+	return fmt.Sprintf(`data "aws_partition" "current" {}
+
+resource "aws_iam_role" "function" {
+  name = "%s-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "lambda.amazonaws.com" }
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "function_vpc_access" {
+  role       = aws_iam_role.function.name
+  policy_arn = "arn:${data.aws_partition.current.partition}:iam::aws:policy/service-role/AWSLambdaVPCAccessExecutionRole"
+}
+
+resource "aws_cloudwatch_log_group" "function" {
+  name              = "/aws/lambda/%s"
+  retention_in_days = %d
+}
+
+%sresource "aws_lambda_function" "function" {
+  function_name    = "%s"
+  role             = aws_iam_role.function.arn
+  handler          = "index.handler"
+  runtime          = "%s"
+  memory_size      = %d
+  timeout          = %d
+  filename         = data.archive_file.function_package.output_path
+  source_code_hash = data.archive_file.function_package.output_base64sha256
+
+  vpc_config {
+    subnet_ids         = %s
+    security_group_ids = %s
+  }
+
+  depends_on = [
+    aws_iam_role_policy_attachment.function_vpc_access,
+    aws_cloudwatch_log_group.function,
+  ]
+}
+`, hclStringContent(spec.Name), hclStringContent(spec.Name), spec.LogRetentionDays, securityGroupConfig,
+		hclStringContent(spec.Name), runtime, spec.MemorySize, spec.Timeout, hclList(subnetIds), hclList(securityGroupRefs))
+}
+
+// generateAzureFunction 生成Azure Functions配置，使用弹性高级计划以支持VNet集成
+func generateAzureFunction(spec functionSpec, vpc models.VPC) string {
+	storageName := azureStorageAccountNameRegexp.ReplaceAllString(strings.ToLower(spec.Name), "")
+	if len(storageName) > 20 {
+		storageName = storageName[:20]
+	}
+	storageName += "func"
+
+	applicationStack := fmt.Sprintf(`node_version = "%s"`, spec.LanguageVersion)
+	if spec.Language == "python" {
+		applicationStack = fmt.Sprintf(`python_version = "%s"`, spec.LanguageVersion)
+	}
+
+	return fmt.Sprintf(`resource "azurerm_storage_account" "function" {
+  name                     = "%s"
+  resource_group_name      = azurerm_resource_group.rg.name
+  location                 = azurerm_resource_group.rg.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+  min_tls_version          = "TLS1_2"
+
+  tags = local.common_tags
+}
+
+resource "azurerm_log_analytics_workspace" "function" {
+  name                = "%s-logs"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  sku                 = "PerGB2018"
+  retention_in_days   = %d
+
+  tags = local.common_tags
+}
+
+resource "azurerm_application_insights" "function" {
+  name                = "%s-insights"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  workspace_id        = azurerm_log_analytics_workspace.function.id
+  application_type    = "web"
+
+  tags = local.common_tags
+}
+
+resource "azurerm_user_assigned_identity" "function" {
+  name                = "%s-identity"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+
+  tags = local.common_tags
+}
+
+resource "azurerm_subnet" "function" {
+  name                 = "%s-subnet"
+  resource_group_name  = azurerm_resource_group.rg.name
+  virtual_network_name = %s.name
+  address_prefixes     = [cidrsubnet("%s", 8, 253)]
+
+  delegation {
+    name = "function-app"
+    service_delegation {
+      name    = "Microsoft.Web/serverFarms"
+      actions = ["Microsoft.Network/virtualNetworks/subnets/action"]
+    }
+  }
+}
+
+resource "azurerm_service_plan" "function" {
+  name                = "%s-plan"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  os_type             = "Linux"
+  sku_name            = "EP1"
+
+  tags = local.common_tags
+}
+
+resource "azurerm_linux_function_app" "function" {
+  name                       = "%s"
+  resource_group_name        = azurerm_resource_group.rg.name
+  location                   = azurerm_resource_group.rg.location
+  service_plan_id            = azurerm_service_plan.function.id
+  storage_account_name       = azurerm_storage_account.function.name
+  storage_account_access_key = azurerm_storage_account.function.primary_access_key
+  virtual_network_subnet_id  = azurerm_subnet.function.id
+  zip_deploy_file            = data.archive_file.function_package.output_path
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.function.id]
+  }
+
+  site_config {
+    application_insights_connection_string = azurerm_application_insights.function.connection_string
+    vnet_route_all_enabled                 = true
+
+    application_stack {
+      %s
+    }
+  }
+
+  app_settings = {
+    AzureFunctionsJobHost__functionTimeout = "%s"
+  }
+
+  tags = local.common_tags
+}
+`, storageName, hclStringContent(spec.Name), spec.LogRetentionDays, hclStringContent(spec.Name), hclStringContent(spec.Name), hclStringContent(spec.Name), vpcAddress("azure", vpc.Name), vpc.CIDR,
+		hclStringContent(spec.Name), hclStringContent(spec.Name), applicationStack, azureFunctionTimeout(spec.Timeout))
+}
+
+// azureFunctionTimeout 将秒数转换为Azure Functions的超时格式 hh:mm:ss
+func azureFunctionTimeout(seconds int) string {
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+}
+
+// generateAlicloudFunction 生成阿里云函数计算配置
+func generateAlicloudFunction(config renderContext, spec functionSpec, vpc models.VPC) string {
+	runtime := "nodejs" + spec.LanguageVersion
+	if spec.Language == "python" {
+		runtime = "python" + spec.LanguageVersion
+	}
+
+	vswitchIds := make([]string, 0, len(spec.Subnets))
+	for _, subnet := range spec.Subnets {
+		vswitchIds = append(vswitchIds, subnetAddress("alicloud", subnet.Name)+".id")
+	}
+
+	// 函数计算服务只能绑定一个安全组
+	securityGroupRef := "alicloud_security_group.function_sg.id"
+	securityGroupConfig := ""
+	if len(spec.SecurityGroups) > 0 {
+		if len(spec.SecurityGroups) > 1 {
+			reportWarn(config, "函数计算服务只能绑定一个安全组，仅使用第一个")
+		}
+		securityGroupRef = spec.SecurityGroups[0]
+	} else {
+		securityGroupConfig = fmt.Sprintf(`resource "alicloud_security_group" "function_sg" {
+  name        = "%s-sg"
+  description = "Security group for function %s"
+  vpc_id      = %s.id
+
+  tags = local.common_tags
+}
+
+`, hclStringContent(spec.Name), hclStringContent(spec.Name), vpcAddress("alicloud", vpc.Name))
+	}
+
+	return fmt.Sprintf(`resource "alicloud_ram_role" "function" {
+  name     = "%s-role"
+  document = jsonencode({
+    Version = "1"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = ["fc.aliyuncs.com"] }
+    }]
+  })
+}
+
+resource "alicloud_ram_role_policy_attachment" "function_vpc_access" {
+  role_name   = alicloud_ram_role.function.name
+  policy_name = "AliyunECSNetworkInterfaceManagementAccess"
+  policy_type = "System"
+}
+
+resource "alicloud_ram_role_policy_attachment" "function_log_access" {
+  role_name   = alicloud_ram_role.function.name
+  policy_name = "AliyunLogFullAccess"
+  policy_type = "System"
+}
+
+resource "alicloud_log_project" "function" {
+  name = "%s-logs"
+
+  tags = local.common_tags
+}
+
+resource "alicloud_log_store" "function" {
+  project          = alicloud_log_project.function.name
+  name             = "function-logs"
+  retention_period = %d
+}
+
+%sresource "alicloud_fc_service" "function" {
+  name = "%s-service"
+  role = alicloud_ram_role.function.arn
+
+  log_config {
+    project  = alicloud_log_project.function.name
+    logstore = alicloud_log_store.function.name
+  }
+
+  vpc_config {
+    vswitch_ids       = %s
+    security_group_id = %s
+  }
+
+  depends_on = [
+    alicloud_ram_role_policy_attachment.function_vpc_access,
+    alicloud_ram_role_policy_attachment.function_log_access,
+  ]
+}
+
+resource "alicloud_fc_function" "function" {
+  service     = alicloud_fc_service.function.name
+  name        = "%s"
+  handler     = "index.handler"
+  runtime     = "%s"
+  memory_size = %d
+  timeout     = %d
+  filename    = data.archive_file.function_package.output_path
+}
+`, hclStringContent(spec.Name), hclStringContent(spec.Name), spec.LogRetentionDays, securityGroupConfig,
+		hclStringContent(spec.Name), hclList(vswitchIds), securityGroupRef, hclStringContent(spec.Name), runtime, spec.MemorySize, spec.Timeout)
+}
+
+// generateHuaweiFunction 生成华为云FunctionGraph配置
+func generateHuaweiFunction(spec functionSpec, vpc models.VPC, region string) string {
+	runtime, ok := huaweiNodeRuntimes[spec.LanguageVersion]
+	if !ok {
+		runtime = huaweiNodeRuntimes["18"]
+	}
+	if spec.Language == "python" {
+		runtime = "Python" + spec.LanguageVersion
+	}
+
+	subnet := spec.Subnets[0]
+	return fmt.Sprintf(`resource "huaweicloud_identity_agency" "function" {
+  name                   = "%s-agency"
+  description            = "Execution agency for FunctionGraph function %s"
+  delegated_service_name = "op_svc_cff"
+
+  project_role {
+    project = "%s"
+    roles   = ["VPC Administrator", "LTS Administrator"]
+  }
+}
+
+resource "huaweicloud_lts_group" "function" {
+  group_name  = "%s-logs"
+  ttl_in_days = %d
+}
+
+resource "huaweicloud_lts_stream" "function" {
+  group_id    = huaweicloud_lts_group.function.id
+  stream_name = "function-logs"
+}
+
+resource "huaweicloud_fgs_function" "function" {
+  name          = "%s"
+  app           = "default"
+  agency        = huaweicloud_identity_agency.function.name
+  handler       = "index.handler"
+  runtime       = "%s"
+  memory_size   = %d
+  timeout       = %d
+  code_type     = "zip"
+  func_code     = filebase64(data.archive_file.function_package.output_path)
+  vpc_id        = %s.id
+  network_id    = %s.id
+  log_group_id  = huaweicloud_lts_group.function.id
+  log_stream_id = huaweicloud_lts_stream.function.id
+
+  tags = local.common_tags
+}
+`, hclStringContent(spec.Name), hclStringContent(spec.Name), region, hclStringContent(spec.Name), spec.LogRetentionDays,
+		hclStringContent(spec.Name), runtime, spec.MemorySize, spec.Timeout, vpcAddress("huawei", vpc.Name), subnetAddress("huawei", subnet.Name))
+}
+
+// generateTencentFunction 生成腾讯云SCF配置
+func generateTencentFunction(spec functionSpec, vpc models.VPC) string {
+	runtime, ok := tencentNodeRuntimes[spec.LanguageVersion]
+	if !ok {
+		runtime = tencentNodeRuntimes["18"]
+	}
+	if spec.Language == "python" {
+		runtime = "Python" + spec.LanguageVersion
+	}
+
+	subnet := spec.Subnets[0]
+	return fmt.Sprintf(`resource "tencentcloud_cam_role" "function" {
+  name        = "%s-role"
+  description = "Execution role for SCF function %s"
+  document = jsonencode({
+    version = "2.0"
+    statement = [{
+      action    = ["name/sts:AssumeRole"]
+      effect    = "allow"
+      principal = { service = ["scf.qcloud.com"] }
+    }]
+  })
+}
+
+resource "tencentcloud_cam_role_policy_attachment_by_name" "function_log_access" {
+  role_name   = tencentcloud_cam_role.function.name
+  policy_name = "QcloudCLSFullAccess"
+}
+
+resource "tencentcloud_cls_logset" "function" {
+  logset_name = "%s-logs"
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_cls_topic" "function" {
+  topic_name = "function-logs"
+  logset_id  = tencentcloud_cls_logset.function.id
+  period     = %d
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_scf_function" "function" {
+  name          = "%s"
+  handler       = "index.handler"
+  runtime       = "%s"
+  mem_size      = %d
+  timeout       = %d
+  zip_file      = data.archive_file.function_package.output_path
+  role          = tencentcloud_cam_role.function.name
+  vpc_id        = %s.id
+  subnet_id     = %s.id
+  cls_logset_id = tencentcloud_cls_logset.function.id
+  cls_topic_id  = tencentcloud_cls_topic.function.id
+}
+`, hclStringContent(spec.Name), hclStringContent(spec.Name), hclStringContent(spec.Name), spec.LogRetentionDays, hclStringContent(spec.Name), runtime, spec.MemorySize, spec.Timeout,
+		vpcAddress("tencent", vpc.Name), subnetAddress("tencent", subnet.Name))
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateFunctionConfigSubnets(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		region    string
+		component string
+		props     map[string]interface{}
+		want      string
+	}{
+		{"aws all private subnets", "aws", "us-east-1", "lambda", nil, "subnet_ids         = [aws_subnet.app-a.id, aws_subnet.app-b.id]"},
+		{"aws named subnets skip public and other vpc", "aws", "us-east-1", "lambda", map[string]interface{}{"subnets": "pub,app-b,other-a"}, "subnet_ids         = [aws_subnet.app-b.id]"},
+		{"tencent first private subnet", "tencent", "ap-guangzhou", "scf", nil, "subnet_id     = tencentcloud_subnet.app-a.id"},
+		{"public subnet only", "aws", "us-east-1", "lambda", map[string]interface{}{"subnets": "pub"}, ""},
+		{"function name breaking out of the string", "aws", "us-east-1", "lambda", map[string]interface{}{"function_name": `fn" } resource "null_resource" "pwn" {`}, ""},
+		{"huawei function name must start with a letter", "huawei", "cn-north-4", "functiongraph", map[string]interface{}{"function_name": "1-fn"}, ""},
+		{"tencent function name", "tencent", "ap-guangzhou", "scf", map[string]interface{}{"function_name": "order_handler"}, `name          = "order_handler"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			props := tt.props
+			if props == nil {
+				props = map[string]interface{}{}
+			}
+			body := generateFunctionConfig(testRenderContext(testNetworkConfig(tt.provider, tt.region)), tt.component, props)
+			if tt.want == "" {
+				if body != "" {
+					t.Errorf("expected no config, got:\n%s", body)
+				}
+				return
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("config does not contain %q:\n%s", tt.want, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,600 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// iamPersona 表示标准身份角色及其在各云提供商上授予的系统策略
+// AWS为托管策略ARN，Azure为内置角色名称，其他云提供商为系统策略名称
+type iamPersona struct {
+	Description string
+	Policies    map[string][]string
+	RequireMfa  bool // 紧急访问角色始终要求MFA
+}
+
+// iamPersonas 着陆区的标准身份角色
+var iamPersonas = map[string]iamPersona{
+	"admin": {
+		Description: "Landing zone administrators",
+		Policies: map[string][]string{
+			"aws":      {"arn:aws:iam::aws:policy/AdministratorAccess"},
+			"azure":    {"Owner"},
+			"alicloud": {"AdministratorAccess"},
+			"huawei":   {"Tenant Administrator"},
+			"tencent":  {"AdministratorAccess"},
+		},
+	},
+	"network-ops": {
+		Description: "Network operators managing VPCs, routing and connectivity",
+		Policies: map[string][]string{
+			"aws":      {"arn:aws:iam::aws:policy/job-function/NetworkAdministrator"},
+			"azure":    {"Network Contributor"},
+			"alicloud": {"AliyunVPCFullAccess", "AliyunCENFullAccess"},
+			"huawei":   {"VPC FullAccess"},
+			"tencent":  {"QcloudVPCFullAccess"},
+		},
+	},
+	"read-only": {
+		Description: "Read-only access for auditors and support staff",
+		Policies: map[string][]string{
+			"aws":      {"arn:aws:iam::aws:policy/ReadOnlyAccess"},
+			"azure":    {"Reader"},
+			"alicloud": {"ReadOnlyAccess"},
+			"huawei":   {"Tenant Guest"},
+			"tencent":  {"ReadOnlyAccess"},
+		},
+	},
+	"break-glass": {
+		Description: "Emergency access, use only when normal administration is unavailable",
+		Policies: map[string][]string{
+			"aws":      {"arn:aws:iam::aws:policy/AdministratorAccess"},
+			"azure":    {"Owner"},
+			"alicloud": {"AdministratorAccess"},
+			"huawei":   {"Tenant Administrator"},
+			"tencent":  {"AdministratorAccess"},
+		},
+		RequireMfa: true,
+	},
+}
+
+// iamDefaultPersonas 未指定personas属性时生成的身份角色
+var iamDefaultPersonas = []string{"admin", "network-ops", "read-only", "break-glass"}
+
+// iamPolicyNameRegexp 自定义策略名称只能包含字母、数字和 - _
+var iamPolicyNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]{0,63}$`)
+
+// iamTrustedAccountRegexps 各云提供商受信任账号ID的格式
+// AWS账号ID为12位数字，阿里云账号ID为16位数字，腾讯云主账号UIN为数字
+var iamTrustedAccountRegexps = map[string]*regexp.Regexp{
+	"aws":      regexp.MustCompile(`^\d{12}$`),
+	"alicloud": regexp.MustCompile(`^\d{16}$`),
+	"tencent":  regexp.MustCompile(`^\d{5,20}$`),
+}
+
+// iamPolicy 表示校验后的自定义策略，Document为规范化后的策略文档
+type iamPolicy struct {
+	Name     string
+	Label    string
+	Persona  string
+	Document map[string]interface{}
+}
+
+// generateIamBaselineConfig 生成标准身份角色（或用户组）、系统策略授权和自定义策略
+// 自定义策略校验失败时返回错误，不生成IAM配置
+func generateIamBaselineConfig(config renderContext, propsMap map[string]interface{}) (string, error) {
+	provider := config.CloudProvider
+	if _, ok := iamPersonas["admin"].Policies[provider]; !ok {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持IAM基线组件", provider))
+		return "", nil
+	}
+
+	personas := resolveIamPersonas(config, propsMap)
+	if len(personas) == 0 {
+		reportWarn(config, "IAM基线组件没有有效的身份角色，未生成IAM配置")
+		return "", nil
+	}
+	policies, err := resolveIamPolicies(provider, propsMap, personas)
+	if err != nil {
+		return "", err
+	}
+	prefix := getStringProp(propsMap, "name_prefix", "landing-zone")
+	requireMfa := getBoolProp(propsMap, "require_mfa", true)
+	trustedAccount := getStringProp(propsMap, "trusted_account_id", "")
+	if pattern, ok := iamTrustedAccountRegexps[provider]; ok && trustedAccount != "" && !pattern.MatchString(trustedAccount) {
+		return "", fmt.Errorf("受信任账号ID %q 不是有效的 %s 账号ID", trustedAccount, provider)
+	}
+
+	var iamConfig string
+	switch provider {
+	case "aws":
+		iamConfig = generateAwsIamBaseline(prefix, personas, policies, trustedAccount, requireMfa)
+	case "azure":
+		iamConfig = generateAzureIamBaseline(prefix, personas, policies)
+	case "alicloud":
+		iamConfig = generateAlicloudRamBaseline(prefix, personas, policies, trustedAccount)
+	case "huawei":
+		iamConfig = generateHuaweiIamBaseline(prefix, personas, policies)
+	case "tencent":
+		iamConfig = generateTencentCamBaseline(prefix, personas, policies, trustedAccount)
+	}
+	LogInfo(fmt.Sprintf("已生成IAM基线配置: 身份角色=%s, 自定义策略=%d", strings.Join(personas, ","), len(policies)))
+	return iamConfig, nil
+}
+
+// resolveIamPersonas 解析需要生成的身份角色，忽略未知和重复的角色
+func resolveIamPersonas(config renderContext, propsMap map[string]interface{}) []string {
+	names := getStringListProp(propsMap, "personas")
+	if len(names) == 0 {
+		names = iamDefaultPersonas
+	}
+
+	var personas []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := iamPersonas[name]; !ok {
+			reportWarn(config, fmt.Sprintf("未知的身份角色 %s，支持 admin、network-ops、read-only 和 break-glass", name))
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			personas = append(personas, name)
+		}
+	}
+	return personas
+}
+
+// resolveIamPolicies 解析并校验自定义策略，任何一个策略无效时返回错误
+func resolveIamPolicies(provider string, propsMap map[string]interface{}, personas []string) ([]iamPolicy, error) {
+	var definitions []models.IamPolicy
+	if !decodeListProp(propsMap, "custom_policies", &definitions) && hasListProp(propsMap, "custom_policies") {
+		return nil, errors.New("自定义策略 custom_policies 不是有效的策略列表")
+	}
+
+	enabled := make(map[string]bool)
+	for _, persona := range personas {
+		enabled[persona] = true
+	}
+
+	var policies []iamPolicy
+	labels := make(map[string]bool)
+	for _, definition := range definitions {
+		if !iamPolicyNameRegexp.MatchString(definition.Name) {
+			return nil, fmt.Errorf("自定义策略名称 %s 无效，只能包含字母、数字、- 和 _，且以字母开头", definition.Name)
+		}
+		persona := strings.ToLower(strings.TrimSpace(definition.Persona))
+		if !enabled[persona] {
+			return nil, fmt.Errorf("自定义策略 %s 关联的身份角色 %s 未启用", definition.Name, definition.Persona)
+		}
+		document, err := validateIamPolicyDocument(provider, definition.Document)
+		if err != nil {
+			return nil, fmt.Errorf("自定义策略 %s 校验失败: %v", definition.Name, err)
+		}
+
+		label := "custom_" + strings.ToLower(strings.ReplaceAll(definition.Name, "-", "_"))
+		if labels[label] {
+			return nil, fmt.Errorf("自定义策略 %s 重复定义", definition.Name)
+		}
+		labels[label] = true
+		policies = append(policies, iamPolicy{Name: definition.Name, Label: label, Persona: persona, Document: document})
+	}
+	return policies, nil
+}
+
+// validateIamPolicyDocument 按云提供商的策略语法校验自定义策略文档
+// 除语法外还拒绝对所有资源允许所有操作的语句，管理员权限只能通过标准身份角色授予
+func validateIamPolicyDocument(provider, document string) (map[string]interface{}, error) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(document), &parsed); err != nil {
+		return nil, fmt.Errorf("策略文档不是有效的JSON对象: %v", err)
+	}
+
+	if provider == "azure" {
+		actions, _ := parsed["actions"].([]interface{})
+		dataActions, _ := parsed["dataActions"].([]interface{})
+		if len(actions) == 0 && len(dataActions) == 0 {
+			return nil, errors.New("Azure角色权限必须包含actions或dataActions")
+		}
+		if iamContains(iamStringList(parsed["actions"]), "*") {
+			return nil, errors.New("不允许授予所有操作（*），请使用admin身份角色")
+		}
+		return parsed, nil
+	}
+
+	// 腾讯云CAM策略使用小写字段名
+	versionKey, statementKey, effectKey, actionKey, resourceKey := "Version", "Statement", "Effect", "Action", "Resource"
+	versions := map[string]string{"aws": "2012-10-17", "alicloud": "1", "huawei": "1.1", "tencent": "2.0"}
+	if provider == "tencent" {
+		versionKey, statementKey, effectKey, actionKey, resourceKey = "version", "statement", "effect", "action", "resource"
+	}
+
+	if version, _ := parsed[versionKey].(string); version != versions[provider] {
+		return nil, fmt.Errorf("%s必须为 %s", versionKey, versions[provider])
+	}
+
+	var statements []interface{}
+	switch value := parsed[statementKey].(type) {
+	case []interface{}:
+		statements = value
+	case map[string]interface{}:
+		statements = []interface{}{value}
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("%s不能为空", statementKey)
+	}
+
+	for i, item := range statements {
+		statement, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("第 %d 条语句不是JSON对象", i+1)
+		}
+		effect, _ := statement[effectKey].(string)
+		if !strings.EqualFold(effect, "allow") && !strings.EqualFold(effect, "deny") {
+			return nil, fmt.Errorf("第 %d 条语句的%s必须为Allow或Deny", i+1, effectKey)
+		}
+		actions := iamStringList(statement[actionKey])
+		if len(actions) == 0 && statement["NotAction"] == nil {
+			return nil, fmt.Errorf("第 %d 条语句缺少%s", i+1, actionKey)
+		}
+		// 华为云策略可以省略Resource，表示所有资源
+		resources := iamStringList(statement[resourceKey])
+		if len(resources) == 0 && statement["NotResource"] == nil && provider != "huawei" {
+			return nil, fmt.Errorf("第 %d 条语句缺少%s", i+1, resourceKey)
+		}
+		if strings.EqualFold(effect, "allow") && iamContains(actions, "*") && (len(resources) == 0 || iamContains(resources, "*")) {
+			return nil, fmt.Errorf("第 %d 条语句对所有资源允许所有操作，请使用admin身份角色", i+1)
+		}
+	}
+	return parsed, nil
+}
+
+// iamStringList 将策略中字符串或字符串数组类型的字段转换为字符串列表
+func iamStringList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			if itemStr, ok := item.(string); ok {
+				items = append(items, itemStr)
+			}
+		}
+		return items
+	}
+	return nil
+}
+
+// iamContains 判断列表中是否包含指定值
+func iamContains(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// iamPolicyHeredoc 将策略文档渲染为heredoc，并转义Terraform插值语法，例如 ${aws:username}
+func iamPolicyHeredoc(document map[string]interface{}) string {
+	data, _ := json.MarshalIndent(document, "", "  ")
+	escaped := strings.NewReplacer("${", "$${", "%{", "%%{").Replace(string(data))
+	return "<<POLICY\n" + escaped + "\nPOLICY"
+}
+
+// iamLabel 将身份角色名称转换为Terraform资源名称
+func iamLabel(persona string) string {
+	return strings.ReplaceAll(persona, "-", "_")
+}
+
+// generateAwsIamBaseline 生成AWS IAM角色，角色信任本账户或指定账户，并附加托管策略和自定义策略
+func generateAwsIamBaseline(prefix string, personas []string, policies []iamPolicy, trustedAccount string, requireMfa bool) string {
+	var iam strings.Builder
+
+	principal := fmt.Sprintf(`"arn:aws:iam::%s:root"`, trustedAccount)
+	if trustedAccount == "" {
+		iam.WriteString(`data "aws_caller_identity" "iam" {}
+
+`)
+		principal = `"arn:aws:iam::${data.aws_caller_identity.iam.account_id}:root"`
+	}
+
+	for _, persona := range personas {
+		definition := iamPersonas[persona]
+		label := iamLabel(persona)
+
+		condition := ""
+		if requireMfa || definition.RequireMfa {
+			condition = `
+      Condition = { Bool = { "aws:MultiFactorAuthPresent" = "true" } }`
+		}
+		iam.WriteString(fmt.Sprintf(`resource "aws_iam_role" "%s" {
+  name                 = "%s-%s"
+  description          = "%s"
+  max_session_duration = %d
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Principal = { AWS = %s }
+      Action    = "sts:AssumeRole"%s
+    }]
+  })
+
+  tags = {
+    Persona = "%s"
+  }
+}
+
+`, label, hclStringContent(prefix), persona, definition.Description, iamSessionDuration(definition), principal, condition, persona))
+
+		for i, policyArn := range definition.Policies["aws"] {
+			iam.WriteString(fmt.Sprintf(`resource "aws_iam_role_policy_attachment" "%s_%d" {
+  role       = aws_iam_role.%s.name
+  policy_arn = "%s"
+}
+
+`, label, i+1, label, policyArn))
+		}
+	}
+
+	for _, policy := range policies {
+		iam.WriteString(fmt.Sprintf(`resource "aws_iam_policy" "%s" {
+  name   = "%s-%s"
+  policy = %s
+}
+
+resource "aws_iam_role_policy_attachment" "%s" {
+  role       = aws_iam_role.%s.name
+  policy_arn = aws_iam_policy.%s.arn
+}
+
+`, policy.Label, hclStringContent(prefix), policy.Name, iamPolicyHeredoc(policy.Document), policy.Label, iamLabel(policy.Persona), policy.Label))
+	}
+	return iam.String()
+}
+
+// iamSessionDuration 返回角色的最长会话时间，紧急访问角色限制为1小时
+func iamSessionDuration(persona iamPersona) int {
+	if persona.RequireMfa {
+		return 3600
+	}
+	return 14400
+}
+
+// generateAzureIamBaseline 为每个身份角色创建Entra ID安全组，并在订阅范围分配内置角色和自定义角色
+func generateAzureIamBaseline(prefix string, personas []string, policies []iamPolicy) string {
+	var iam strings.Builder
+	iam.WriteString(`data "azurerm_subscription" "iam" {}
+
+`)
+
+	for _, persona := range personas {
+		definition := iamPersonas[persona]
+		label := iamLabel(persona)
+		iam.WriteString(fmt.Sprintf(`resource "azuread_group" "%s" {
+  display_name     = "%s-%s"
+  description      = "%s"
+  security_enabled = true
+}
+
+`, label, hclStringContent(prefix), persona, definition.Description))
+
+		for i, role := range definition.Policies["azure"] {
+			iam.WriteString(fmt.Sprintf(`resource "azurerm_role_assignment" "%s_%d" {
+  scope                = data.azurerm_subscription.iam.id
+  role_definition_name = "%s"
+  principal_id         = azuread_group.%s.object_id
+}
+
+`, label, i+1, role, label))
+		}
+	}
+
+	for _, policy := range policies {
+		iam.WriteString(fmt.Sprintf(`resource "azurerm_role_definition" "%s" {
+  name        = "%s-%s"
+  scope       = data.azurerm_subscription.iam.id
+  description = "Custom role created by multi-cloud landing zone platform"
+
+  permissions {
+    actions          = %s
+    not_actions      = %s
+    data_actions     = %s
+    not_data_actions = %s
+  }
+
+  assignable_scopes = [data.azurerm_subscription.iam.id]
+}
+
+resource "azurerm_role_assignment" "%s" {
+  scope              = data.azurerm_subscription.iam.id
+  role_definition_id = azurerm_role_definition.%s.role_definition_resource_id
+  principal_id       = azuread_group.%s.object_id
+}
+
+`, policy.Label, hclStringContent(prefix), policy.Name,
+			hclStringList(iamStringList(policy.Document["actions"])), hclStringList(iamStringList(policy.Document["notActions"])),
+			hclStringList(iamStringList(policy.Document["dataActions"])), hclStringList(iamStringList(policy.Document["notDataActions"])),
+			policy.Label, policy.Label, iamLabel(policy.Persona)))
+	}
+	return iam.String()
+}
+
+// generateAlicloudRamBaseline 生成阿里云RAM角色，角色信任本账号或指定账号，并授予系统策略和自定义策略
+func generateAlicloudRamBaseline(prefix string, personas []string, policies []iamPolicy, trustedAccount string) string {
+	var ram strings.Builder
+
+	account := trustedAccount
+	if account == "" {
+		ram.WriteString(`data "alicloud_account" "iam" {}
+
+`)
+		account = "${data.alicloud_account.iam.id}"
+	}
+
+	for _, persona := range personas {
+		definition := iamPersonas[persona]
+		label := iamLabel(persona)
+		ram.WriteString(fmt.Sprintf(`resource "alicloud_ram_role" "%s" {
+  role_name            = "%s-%s"
+  description          = "%s"
+  max_session_duration = %d
+
+  assume_role_policy_document = jsonencode({
+    Version = "1"
+    Statement = [{
+      Effect    = "Allow"
+      Action    = "sts:AssumeRole"
+      Principal = { RAM = ["acs:ram::%s:root"] }
+    }]
+  })
+}
+
+`, label, hclStringContent(prefix), persona, definition.Description, iamSessionDuration(definition), account))
+
+		for i, policyName := range definition.Policies["alicloud"] {
+			ram.WriteString(fmt.Sprintf(`resource "alicloud_ram_role_policy_attachment" "%s_%d" {
+  role_name   = alicloud_ram_role.%s.role_name
+  policy_name = "%s"
+  policy_type = "System"
+}
+
+`, label, i+1, label, policyName))
+		}
+	}
+
+	for _, policy := range policies {
+		ram.WriteString(fmt.Sprintf(`resource "alicloud_ram_policy" "%s" {
+  policy_name     = "%s-%s"
+  policy_document = %s
+}
+
+resource "alicloud_ram_role_policy_attachment" "%s" {
+  role_name   = alicloud_ram_role.%s.role_name
+  policy_name = alicloud_ram_policy.%s.policy_name
+  policy_type = "Custom"
+}
+
+`, policy.Label, hclStringContent(prefix), policy.Name, iamPolicyHeredoc(policy.Document), policy.Label, iamLabel(policy.Persona), policy.Label))
+	}
+	return ram.String()
+}
+
+// generateHuaweiIamBaseline 为每个身份角色创建IAM用户组，并在所有项目中授予系统角色和自定义策略
+func generateHuaweiIamBaseline(prefix string, personas []string, policies []iamPolicy) string {
+	var iam strings.Builder
+
+	for _, persona := range personas {
+		definition := iamPersonas[persona]
+		label := iamLabel(persona)
+		iam.WriteString(fmt.Sprintf(`resource "huaweicloud_identity_group" "%s" {
+  name        = "%s-%s"
+  description = "%s"
+}
+
+`, label, hclStringContent(prefix), persona, definition.Description))
+
+		for i, roleName := range definition.Policies["huawei"] {
+			iam.WriteString(fmt.Sprintf(`data "huaweicloud_identity_role" "%s_%d" {
+  display_name = "%s"
+}
+
+resource "huaweicloud_identity_group_role_assignment" "%s_%d" {
+  group_id   = huaweicloud_identity_group.%s.id
+  role_id    = data.huaweicloud_identity_role.%s_%d.id
+  project_id = "all"
+}
+
+`, label, i+1, roleName, label, i+1, label, label, i+1))
+		}
+	}
+
+	for _, policy := range policies {
+		iam.WriteString(fmt.Sprintf(`resource "huaweicloud_identity_role" "%s" {
+  name        = "%s-%s"
+  description = "Custom policy created by multi-cloud landing zone platform"
+  type        = "AX"
+  policy      = %s
+}
+
+resource "huaweicloud_identity_group_role_assignment" "%s" {
+  group_id   = huaweicloud_identity_group.%s.id
+  role_id    = huaweicloud_identity_role.%s.id
+  project_id = "all"
+}
+
+`, policy.Label, hclStringContent(prefix), policy.Name, iamPolicyHeredoc(policy.Document), policy.Label, iamLabel(policy.Persona), policy.Label))
+	}
+	return iam.String()
+}
+
+// generateTencentCamBaseline 生成腾讯云CAM角色，角色信任本账号或指定账号，并关联预设策略和自定义策略
+func generateTencentCamBaseline(prefix string, personas []string, policies []iamPolicy, trustedAccount string) string {
+	var cam strings.Builder
+
+	account := trustedAccount
+	if account == "" {
+		cam.WriteString(`data "tencentcloud_user_info" "iam" {}
+
+`)
+		account = "${data.tencentcloud_user_info.iam.owner_uin}"
+	}
+
+	for _, persona := range personas {
+		definition := iamPersonas[persona]
+		label := iamLabel(persona)
+		cam.WriteString(fmt.Sprintf(`resource "tencentcloud_cam_role" "%s" {
+  name             = "%s-%s"
+  description      = "%s"
+  console_login    = true
+  session_duration = %d
+
+  document = jsonencode({
+    version = "2.0"
+    statement = [{
+      action    = "name/sts:AssumeRole"
+      effect    = "allow"
+      principal = { qcs = ["qcs::cam::uin/%s:root"] }
+    }]
+  })
+}
+
+`, label, hclStringContent(prefix), persona, definition.Description, iamSessionDuration(definition), account))
+
+		for i, policyName := range definition.Policies["tencent"] {
+			cam.WriteString(fmt.Sprintf(`data "tencentcloud_cam_policies" "%s_%d" {
+  name = "%s"
+}
+
+resource "tencentcloud_cam_role_policy_attachment" "%s_%d" {
+  role_id   = tencentcloud_cam_role.%s.id
+  policy_id = data.tencentcloud_cam_policies.%s_%d.policy_list[0].policy_id
+}
+
+`, label, i+1, policyName, label, i+1, label, label, i+1))
+		}
+	}
+
+	for _, policy := range policies {
+		cam.WriteString(fmt.Sprintf(`resource "tencentcloud_cam_policy" "%s" {
+  name     = "%s-%s"
+  document = %s
+}
+
+resource "tencentcloud_cam_role_policy_attachment" "%s" {
+  role_id   = tencentcloud_cam_role.%s.id
+  policy_id = tencentcloud_cam_policy.%s.id
+}
+
+`, policy.Label, hclStringContent(prefix), policy.Name, iamPolicyHeredoc(policy.Document), policy.Label, iamLabel(policy.Persona), policy.Label))
+	}
+	return cam.String()
+}
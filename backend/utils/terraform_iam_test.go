@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+func TestGenerateIamBaselineConfigCustomPolicies(t *testing.T) {
+	readS3 := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":"*"}]}`
+	tests := []struct {
+		name     string
+		policies interface{}
+		want     string
+		wantErr  bool
+	}{
+		{"valid policy", `[{"name":"read-s3","persona":"read-only","document":` + quoteJSON(readS3) + `}]`, `resource "aws_iam_policy" "custom_read_s3"`, false},
+		{"allow all", `[{"name":"all","persona":"read-only","document":` + quoteJSON(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`) + `}]`, "", true},
+		{"persona not enabled", `[{"name":"read-s3","persona":"network-ops","document":` + quoteJSON(readS3) + `}]`, "", true},
+		{"invalid name", `[{"name":"1bad","persona":"read-only","document":` + quoteJSON(readS3) + `}]`, "", true},
+		{"not a list", `{"name":"read-s3"}`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(models.DeploymentConfig{CloudProvider: "aws", Region: "us-east-1"})
+			props := map[string]interface{}{"personas": "admin,read-only", "custom_policies": tt.policies}
+			body, err := generateIamBaselineConfig(config, props)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateIamBaselineConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && body != "" {
+				t.Errorf("expected no config on error, got:\n%s", body)
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("config does not contain %q:\n%s", tt.want, body)
+			}
+		})
+	}
+}
+
+// quoteJSON 将策略文档编码为JSON字符串
+func quoteJSON(document string) string {
+	return `"` + strings.ReplaceAll(document, `"`, `\"`) + `"`
+}
+
+func TestGenerateIamBaselineConfigUserStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		props    map[string]interface{}
+		want     string
+		wantErr  bool
+	}{
+		{"aws account id", "aws", map[string]interface{}{"trusted_account_id": "123456789012"}, `"arn:aws:iam::123456789012:root"`, false},
+		{"aws account id too short", "aws", map[string]interface{}{"trusted_account_id": "12345"}, "", true},
+		{"aws account id injection", "aws", map[string]interface{}{"trusted_account_id": `123456789012:root" } resource "x" "y" { a = "`}, "", true},
+		{"alicloud account id", "alicloud", map[string]interface{}{"trusted_account_id": "1234567890123456"}, "1234567890123456", false},
+		{"alicloud aws-style account id", "alicloud", map[string]interface{}{"trusted_account_id": "123456789012"}, "", true},
+		{"name prefix escaped", "aws", map[string]interface{}{"name_prefix": `lz" } x = "${file("/etc/passwd")}`}, `name                 = "lz\" } x = \"$${file(\"/etc/passwd\")}-admin"`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(models.DeploymentConfig{CloudProvider: tt.provider, Region: "us-east-1"})
+			body, err := generateIamBaselineConfig(config, tt.props)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateIamBaselineConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && body != "" {
+				t.Errorf("expected no config on error, got:\n%s", body)
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("config does not contain %q:\n%s", tt.want, body)
+			}
+		})
+	}
+}
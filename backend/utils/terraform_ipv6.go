@@ -0,0 +1,280 @@
+package utils
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// ipv6GatewayProviders 支持仅出方向IPv6网关的云提供商及其资源类型
+var ipv6GatewayProviders = map[string]string{
+	"aws":        "aws_egress_only_internet_gateway",
+	"alicloud":   "alicloud_vpc_ipv6_gateway",
+	"volcengine": "volcengine_vpc_ipv6_gateway",
+}
+
+// subnetIpv6Enabled 判断子网是否开启IPv6，子网和所属VPC都开启时才生效
+func subnetIpv6Enabled(vpc models.VPC, subnet models.Subnet) bool {
+	return vpc.EnableIpv6 && subnet.EnableIpv6
+}
+
+// deploymentHasIpv6 判断部署中是否有开启IPv6的VPC
+func deploymentHasIpv6(config renderContext) bool {
+	for _, vpc := range resolveVpcs(config) {
+		if vpc.EnableIpv6 {
+			return true
+		}
+	}
+	return false
+}
+
+// subnetIpv6Index 返回子网在所属VPC开启IPv6的子网中的序号，用于从VPC的IPv6网段中划分/64
+func subnetIpv6Index(config renderContext, subnet models.Subnet) int {
+	vpc := subnetVpc(config, subnet)
+	index := 0
+	for _, other := range resolveSubnets(config) {
+		if other.Name == subnet.Name {
+			return index
+		}
+		if subnetIpv6Enabled(vpc, other) && subnetVpc(config, other).Name == vpc.Name {
+			index++
+		}
+	}
+	return index
+}
+
+// azureUlaPrefix 为Azure VNet生成确定的ULA /48前缀（fdxx:xxxx:xxxx::/48）
+// Azure不分配公网IPv6网段，按VNet名称哈希生成，保证重复生成时结果不变
+func azureUlaPrefix(vpcName string) string {
+	hash := fnv.New64a()
+	hash.Write([]byte(vpcName))
+	sum := hash.Sum64()
+	return fmt.Sprintf("fd%02x:%04x:%04x", byte(sum>>32), uint16(sum>>16), uint16(sum))
+}
+
+// ipv6VpcArgs 返回VPC开启IPv6时需要追加到VPC资源中的参数
+func ipv6VpcArgs(provider string, vpc models.VPC) string {
+	if !vpc.EnableIpv6 {
+		return ""
+	}
+	switch provider {
+	case "aws":
+		return "\n  assign_generated_ipv6_cidr_block = true"
+	case "alicloud":
+		return "\n  enable_ipv6 = true\n  ipv6_isp    = \"BGP\""
+	case "tencent":
+		return "\n  assign_ipv6_cidr_block = true"
+	case "volcengine":
+		return "\n  enable_ipv6 = true"
+	}
+	return ""
+}
+
+// ipv6SubnetArgs 返回子网开启IPv6时需要追加到子网资源中的参数，每个子网分配一个/64
+func ipv6SubnetArgs(config renderContext, subnet models.Subnet) string {
+	vpc := subnetVpc(config, subnet)
+	if !subnetIpv6Enabled(vpc, subnet) {
+		return ""
+	}
+	index := subnetIpv6Index(config, subnet)
+	switch config.CloudProvider {
+	case "aws":
+		return fmt.Sprintf("\n  ipv6_cidr_block                 = cidrsubnet(%s.ipv6_cidr_block, 8, %d)\n  assign_ipv6_address_on_creation = true", vpcAddress("aws", vpc.Name), index)
+	case "alicloud":
+		return fmt.Sprintf("\n  enable_ipv6          = true\n  ipv6_cidr_block_mask = %d", index)
+	case "huawei":
+		return "\n  ipv6_enable = true"
+	case "volcengine":
+		return fmt.Sprintf("\n  enable_ipv6     = true\n  ipv6_cidr_block = %d", index)
+	}
+	return ""
+}
+
+// ipv6AddressSpace 返回Azure VNet或子网追加的IPv6地址前缀，subnetIndex小于0时返回VNet的/48
+func ipv6AddressSpace(vpc models.VPC, subnetIndex int) string {
+	if subnetIndex < 0 {
+		return fmt.Sprintf(", \"%s::/48\"", azureUlaPrefix(vpc.Name))
+	}
+	return fmt.Sprintf(", \"%s:%x::/64\"", azureUlaPrefix(vpc.Name), subnetIndex)
+}
+
+// ipv6GatewayAddress 返回VPC仅出方向IPv6网关的资源地址，不支持的云提供商返回空字符串
+func ipv6GatewayAddress(provider, vpcName string) string {
+	resourceType, ok := ipv6GatewayProviders[provider]
+	if !ok {
+		return ""
+	}
+	return resourceType + "." + vpcName
+}
+
+// generateIpv6Config 生成IPv6网关、默认路由、子网IPv6网段和IPv6网段输出
+// 启用路由表时IPv6默认路由写入每个子网的路由表，否则写入VPC的默认路由表
+func generateIpv6Config(config renderContext) string {
+	var ipv6Config strings.Builder
+	provider := config.CloudProvider
+	outputs := make(map[string]string)
+
+	switch provider {
+	case "baidu":
+		reportWarn(config, "百度云暂不支持通过Terraform开启VPC的IPv6，已忽略IPv6配置")
+		return ""
+	case "azure", "huawei", "tencent":
+		reportWarn(config, fmt.Sprintf("云提供商 %s 没有仅出方向IPv6网关，子网中的实例需要绑定IPv6公网带宽才能访问互联网", provider))
+	}
+
+	for _, vpc := range resolveVpcs(config) {
+		if !vpc.EnableIpv6 {
+			continue
+		}
+		vpcRef := vpcAddress(provider, vpc.Name)
+
+		switch provider {
+		case "aws":
+			ipv6Config.WriteString(fmt.Sprintf(`resource "aws_egress_only_internet_gateway" "%s" {
+  vpc_id = %s.id
+
+  tags = {
+    Name = "%s-eigw"
+  }
+}
+
+`, vpc.Name, vpcRef, vpc.Name))
+			if !config.ComponentConfig.EnableRouteTables {
+				ipv6Config.WriteString(fmt.Sprintf(`resource "aws_route" "%s_ipv6_default" {
+  route_table_id              = %s.main_route_table_id
+  destination_ipv6_cidr_block = "::/0"
+  egress_only_gateway_id      = aws_egress_only_internet_gateway.%s.id
+}
+
+`, vpc.Name, vpcRef, vpc.Name))
+			}
+			outputs[vpc.Name] = vpcRef + ".ipv6_cidr_block"
+		case "alicloud":
+			// 阿里云IPv6网关默认只提供私网通信，实例需开通IPv6公网带宽后才能访问互联网
+			ipv6Config.WriteString(fmt.Sprintf(`resource "alicloud_vpc_ipv6_gateway" "%s" {
+  ipv6_gateway_name = "%s-ipv6gw"
+  vpc_id            = %s.id
+}
+
+`, vpc.Name, vpc.Name, vpcRef))
+			if !config.ComponentConfig.EnableRouteTables {
+				ipv6Config.WriteString(fmt.Sprintf(`resource "alicloud_route_entry" "%s_ipv6_default" {
+  route_table_id        = %s.route_table_id
+  destination_cidrblock = "::/0"
+  nexthop_type          = "Ipv6Gateway"
+  nexthop_id            = alicloud_vpc_ipv6_gateway.%s.id
+}
+
+`, vpc.Name, vpcRef, vpc.Name))
+			}
+			outputs[vpc.Name] = vpcRef + ".ipv6_cidr_block"
+		case "volcengine":
+			ipv6Config.WriteString(fmt.Sprintf(`resource "volcengine_vpc_ipv6_gateway" "%s" {
+  vpc_id = %s.id
+  name   = "%s-ipv6gw"
+}
+
+`, vpc.Name, vpcRef, vpc.Name))
+			if !config.ComponentConfig.EnableRouteTables {
+				reportWarn(config, fmt.Sprintf("火山引擎VPC %s 未启用路由表，IPv6默认路由需要在系统路由表中手动添加", vpc.Name))
+			}
+			outputs[vpc.Name] = vpcRef + ".ipv6_cidr_block"
+		case "tencent":
+			outputs[vpc.Name] = vpcRef + ".ipv6_cidr_block"
+		case "azure":
+			outputs[vpc.Name] = fmt.Sprintf(`"%s::/48"`, azureUlaPrefix(vpc.Name))
+		}
+		LogInfo(fmt.Sprintf("已生成VPC %s 的IPv6配置", vpc.Name))
+	}
+
+	// 腾讯云子网的IPv6网段通过单独的资源从VPC的IPv6网段中分配
+	for _, subnet := range resolveSubnets(config) {
+		vpc := subnetVpc(config, subnet)
+		if subnet.EnableIpv6 && !vpc.EnableIpv6 {
+			reportWarn(config, fmt.Sprintf("子网 %s 开启了IPv6，但所属VPC %s 未开启IPv6，已忽略", subnet.Name, vpc.Name))
+			continue
+		}
+		if !subnetIpv6Enabled(vpc, subnet) {
+			continue
+		}
+		index := subnetIpv6Index(config, subnet)
+		if index > 255 {
+			reportError(config, fmt.Sprintf("VPC %s 中开启IPv6的子网超过256个，子网 %s 无法分配/64网段", vpc.Name, subnet.Name))
+			continue
+		}
+
+		switch provider {
+		case "tencent":
+			ipv6Config.WriteString(fmt.Sprintf(`resource "tencentcloud_vpc_ipv6_subnet_cidr_block" "%s" {
+  vpc_id = %s.id
+
+  ipv6_subnet_cidr_blocks {
+    subnet_id       = %s.id
+    ipv6_cidr_block = cidrsubnet(%s.ipv6_cidr_block, 8, %d)
+  }
+}
+
+`, subnet.Name, vpcAddress(provider, vpc.Name), subnetAddress(provider, subnet.Name), vpcAddress(provider, vpc.Name), index))
+			outputs[subnet.Name] = fmt.Sprintf("cidrsubnet(%s.ipv6_cidr_block, 8, %d)", vpcAddress(provider, vpc.Name), index)
+		case "huawei":
+			outputs[subnet.Name] = subnetAddress(provider, subnet.Name) + ".ipv6_cidr"
+		case "azure":
+			outputs[subnet.Name] = fmt.Sprintf(`"%s:%x::/64"`, azureUlaPrefix(vpc.Name), index)
+		default:
+			outputs[subnet.Name] = subnetAddress(provider, subnet.Name) + ".ipv6_cidr_block"
+		}
+	}
+
+	if len(outputs) > 0 {
+		names := make([]string, 0, len(outputs))
+		for name := range outputs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		width := 0
+		for _, name := range names {
+			if len(name) > width {
+				width = len(name)
+			}
+		}
+
+		ipv6Config.WriteString("output \"ipv6_cidr_blocks\" {\n  value = {\n")
+		for _, name := range names {
+			ipv6Config.WriteString(fmt.Sprintf("    %-*s = %s\n", width, name, outputs[name]))
+		}
+		ipv6Config.WriteString("  }\n}\n\n")
+	}
+
+	return ipv6Config.String()
+}
+
+// ipv6TopologyNodes 为开启IPv6的VPC生成仅出方向IPv6网关节点及其与VPC的连接
+func ipv6TopologyNodes(config renderContext) ([]map[string]interface{}, []map[string]interface{}) {
+	var nodes, edges []map[string]interface{}
+	if _, ok := ipv6GatewayProviders[config.CloudProvider]; !ok {
+		return nodes, edges
+	}
+	for _, vpc := range resolveVpcs(config) {
+		if !vpc.EnableIpv6 {
+			continue
+		}
+		id := vpc.Name + "-ipv6gw"
+		nodes = append(nodes, map[string]interface{}{
+			"id":   id,
+			"type": "ipv6-gateway",
+			"name": id,
+			"data": map[string]interface{}{
+				"resource": ipv6GatewayAddress(config.CloudProvider, vpc.Name),
+			},
+		})
+		edges = append(edges, map[string]interface{}{
+			"source": id,
+			"target": vpc.Name,
+			"label":  "attached-to",
+		})
+	}
+	return nodes, edges
+}
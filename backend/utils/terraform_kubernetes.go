@@ -0,0 +1,927 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// defaultKubernetesVersions 各云提供商默认的Kubernetes版本
+var defaultKubernetesVersions = map[string]string{
+	"aws":        "1.30",
+	"azure":      "1.30",
+	"alicloud":   "1.30.1-aliyun.1",
+	"huawei":     "v1.29",
+	"tencent":    "1.30.0",
+	"volcengine": "v1.30",
+}
+
+// kubernetesVersionRegexps 各云提供商Kubernetes版本号的格式，与默认版本的写法一致
+var kubernetesVersionRegexps = map[string]*regexp.Regexp{
+	"aws":        regexp.MustCompile(`^\d+\.\d+$`),
+	"azure":      regexp.MustCompile(`^\d+\.\d+(\.\d+)?$`),
+	"alicloud":   regexp.MustCompile(`^\d+\.\d+\.\d+-aliyun\.\d+$`),
+	"huawei":     regexp.MustCompile(`^v\d+\.\d+$`),
+	"tencent":    regexp.MustCompile(`^\d+\.\d+\.\d+$`),
+	"volcengine": regexp.MustCompile(`^v\d+\.\d+$`),
+}
+
+// defaultKubernetesInstanceTypes 各云提供商节点池默认的实例规格
+var defaultKubernetesInstanceTypes = map[string]string{
+	"aws":        "t3.large",
+	"azure":      "Standard_D4s_v5",
+	"alicloud":   "ecs.g6.xlarge",
+	"huawei":     "c7.xlarge.2",
+	"tencent":    "S5.LARGE8",
+	"volcengine": "ecs.g3i.xlarge",
+}
+
+// kubernetesNodePoolLabel 返回节点池的Terraform资源名称
+func kubernetesNodePoolLabel(pool models.KubernetesNodePool) string {
+	return "kubernetes_" + strings.ReplaceAll(pool.Name, "-", "_")
+}
+
+// kubernetesNodePoolAutoscaling 判断节点池是否开启自动伸缩
+func kubernetesNodePoolAutoscaling(pool models.KubernetesNodePool) bool {
+	return pool.MinSize != pool.MaxSize
+}
+
+// kubernetesSpec 表示托管Kubernetes组件的解析结果
+type kubernetesSpec struct {
+	Name           string
+	Version        string
+	NodePools      []models.KubernetesNodePool
+	Subnets        []models.Subnet
+	PublicEndpoint bool
+	PodCidr        string
+	ServiceCidr    string
+	KeyName        string
+	SecurityGroups []string // 安全组组件中定义的安全组引用
+}
+
+// generateKubernetesConfig 生成跨云托管Kubernetes组件的Terraform配置：EKS、AKS、ACK、CCE、TKE和VKE
+// 集群入口地址和CA证书作为敏感输出导出，未设置key_name时节点登录密码由random_password生成
+func generateKubernetesConfig(config renderContext, propsMap map[string]interface{}) string {
+	provider := config.CloudProvider
+	if _, ok := defaultKubernetesVersions[provider]; !ok {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持托管Kubernetes组件", provider))
+		return ""
+	}
+
+	spec := kubernetesSpec{
+		Name:           strings.ToLower(getStringProp(propsMap, "name", "landing-zone-k8s")),
+		Version:        getStringProp(propsMap, "version", defaultKubernetesVersions[provider]),
+		PublicEndpoint: getBoolProp(propsMap, "public_endpoint", false),
+		PodCidr:        getStringProp(propsMap, "pod_cidr", "172.20.0.0/16"),
+		ServiceCidr:    getStringProp(propsMap, "service_cidr", "172.21.0.0/20"),
+		KeyName:        getStringProp(propsMap, "key_name", ""),
+		SecurityGroups: resolveSecurityGroupRefs(config, propsMap),
+	}
+	if !kubernetesVersionRegexps[provider].MatchString(spec.Version) {
+		reportError(config, fmt.Sprintf("Kubernetes集群 %s 的版本 %q 格式无效，默认版本为 %s，跳过生成", spec.Name, spec.Version, defaultKubernetesVersions[provider]))
+		return ""
+	}
+	spec.NodePools = resolveKubernetesNodePools(config, provider, propsMap)
+	if len(spec.NodePools) == 0 {
+		reportError(config, fmt.Sprintf("Kubernetes集群 %s 没有有效的节点池，跳过生成", spec.Name))
+		return ""
+	}
+
+	// 集群的子网必须属于同一个VPC
+	subnets := selectSubnets(config, getStringListProp(propsMap, "subnets"))
+	vpc := subnetVpc(config, subnets[0])
+	for _, subnet := range subnets {
+		if subnetVpc(config, subnet).Name != vpc.Name {
+			reportWarn(config, fmt.Sprintf("子网 %s 不属于VPC %s，Kubernetes集群已忽略该子网", subnet.Name, vpc.Name))
+			continue
+		}
+		spec.Subnets = append(spec.Subnets, subnet)
+	}
+	zones := databaseSubnetZones(config, spec.Subnets)
+	if provider == "aws" && len(zones) < 2 {
+		reportError(config, fmt.Sprintf("Kubernetes集群 %s 的子网只覆盖了 %d 个可用区，EKS至少需要2个不同可用区的子网，跳过生成", spec.Name, len(zones)))
+		return ""
+	}
+
+	// Pod和Service网段不能与VPC和彼此重叠
+	if err := validateKubernetesCidrs(config, spec); err != nil {
+		reportError(config, fmt.Sprintf("Kubernetes集群 %s 的网段配置无效，跳过生成: %v", spec.Name, err))
+		return ""
+	}
+
+	var kubernetesConfig strings.Builder
+	if spec.KeyName == "" && provider != "aws" && provider != "azure" {
+		kubernetesConfig.WriteString(`resource "random_password" "kubernetes_node" {
+  length           = 20
+  special          = true
+  override_special = "!#%^*()-_=+"
+  min_lower        = 2
+  min_upper        = 2
+  min_numeric      = 2
+  min_special      = 2
+}
+
+`)
+	}
+
+	switch provider {
+	case "aws":
+		kubernetesConfig.WriteString(generateAwsKubernetes(spec, vpc))
+	case "azure":
+		kubernetesConfig.WriteString(generateAzureKubernetes(config, spec, vpc))
+	case "alicloud":
+		kubernetesConfig.WriteString(generateAlicloudKubernetes(spec, vpc))
+	case "huawei":
+		kubernetesConfig.WriteString(generateHuaweiKubernetes(config, spec, vpc))
+	case "tencent":
+		kubernetesConfig.WriteString(generateTencentKubernetes(spec, vpc))
+	case "volcengine":
+		kubernetesConfig.WriteString(generateVolcengineKubernetes(spec, vpc))
+	}
+
+	LogInfo(fmt.Sprintf("已生成托管Kubernetes配置: 名称=%s, 版本=%s, 节点池=%d, 子网=%d", spec.Name, spec.Version, len(spec.NodePools), len(spec.Subnets)))
+	return kubernetesConfig.String()
+}
+
+// resolveKubernetesNodePools 解析节点池配置，未配置时使用一个默认节点池
+// 节点数量超出伸缩范围时调整到范围内
+func resolveKubernetesNodePools(config renderContext, provider string, propsMap map[string]interface{}) []models.KubernetesNodePool {
+	var pools []models.KubernetesNodePool
+	if !decodeListProp(propsMap, "node_pools", &pools) {
+		pools = []models.KubernetesNodePool{{Name: "default", Count: 2, MinSize: 1, MaxSize: 3}}
+	}
+
+	var resolved []models.KubernetesNodePool
+	used := make(map[string]bool)
+	for i, pool := range pools {
+		pool.Name = strings.ToLower(strings.TrimSpace(pool.Name))
+		if pool.Name == "" {
+			pool.Name = fmt.Sprintf("pool-%d", i+1)
+		}
+		if !terraformIdentifierRegexp.MatchString(pool.Name) || used[pool.Name] {
+			reportWarn(config, fmt.Sprintf("节点池名称 %s 无效或重复，已忽略", pool.Name))
+			continue
+		}
+		used[pool.Name] = true
+		if pool.InstanceType == "" {
+			pool.InstanceType = defaultKubernetesInstanceTypes[provider]
+		}
+		if err := checkPropertyToken("instance_type", pool.InstanceType); err != nil {
+			reportError(config, fmt.Sprintf("节点池 %s 的配置无效，已忽略: %v", pool.Name, err))
+			continue
+		}
+		if pool.Count <= 0 {
+			pool.Count = 2
+		}
+		if pool.MinSize <= 0 {
+			pool.MinSize = pool.Count
+		}
+		if pool.MaxSize <= 0 {
+			pool.MaxSize = pool.Count
+		}
+		if pool.MinSize > pool.MaxSize {
+			reportWarn(config, fmt.Sprintf("节点池 %s 的最小节点数 %d 大于最大节点数 %d，已交换", pool.Name, pool.MinSize, pool.MaxSize))
+			pool.MinSize, pool.MaxSize = pool.MaxSize, pool.MinSize
+		}
+		if pool.Count < pool.MinSize || pool.Count > pool.MaxSize {
+			count := pool.Count
+			if count < pool.MinSize {
+				pool.Count = pool.MinSize
+			} else {
+				pool.Count = pool.MaxSize
+			}
+			reportWarn(config, fmt.Sprintf("节点池 %s 的节点数 %d 超出伸缩范围 %d-%d，已调整为 %d", pool.Name, count, pool.MinSize, pool.MaxSize, pool.Count))
+		}
+		resolved = append(resolved, pool)
+	}
+	return resolved
+}
+
+// validateKubernetesCidrs 校验Pod和Service网段的格式，并检查与部署中的VPC是否重叠
+func validateKubernetesCidrs(config renderContext, spec kubernetesSpec) error {
+	for _, cidr := range []string{spec.PodCidr, spec.ServiceCidr} {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("网段 %s 格式无效", cidr)
+		}
+		for _, vpc := range resolveVpcs(config) {
+			if cidrsOverlap(cidr, vpc.CIDR) {
+				return fmt.Errorf("网段 %s 与VPC %s 的网段 %s 重叠", cidr, vpc.Name, vpc.CIDR)
+			}
+		}
+	}
+	if cidrsOverlap(spec.PodCidr, spec.ServiceCidr) {
+		return fmt.Errorf("Pod网段 %s 与Service网段 %s 重叠", spec.PodCidr, spec.ServiceCidr)
+	}
+	return nil
+}
+
+// kubernetesSubnetIds 返回集群子网的ID引用
+func kubernetesSubnetIds(provider string, subnets []models.Subnet) []string {
+	subnetIds := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		subnetIds = append(subnetIds, subnetAddress(provider, subnet.Name)+".id")
+	}
+	return subnetIds
+}
+
+// kubernetesOutputs 生成集群入口地址和CA证书的敏感输出
+func kubernetesOutputs(endpoint, certificate string) string {
+	return fmt.Sprintf(`output "kubernetes_endpoint" {
+  value     = %s
+  sensitive = true
+}
+
+output "kubernetes_ca_certificate" {
+  value     = %s
+  sensitive = true
+}
+`, endpoint, certificate)
+}
+
+// generateAwsKubernetes 生成EKS集群、集群和节点IAM角色、控制面安全组和托管节点组
+func generateAwsKubernetes(spec kubernetesSpec, vpc models.VPC) string {
+	subnetIds := hclList(kubernetesSubnetIds("aws", spec.Subnets))
+	securityGroupRefs := append([]string{"aws_security_group.kubernetes_cluster.id"}, spec.SecurityGroups...)
+
+	var eks strings.Builder
+	eks.WriteString(fmt.Sprintf(`data "aws_partition" "kubernetes" {}
+
+resource "aws_iam_role" "kubernetes_cluster" {
+  name = "%s-cluster-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "eks.amazonaws.com" }
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "kubernetes_cluster" {
+  role       = aws_iam_role.kubernetes_cluster.name
+  policy_arn = "arn:${data.aws_partition.kubernetes.partition}:iam::aws:policy/AmazonEKSClusterPolicy"
+}
+
+resource "aws_iam_role" "kubernetes_node" {
+  name = "%s-node-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "ec2.amazonaws.com" }
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "kubernetes_node" {
+  for_each = toset([
+    "AmazonEKSWorkerNodePolicy",
+    "AmazonEKS_CNI_Policy",
+    "AmazonEC2ContainerRegistryReadOnly",
+  ])
+
+  role       = aws_iam_role.kubernetes_node.name
+  policy_arn = "arn:${data.aws_partition.kubernetes.partition}:iam::aws:policy/${each.value}"
+}
+
+resource "aws_security_group" "kubernetes_cluster" {
+  name        = "%s-cluster-sg"
+  description = "Allow Kubernetes API access from within the VPC"
+  vpc_id      = %s.id
+
+  ingress {
+    description = "Kubernetes API from VPC"
+    from_port   = 443
+    to_port     = 443
+    protocol    = "tcp"
+    cidr_blocks = ["%s"]
+  }
+
+  egress {
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  tags = {
+    Name = "%s-cluster-sg"
+  }
+}
+
+resource "aws_eks_cluster" "kubernetes" {
+  name     = "%s"
+  version  = "%s"
+  role_arn = aws_iam_role.kubernetes_cluster.arn
+
+  vpc_config {
+    subnet_ids              = %s
+    security_group_ids      = %s
+    endpoint_private_access = true
+    endpoint_public_access  = %t
+  }
+
+  kubernetes_network_config {
+    service_ipv4_cidr = "%s"
+  }
+
+  tags = {
+    Name = "%s"
+  }
+
+  depends_on = [aws_iam_role_policy_attachment.kubernetes_cluster]
+}
+`, hclStringContent(spec.Name), hclStringContent(spec.Name), hclStringContent(spec.Name), vpcAddress("aws", vpc.Name), vpc.CIDR, hclStringContent(spec.Name),
+		hclStringContent(spec.Name), spec.Version, subnetIds, hclList(securityGroupRefs), spec.PublicEndpoint, spec.ServiceCidr, hclStringContent(spec.Name)))
+
+	for _, pool := range spec.NodePools {
+		remoteAccess := ""
+		if spec.KeyName != "" {
+			remoteAccess = fmt.Sprintf(`
+
+  remote_access {
+    ec2_ssh_key = "%s"
+  }`, hclStringContent(spec.KeyName))
+		}
+		eks.WriteString(fmt.Sprintf(`
+resource "aws_eks_node_group" "%s" {
+  cluster_name    = aws_eks_cluster.kubernetes.name
+  node_group_name = "%s"
+  node_role_arn   = aws_iam_role.kubernetes_node.arn
+  subnet_ids      = %s
+  instance_types  = ["%s"]
+
+  scaling_config {
+    desired_size = %d
+    min_size     = %d
+    max_size     = %d
+  }%s
+
+  tags = {
+    Name = "%s-%s"
+  }
+
+  depends_on = [aws_iam_role_policy_attachment.kubernetes_node]
+}
+`, kubernetesNodePoolLabel(pool), pool.Name, subnetIds, pool.InstanceType, pool.Count, pool.MinSize, pool.MaxSize, remoteAccess, hclStringContent(spec.Name), pool.Name))
+	}
+
+	eks.WriteString("\n" + kubernetesOutputs("aws_eks_cluster.kubernetes.endpoint", "aws_eks_cluster.kubernetes.certificate_authority[0].data"))
+	return eks.String()
+}
+
+// generateAzureKubernetes 生成AKS集群，集群使用用户分配的托管标识，并授予对集群子网的网络参与者角色
+// 第一个节点池作为默认节点池，其余节点池通过单独的资源创建
+func generateAzureKubernetes(config renderContext, spec kubernetesSpec, vpc models.VPC) string {
+	subnet := subnetAddress("azure", spec.Subnets[0].Name)
+	if len(spec.Subnets) > 1 {
+		reportWarn(config, "AKS节点池只能使用一个子网，仅使用第一个子网")
+	}
+	_, serviceNet, _ := net.ParseCIDR(spec.ServiceCidr)
+	dnsServiceIP := make(net.IP, len(serviceNet.IP))
+	copy(dnsServiceIP, serviceNet.IP)
+	dnsServiceIP[len(dnsServiceIP)-1] += 10
+
+	azurePoolName := func(pool models.KubernetesNodePool) string {
+		// AKS节点池名称只能包含小写字母和数字，最多12个字符
+		name := strings.NewReplacer("-", "", "_", "").Replace(pool.Name)
+		if len(name) > 12 {
+			name = name[:12]
+		}
+		return name
+	}
+	autoscaling := func(pool models.KubernetesNodePool, indent string) string {
+		if !kubernetesNodePoolAutoscaling(pool) {
+			return fmt.Sprintf("%snode_count = %d", indent, pool.Count)
+		}
+		return fmt.Sprintf(`%senable_auto_scaling = true
+%snode_count          = %d
+%smin_count           = %d
+%smax_count           = %d`, indent, indent, pool.Count, indent, pool.MinSize, indent, pool.MaxSize)
+	}
+
+	defaultPool := spec.NodePools[0]
+	var aks strings.Builder
+	aks.WriteString(fmt.Sprintf(`resource "azurerm_user_assigned_identity" "kubernetes" {
+  name                = "%s-identity"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+
+  tags = local.common_tags
+}
+
+resource "azurerm_role_assignment" "kubernetes_network" {
+  scope                = %s.id
+  role_definition_name = "Network Contributor"
+  principal_id         = azurerm_user_assigned_identity.kubernetes.principal_id
+}
+
+resource "azurerm_kubernetes_cluster" "kubernetes" {
+  name                = "%s"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+  dns_prefix          = "%s"
+  kubernetes_version  = "%s"
+
+  private_cluster_enabled = %t
+
+  default_node_pool {
+    name           = "%s"
+    vm_size        = "%s"
+    vnet_subnet_id = %s.id
+
+%s
+  }
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.kubernetes.id]
+  }
+
+  network_profile {
+    network_plugin = "azure"
+    network_policy = "azure"
+    service_cidr   = "%s"
+    dns_service_ip = "%s"
+  }
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+
+  depends_on = [azurerm_role_assignment.kubernetes_network]
+}
+`, hclStringContent(spec.Name), subnet, hclStringContent(spec.Name), hclStringContent(spec.Name), spec.Version, !spec.PublicEndpoint,
+		azurePoolName(defaultPool), defaultPool.InstanceType, subnet, autoscaling(defaultPool, "    "),
+		spec.ServiceCidr, dnsServiceIP.String(), hclStringContent(spec.Name)))
+
+	for _, pool := range spec.NodePools[1:] {
+		aks.WriteString(fmt.Sprintf(`
+resource "azurerm_kubernetes_cluster_node_pool" "%s" {
+  name                  = "%s"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.kubernetes.id
+  vm_size               = "%s"
+  vnet_subnet_id        = %s.id
+
+%s
+
+  tags = merge(local.common_tags, {
+    Name = "%s-%s"
+  })
+}
+`, kubernetesNodePoolLabel(pool), azurePoolName(pool), pool.InstanceType, subnet, autoscaling(pool, "  "), hclStringContent(spec.Name), pool.Name))
+	}
+
+	aks.WriteString("\n" + kubernetesOutputs("azurerm_kubernetes_cluster.kubernetes.kube_config[0].host", "azurerm_kubernetes_cluster.kubernetes.kube_config[0].cluster_ca_certificate"))
+	return aks.String()
+}
+
+// generateAlicloudKubernetes 生成ACK托管版集群、容器服务关联角色、节点安全组和节点池
+func generateAlicloudKubernetes(spec kubernetesSpec, vpc models.VPC) string {
+	vswitchIds := hclList(kubernetesSubnetIds("alicloud", spec.Subnets))
+	securityGroupRef := "alicloud_security_group.kubernetes.id"
+	if len(spec.SecurityGroups) > 0 {
+		securityGroupRef = spec.SecurityGroups[0]
+	}
+	login := `password             = random_password.kubernetes_node.result`
+	if spec.KeyName != "" {
+		login = fmt.Sprintf(`key_name             = "%s"`, hclStringContent(spec.KeyName))
+	}
+
+	var ack strings.Builder
+	ack.WriteString(fmt.Sprintf(`# ACK通过容器服务的服务关联角色访问VPC、ECS和负载均衡等资源
+resource "alicloud_resource_manager_service_linked_role" "kubernetes" {
+  service_name = "cs.aliyuncs.com"
+}
+
+resource "alicloud_security_group" "kubernetes" {
+  name        = "%s-sg"
+  description = "Kubernetes nodes security group"
+  vpc_id      = %s.id
+
+  tags = merge(local.common_tags, {
+    Name = "%s-sg"
+  })
+}
+
+resource "alicloud_security_group_rule" "kubernetes_vpc_ingress" {
+  type              = "ingress"
+  security_group_id = alicloud_security_group.kubernetes.id
+  ip_protocol       = "all"
+  port_range        = "-1/-1"
+  nic_type          = "intranet"
+  policy            = "accept"
+  priority          = 1
+  cidr_ip           = "%s"
+  description       = "Allow traffic from within the VPC"
+}
+
+resource "alicloud_security_group_rule" "kubernetes_pod_ingress" {
+  type              = "ingress"
+  security_group_id = alicloud_security_group.kubernetes.id
+  ip_protocol       = "all"
+  port_range        = "-1/-1"
+  nic_type          = "intranet"
+  policy            = "accept"
+  priority          = 1
+  cidr_ip           = "%s"
+  description       = "Allow traffic from pods"
+}
+
+resource "alicloud_cs_managed_kubernetes" "kubernetes" {
+  name                 = "%s"
+  cluster_spec         = "ack.pro.small"
+  version              = "%s"
+  vswitch_ids          = %s
+  pod_cidr             = "%s"
+  service_cidr         = "%s"
+  new_nat_gateway      = false
+  slb_internet_enabled = %t
+  security_group_id    = %s
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+
+  depends_on = [alicloud_resource_manager_service_linked_role.kubernetes]
+}
+`, hclStringContent(spec.Name), vpcAddress("alicloud", vpc.Name), hclStringContent(spec.Name), vpc.CIDR, spec.PodCidr,
+		hclStringContent(spec.Name), spec.Version, vswitchIds, spec.PodCidr, spec.ServiceCidr, spec.PublicEndpoint, securityGroupRef, hclStringContent(spec.Name)))
+
+	for _, pool := range spec.NodePools {
+		scaling := fmt.Sprintf(`desired_size = %d`, pool.Count)
+		if kubernetesNodePoolAutoscaling(pool) {
+			scaling = fmt.Sprintf(`scaling_config {
+    enable   = true
+    min_size = %d
+    max_size = %d
+  }`, pool.MinSize, pool.MaxSize)
+		}
+		ack.WriteString(fmt.Sprintf(`
+resource "alicloud_cs_kubernetes_node_pool" "%s" {
+  cluster_id           = alicloud_cs_managed_kubernetes.kubernetes.id
+  node_pool_name       = "%s"
+  vswitch_ids          = %s
+  instance_types       = ["%s"]
+  security_group_ids   = [%s]
+  system_disk_category = "cloud_essd"
+  system_disk_size     = 120
+  %s
+
+  %s
+
+  tags = merge(local.common_tags, {
+    Name = "%s-%s"
+  })
+}
+`, kubernetesNodePoolLabel(pool), pool.Name, vswitchIds, pool.InstanceType, securityGroupRef, login, scaling, hclStringContent(spec.Name), pool.Name))
+	}
+
+	ack.WriteString("\n" + kubernetesOutputs(`alicloud_cs_managed_kubernetes.kubernetes.connections["api_server_intranet"]`, `alicloud_cs_managed_kubernetes.kubernetes.certificate_authority["cluster_cert"]`))
+	return ack.String()
+}
+
+// generateHuaweiKubernetes 生成CCE集群、集群安全组和节点池
+// CCE使用的委托在首次开通容器服务时由控制台授权创建，不在此生成
+func generateHuaweiKubernetes(config renderContext, spec kubernetesSpec, vpc models.VPC) string {
+	subnet := subnetAddress("huawei", spec.Subnets[0].Name)
+	if len(spec.Subnets) > 1 {
+		reportWarn(config, "CCE集群和节点池只能使用一个子网，仅使用第一个子网")
+	}
+	securityGroupRef := "huaweicloud_networking_secgroup.kubernetes.id"
+	if len(spec.SecurityGroups) > 0 {
+		securityGroupRef = spec.SecurityGroups[0]
+	}
+	login := `password           = random_password.kubernetes_node.result`
+	if spec.KeyName != "" {
+		login = fmt.Sprintf(`key_pair           = "%s"`, hclStringContent(spec.KeyName))
+	}
+
+	var cce strings.Builder
+	cce.WriteString(fmt.Sprintf(`resource "huaweicloud_networking_secgroup" "kubernetes" {
+  name        = "%s-sg"
+  description = "Kubernetes cluster security group"
+}
+
+resource "huaweicloud_networking_secgroup_rule" "kubernetes_vpc_ingress" {
+  security_group_id = huaweicloud_networking_secgroup.kubernetes.id
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  remote_ip_prefix  = "%s"
+}
+
+resource "huaweicloud_networking_secgroup_rule" "kubernetes_pod_ingress" {
+  security_group_id = huaweicloud_networking_secgroup.kubernetes.id
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  remote_ip_prefix  = "%s"
+}
+
+resource "huaweicloud_cce_cluster" "kubernetes" {
+  name                   = "%s"
+  flavor_id              = "cce.s2.small"
+  cluster_version        = "%s"
+  vpc_id                 = %s.id
+  subnet_id              = %s.id
+  security_group_id      = %s
+  container_network_type = "vpc-router"
+  container_network_cidr = "%s"
+  service_network_cidr   = "%s"
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+`, hclStringContent(spec.Name), vpc.CIDR, spec.PodCidr, hclStringContent(spec.Name), spec.Version, vpcAddress("huawei", vpc.Name), subnet,
+		securityGroupRef, spec.PodCidr, spec.ServiceCidr, hclStringContent(spec.Name)))
+
+	for _, pool := range spec.NodePools {
+		cce.WriteString(fmt.Sprintf(`
+resource "huaweicloud_cce_node_pool" "%s" {
+  cluster_id         = huaweicloud_cce_cluster.kubernetes.id
+  name               = "%s"
+  os                 = "EulerOS 2.9"
+  flavor_id          = "%s"
+  availability_zone  = "random"
+  subnet_id          = %s.id
+  initial_node_count = %d
+  scall_enable       = %t
+  min_node_count     = %d
+  max_node_count     = %d
+  %s
+
+  root_volume {
+    size       = 50
+    volumetype = "SSD"
+  }
+
+  data_volumes {
+    size       = 100
+    volumetype = "SSD"
+  }
+
+  tags = merge(local.common_tags, {
+    Name = "%s-%s"
+  })
+}
+`, kubernetesNodePoolLabel(pool), pool.Name, pool.InstanceType, subnet, pool.Count, kubernetesNodePoolAutoscaling(pool), pool.MinSize, pool.MaxSize,
+			login, hclStringContent(spec.Name), pool.Name))
+	}
+
+	cce.WriteString("\n" + kubernetesOutputs("huaweicloud_cce_cluster.kubernetes.certificate_clusters[0].server", "huaweicloud_cce_cluster.kubernetes.certificate_clusters[0].certificate_authority_data"))
+	return cce.String()
+}
+
+// generateTencentKubernetes 生成TKE托管集群、节点安全组和节点池
+// TKE使用的服务角色在首次开通容器服务时由控制台授权创建，不在此生成
+func generateTencentKubernetes(spec kubernetesSpec, vpc models.VPC) string {
+	subnetIds := kubernetesSubnetIds("tencent", spec.Subnets)
+	securityGroupRefs := spec.SecurityGroups
+	if len(securityGroupRefs) == 0 {
+		securityGroupRefs = []string{"tencentcloud_security_group.kubernetes.id"}
+	}
+	login := `password           = random_password.kubernetes_node.result`
+	if spec.KeyName != "" {
+		login = fmt.Sprintf(`key_ids            = ["%s"]`, hclStringContent(spec.KeyName))
+	}
+
+	var tke strings.Builder
+	tke.WriteString(fmt.Sprintf(`resource "tencentcloud_security_group" "kubernetes" {
+  name        = "%s-sg"
+  description = "Kubernetes nodes security group"
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_security_group_rule_set" "kubernetes" {
+  security_group_id = tencentcloud_security_group.kubernetes.id
+
+  ingress {
+    action      = "ACCEPT"
+    protocol    = "ALL"
+    port        = "ALL"
+    cidr_block  = "%s"
+    description = "Allow traffic from within the VPC"
+  }
+
+  ingress {
+    action      = "ACCEPT"
+    protocol    = "ALL"
+    port        = "ALL"
+    cidr_block  = "%s"
+    description = "Allow traffic from pods"
+  }
+
+  egress {
+    action      = "ACCEPT"
+    protocol    = "ALL"
+    port        = "ALL"
+    cidr_block  = "0.0.0.0/0"
+    description = "Allow all outbound traffic"
+  }
+}
+
+resource "tencentcloud_kubernetes_cluster" "kubernetes" {
+  cluster_name               = "%s"
+  cluster_version            = "%s"
+  cluster_deploy_type        = "MANAGED_CLUSTER"
+  vpc_id                     = %s.id
+  cluster_cidr               = "%s"
+  service_cidr               = "%s"
+  cluster_max_pod_num        = 32
+  cluster_internet           = %t
+  cluster_intranet           = true
+  cluster_intranet_subnet_id = %s
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+`, hclStringContent(spec.Name), vpc.CIDR, spec.PodCidr, hclStringContent(spec.Name), spec.Version, vpcAddress("tencent", vpc.Name),
+		spec.PodCidr, spec.ServiceCidr, spec.PublicEndpoint, subnetIds[0], hclStringContent(spec.Name)))
+
+	for _, pool := range spec.NodePools {
+		tke.WriteString(fmt.Sprintf(`
+resource "tencentcloud_kubernetes_node_pool" "%s" {
+  name                 = "%s"
+  cluster_id           = tencentcloud_kubernetes_cluster.kubernetes.id
+  vpc_id               = %s.id
+  subnet_ids           = %s
+  desired_capacity     = %d
+  min_size             = %d
+  max_size             = %d
+  enable_auto_scale    = %t
+  retry_policy         = "INCREMENTAL_INTERVALS"
+  termination_policies = ["OLDEST_INSTANCE"]
+
+  auto_scaling_config {
+    instance_type      = "%s"
+    system_disk_type   = "CLOUD_PREMIUM"
+    system_disk_size   = 50
+    security_group_ids = %s
+    %s
+  }
+
+  tags = merge(local.common_tags, {
+    Name = "%s-%s"
+  })
+}
+`, kubernetesNodePoolLabel(pool), pool.Name, vpcAddress("tencent", vpc.Name), hclList(subnetIds), pool.Count, pool.MinSize, pool.MaxSize,
+			kubernetesNodePoolAutoscaling(pool), pool.InstanceType, hclList(securityGroupRefs), login, hclStringContent(spec.Name), pool.Name))
+	}
+
+	tke.WriteString("\n" + kubernetesOutputs("tencentcloud_kubernetes_cluster.kubernetes.pgw_endpoint", "tencentcloud_kubernetes_cluster.kubernetes.certification_authority"))
+	return tke.String()
+}
+
+// generateVolcengineKubernetes 生成VKE集群、节点安全组、节点池，入口地址和CA证书从私网kubeconfig中解析
+// VKE使用的服务角色在首次开通容器服务时由控制台授权创建，不在此生成
+func generateVolcengineKubernetes(spec kubernetesSpec, vpc models.VPC) string {
+	subnetIds := hclList(kubernetesSubnetIds("volcengine", spec.Subnets))
+	securityGroupRefs := spec.SecurityGroups
+	if len(securityGroupRefs) == 0 {
+		securityGroupRefs = []string{"volcengine_security_group.kubernetes.id"}
+	}
+	login := `password = base64encode(random_password.kubernetes_node.result)`
+	if spec.KeyName != "" {
+		login = fmt.Sprintf(`ssh_key_pair_name = "%s"`, hclStringContent(spec.KeyName))
+	}
+
+	var vke strings.Builder
+	vke.WriteString(fmt.Sprintf(`resource "volcengine_security_group" "kubernetes" {
+  security_group_name = "%s-sg"
+  description         = "Kubernetes nodes security group"
+  vpc_id              = %s.id
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+
+resource "volcengine_security_group_rule" "kubernetes_vpc_ingress" {
+  security_group_id = volcengine_security_group.kubernetes.id
+  direction         = "ingress"
+  protocol          = "all"
+  port_start        = -1
+  port_end          = -1
+  policy            = "accept"
+  priority          = 1
+  cidr_ip           = "%s"
+  description       = "Allow traffic from within the VPC"
+}
+
+resource "volcengine_vke_cluster" "kubernetes" {
+  name               = "%s"
+  kubernetes_version = "%s"
+
+  cluster_config {
+    subnet_ids                       = %s
+    api_server_public_access_enabled = %t
+  }
+
+  pods_config {
+    pod_network_mode = "VpcCniShared"
+
+    vpc_cni_config {
+      subnet_ids = %s
+    }
+  }
+
+  services_config {
+    service_cidrsv4 = ["%s"]
+  }
+
+  tags {
+    key   = "Name"
+    value = "%s"
+  }
+
+  dynamic "tags" {
+    for_each = { for key, value in local.common_tags : key => value if key != "Name" }
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+`, hclStringContent(spec.Name), vpcAddress("volcengine", vpc.Name), vpc.CIDR, hclStringContent(spec.Name), spec.Version, subnetIds, spec.PublicEndpoint,
+		subnetIds, spec.ServiceCidr, hclStringContent(spec.Name)))
+
+	for _, pool := range spec.NodePools {
+		vke.WriteString(fmt.Sprintf(`
+resource "volcengine_vke_node_pool" "%s" {
+  cluster_id = volcengine_vke_cluster.kubernetes.id
+  name       = "%s"
+
+  auto_scaling {
+    enabled          = %t
+    desired_replicas = %d
+    min_replicas     = %d
+    max_replicas     = %d
+  }
+
+  node_config {
+    instance_type_ids = ["%s"]
+    subnet_ids        = %s
+
+    security {
+      security_group_ids = %s
+
+      login {
+        %s
+      }
+    }
+
+    system_volume {
+      type = "ESSD_PL0"
+      size = 50
+    }
+  }
+
+  kubernetes_config {
+    cordon = false
+  }
+
+  tags {
+    key   = "Name"
+    value = "%s-%s"
+  }
+
+  dynamic "tags" {
+    for_each = { for key, value in local.common_tags : key => value if key != "Name" }
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+`, kubernetesNodePoolLabel(pool), pool.Name, kubernetesNodePoolAutoscaling(pool), pool.Count, pool.MinSize, pool.MaxSize, pool.InstanceType, subnetIds,
+			hclList(securityGroupRefs), login, hclStringContent(spec.Name), pool.Name))
+	}
+
+	vke.WriteString(`
+resource "volcengine_vke_kubeconfig" "kubernetes" {
+  cluster_id     = volcengine_vke_cluster.kubernetes.id
+  type           = "Private"
+  valid_duration = 2880
+}
+
+# VKE不直接导出入口地址和CA证书，从Base64编码的kubeconfig中解析
+locals {
+  kubernetes_kubeconfig = yamldecode(base64decode(volcengine_vke_kubeconfig.kubernetes.kubeconfig))
+}
+`)
+	vke.WriteString("\n" + kubernetesOutputs(`local.kubernetes_kubeconfig.clusters[0].cluster.server`, `local.kubernetes_kubeconfig.clusters[0].cluster["certificate-authority-data"]`))
+	return vke.String()
+}
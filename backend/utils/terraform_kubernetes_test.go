@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateKubernetesConfigUserStrings(t *testing.T) {
+	name := `k8s" } x = "${file("/etc/passwd")}`
+	tests := []struct {
+		provider string
+		region   string
+		want     string
+	}{
+		{"aws", "us-east-1", `name     = "k8s\" } x = \"$${file(\"/etc/passwd\")}"`},
+		{"azure", "eastus", `k8s\" } x = \"$${file(\"/etc/passwd\")}`},
+		{"alicloud", "cn-hangzhou", `k8s\" } x = \"$${file(\"/etc/passwd\")}`},
+		{"huawei", "cn-north-4", `k8s\" } x = \"$${file(\"/etc/passwd\")}`},
+		{"tencent", "ap-guangzhou", `k8s\" } x = \"$${file(\"/etc/passwd\")}`},
+		{"volcengine", "cn-beijing", `k8s\" } x = \"$${file(\"/etc/passwd\")}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig(tt.provider, tt.region))
+			body := generateKubernetesConfig(config, map[string]interface{}{"name": name, "key_name": `ops"key`})
+			if HasErrorFindings(config.findings.Findings()) {
+				t.Fatalf("unexpected error findings: %v", config.findings.Findings())
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("config does not contain %q:\n%s", tt.want, body)
+			}
+			if strings.Contains(body, `k8s" }`) || strings.Contains(body, `ops"key`) {
+				t.Errorf("config contains an unescaped user string:\n%s", body)
+			}
+		})
+	}
+}
+
+func TestGenerateKubernetesConfigInvalidValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		region   string
+		props    map[string]interface{}
+		wantErr  bool
+	}{
+		{"aws default version", "aws", "us-east-1", map[string]interface{}{}, false},
+		{"aws minor version", "aws", "us-east-1", map[string]interface{}{"version": "1.29"}, false},
+		{"aws version with quotes", "aws", "us-east-1", map[string]interface{}{"version": `1.30" }`}, true},
+		{"aws patch version", "aws", "us-east-1", map[string]interface{}{"version": "1.30.1"}, true},
+		{"alicloud default version", "alicloud", "cn-hangzhou", map[string]interface{}{}, false},
+		{"huawei default version", "huawei", "cn-north-4", map[string]interface{}{}, false},
+		{"tencent default version", "tencent", "ap-guangzhou", map[string]interface{}{}, false},
+		{"volcengine default version", "volcengine", "cn-beijing", map[string]interface{}{}, false},
+		{"instance type with quotes", "aws", "us-east-1", map[string]interface{}{"node_pools": `[{"name":"default","instanceType":"t3.large\" }"}]`}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig(tt.provider, tt.region))
+			body := generateKubernetesConfig(config, tt.props)
+			if got := HasErrorFindings(config.findings.Findings()); got != tt.wantErr {
+				t.Fatalf("error findings = %v, want %v: %v", got, tt.wantErr, config.findings.Findings())
+			}
+			if tt.wantErr && body != "" {
+				t.Errorf("invalid cluster should not be rendered:\n%s", body)
+			}
+		})
+	}
+}
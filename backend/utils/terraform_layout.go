@@ -0,0 +1,726 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// providerSource 表示Terraform provider的来源地址和最低版本
+type providerSource struct {
+	Source  string
+	Version string
+}
+
+// providerSources 生成的配置中使用的provider，键为provider名称（即资源类型前缀）
+var providerSources = map[string]providerSource{
+	"aws":          {Source: "hashicorp/aws", Version: ">= 5.0"},
+	"azurerm":      {Source: "hashicorp/azurerm", Version: ">= 3.90"},
+	"azuread":      {Source: "hashicorp/azuread", Version: ">= 2.47"},
+	"alicloud":     {Source: "aliyun/alicloud", Version: ">= 1.220.0"},
+	"baiducloud":   {Source: "baidubce/baiducloud", Version: ">= 1.19.0"},
+	"huaweicloud":  {Source: "huaweicloud/huaweicloud", Version: ">= 1.60.0"},
+	"tencentcloud": {Source: "tencentcloudstack/tencentcloud", Version: ">= 1.81.0"},
+	"volcengine":   {Source: "volcengine/volcengine", Version: ">= 0.0.140"},
+	"random":       {Source: "hashicorp/random", Version: ">= 3.5"},
+	"archive":      {Source: "hashicorp/archive", Version: ">= 2.4"},
+	"tls":          {Source: "hashicorp/tls", Version: ">= 4.0"},
+}
+
+// sensitiveAttributes provider标记为敏感的属性，子模块导出这些属性时需要标记为sensitive，
+// 键为属性名或 资源类型.属性名
+var sensitiveAttributes = map[string]bool{
+	"password":                            true,
+	"auth_token":                          true,
+	"access_key":                          true,
+	"secret_key":                          true,
+	"secret":                              true,
+	"connection_string":                   true,
+	"primary_access_key":                  true,
+	"secondary_access_key":                true,
+	"primary_connection_string":           true,
+	"secondary_connection_string":         true,
+	"default_primary_connection_string":   true,
+	"default_primary_key":                 true,
+	"kube_config":                         true,
+	"kube_config_raw":                     true,
+	"kube_admin_config":                   true,
+	"kube_admin_config_raw":               true,
+	"random_password.result":              true,
+	"tls_private_key.private_key_pem":     true,
+	"tls_private_key.private_key_openssh": true,
+}
+
+// attributeTypes 子模块输入变量中非字符串属性的类型，其他属性都是string
+var attributeTypes = map[string]string{
+	"allocated_storage": "number",
+	"port":              "number",
+	"address_space":     "list(string)",
+	"private_ips":       "list(string)",
+}
+
+// hclTopLevelHeaderRegexp 匹配顶层resource、data、output和provider块的开始行
+var hclTopLevelHeaderRegexp = regexp.MustCompile(`^(resource|data|output|provider) "([a-zA-Z0-9_-]+)"(?: "([a-zA-Z0-9_-]+)")? \{(\})?\s*$`)
+
+// hclReferenceRegexp 匹配资源引用和紧跟的属性，例如 aws_vpc.main、aws_vpc.main.id 或 data.aws_ami.image.id
+var hclReferenceRegexp = regexp.MustCompile(`(data\.)?([a-z][a-z0-9]*_[a-z0-9_]+)\.([A-Za-z_][A-Za-z0-9_-]*)(?:\.([A-Za-z_][A-Za-z0-9_]*))?`)
+
+// hclRegionLineRegexp 匹配provider块中的region参数
+var hclRegionLineRegexp = regexp.MustCompile(`(?m)^(\s+region\s+= )"([^"]*)"$`)
+
+// hclAliasLineRegexp 匹配provider块中的alias参数
+var hclAliasLineRegexp = regexp.MustCompile(`(?m)^\s+alias\s+= "([^"]+)"$`)
+
+// hclDependsOnRegexp 匹配depends_on参数，列表可以跨多行
+var hclDependsOnRegexp = regexp.MustCompile(`(?m)^([ \t]+)depends_on\s*=\s*\[([^\]]*)\]\n`)
+
+// hclBlock 表示配置中的一个顶层块，块之间的空行和注释Kind为空
+type hclBlock struct {
+	Kind string // resource、data、output、provider
+	Type string // 资源类型或provider名称
+	Name string // 资源名称或输出名称
+	Text string
+}
+
+// address 返回块的资源地址
+func (block hclBlock) address() string {
+	if block.Kind == "data" {
+		return "data." + block.Type + "." + block.Name
+	}
+	return block.Type + "." + block.Name
+}
+
+// moduleVariable 表示根模块的输入变量
+type moduleVariable struct {
+	Name        string
+	Type        string
+	Description string
+	Value       string
+}
+
+// rootModuleFileLabels 根模块固定文件的名称，组件的调用文件使用标签命名，不能使用这些名称覆盖根模块文件
+var rootModuleFileLabels = []string{"main", "variables", "outputs", "providers", "versions"}
+
+// childModule 表示由组件渲染的本地子模块
+type childModule struct {
+	Label     string
+	Component string
+	Main      strings.Builder
+	Outputs   []hclBlock
+	Inputs    map[string]moduleVariable // 子模块变量名 -> 变量，Value为根模块中传入的表达式
+	Exports   map[string]string         // 导出的输出名 -> 子模块中的资源属性引用
+	DependsOn []string
+	Providers map[string]string // 多云部署中通过providers传入的provider别名，单云部署为nil
+}
+
+// RenderTerraformModule 将部署渲染为可独立使用的Terraform根模块，返回相对路径到文件内容的映射
+// 根模块包含versions.tf、providers.tf、variables.tf、terraform.tfvars、outputs.tf和main.tf，
+// 每个组件渲染为modules目录下的本地子模块，并在根模块中通过同名的.tf文件调用
+// 多云部署中每个分段的组件分别渲染为子模块，分段的provider别名通过module块的providers传入
+// 生成过程中发现的问题只记录日志，需要返回问题时使用RenderDeployment
+func RenderTerraformModule(config models.DeploymentConfig) map[string]string {
+	return renderTerraformModule(renderContext{DeploymentConfig: config})
+}
+
+// renderTerraformModule 使用渲染上下文渲染Terraform根模块
+func renderTerraformModule(config renderContext) map[string]string {
+	if len(config.Sections) > 0 {
+		root, components := generateMultiProviderSections(config)
+		files := renderModuleFiles(config, root, components)
+		LogInfo(fmt.Sprintf("已生成多云部署的Terraform模块: 文件=%d, 子模块=%d", len(files), len(components)))
+		return files
+	}
+
+	LogInfo(fmt.Sprintf("开始为云提供商 %s 生成Terraform模块", config.CloudProvider))
+	config = applyNamingConvention(config)
+	tags := resolveTags(config)
+
+	extra := providerAssumeRole(config)
+	if config.CloudProvider == "aws" {
+		extra += "\n\n  default_tags {\n    tags = var.tags\n  }"
+	}
+	// 模板中引用的统一标签改为根模块的tags变量，子模块通过同名变量传入
+	network, components := generateResourceSections(config)
+	network = strings.ReplaceAll(network, commonTagsLocal, "var.tags")
+	for i := range components {
+		components[i].Body = strings.ReplaceAll(components[i].Body, commonTagsLocal, "var.tags")
+	}
+	root := generateProviderBlock(config.CloudProvider, config.Region, "", extra) + network
+
+	files := renderModuleFiles(config, root, components)
+	body := network + joinComponentBodies(components)
+	if config.CloudProvider == "aws" || strings.Contains(body, "var.tags") {
+		description := "Tags applied to every resource"
+		moduleTags := commonTags(body, tags)
+		if config.CloudProvider == "aws" {
+			description = "Tags applied to every resource through the provider default_tags"
+			moduleTags = filterTags("aws", moduleTags)
+		}
+		files["variables.tf"] += fmt.Sprintf(`
+variable "tags" {
+  description = %q
+  type        = map(string)
+}
+`, description)
+		files["terraform.tfvars"] += "tags = " + hclTagMap(moduleTags, "") + "\n"
+	}
+	LogInfo(fmt.Sprintf("已生成Terraform模块: 文件=%d, 子模块=%d", len(files), len(components)))
+	return files
+}
+
+// SaveTerraformModule 将RenderTerraformModule生成的文件写入目录
+func SaveTerraformModule(files map[string]string, dir string) error {
+	for _, path := range sortedKeys(files) {
+		if err := SaveTerraformConfig(files[path], filepath.Join(dir, filepath.FromSlash(path))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderModuleFiles 将根模块配置和组件配置拆分为模块文件
+func renderModuleFiles(config renderContext, root string, components []componentSection) map[string]string {
+	files := make(map[string]string)
+	var providers, main, outputs strings.Builder
+
+	// 资源地址 -> 所在子模块的标签，根模块中的资源为空字符串
+	owners := make(map[string]string)
+	rootBlocks := parseHclBlocks(root)
+	for _, block := range rootBlocks {
+		if block.Kind == "resource" || block.Kind == "data" {
+			owners[block.address()] = ""
+		}
+	}
+
+	var modules []*childModule
+	moduleByLabel := make(map[string]*childModule)
+	labels := make(map[string]bool)
+	for _, name := range rootModuleFileLabels {
+		labels[name] = true
+	}
+	for _, component := range components {
+		if strings.TrimSpace(component.Body) == "" {
+			continue
+		}
+		name := component.Name
+		if component.Section != "" {
+			name = component.Section + "_" + name
+		}
+		label := uniqueIdentifier(terraformIdentifier(strings.ReplaceAll(name, "-", "_"), "component"), labels)
+		if component.Module {
+			// 模块目录中的组件直接在根模块中调用，只引用根模块中的VPC和子网
+			files[label+".tf"] = strings.TrimLeft(component.Body, "\n")
+			continue
+		}
+		module := &childModule{
+			Label:     label,
+			Component: component.Name,
+			Inputs:    make(map[string]moduleVariable),
+			Exports:   make(map[string]string),
+			Providers: component.Providers,
+		}
+		for _, block := range parseHclBlocks(component.Body) {
+			switch block.Kind {
+			case "provider":
+				// 子模块不能声明provider配置，统一放到根模块的providers.tf中，多云部署中已由分段别名覆盖的provider不再声明
+				if _, ok := component.Providers[block.Type]; !ok || hclAliasLineRegexp.MatchString(block.Text) {
+					providers.WriteString(block.Text + "\n")
+				}
+				continue
+			case "output":
+				module.Outputs = append(module.Outputs, block)
+				continue
+			case "resource", "data":
+				owners[block.address()] = module.Label
+			}
+			module.Main.WriteString(block.Text)
+		}
+		if strings.Contains(module.Main.String(), "var.tags") {
+			tags := component.Tags
+			if tags == "" {
+				tags = "var.tags"
+			}
+			module.Inputs["tags"] = moduleVariable{Name: "tags", Type: "map(string)", Description: "Tags applied to every resource", Value: tags}
+		}
+		modules = append(modules, module)
+		moduleByLabel[module.Label] = module
+	}
+
+	// 根模块引用子模块中的资源属性时改为引用子模块的输出，depends_on中子模块内的资源改为依赖整个子模块
+	exportRef := func(address, attribute string) string {
+		owner := moduleByLabel[owners[address]]
+		name := referenceName(address, attribute)
+		owner.Exports[name] = attributeReference(address, attribute)
+		return fmt.Sprintf("module.%s.%s", owner.Label, name)
+	}
+	rootResolver := func(address, attribute string) (string, bool) {
+		if owner, ok := owners[address]; ok && owner != "" {
+			return exportRef(address, attribute), true
+		}
+		return "", false
+	}
+	rootDependsOn := func(text string) string {
+		return rewriteDependsOn(text, func(address string) string {
+			if owner := owners[address]; owner != "" {
+				return "module." + owner
+			}
+			return address
+		})
+	}
+
+	for _, block := range rootBlocks {
+		switch block.Kind {
+		case "provider":
+			providers.WriteString(block.Text + "\n")
+		case "output":
+			outputs.WriteString(rewriteAttributeReferences(block.Text, rootResolver) + "\n")
+		default:
+			main.WriteString(rewriteAttributeReferences(rootDependsOn(block.Text), rootResolver))
+		}
+	}
+
+	// 子模块引用根模块或其他子模块中的资源时改为输入变量，depends_on中的外部资源移到module块上
+	for _, module := range modules {
+		label := module.Label
+		internal := func(address string) bool {
+			owner, ok := owners[address]
+			return ok && owner == label
+		}
+		body, external := extractExternalDependsOn(module.Main.String(), internal)
+		for _, address := range external {
+			if owner, ok := owners[address]; ok && owner != "" {
+				address = "module." + owner
+			}
+			module.DependsOn = appendUnique(module.DependsOn, address)
+		}
+
+		resolver := func(address, attribute string) (string, bool) {
+			owner, ok := owners[address]
+			if !ok || owner == label {
+				return "", false
+			}
+			variable := moduleVariable{
+				Name:        referenceName(address, attribute),
+				Type:        attributeType(attribute),
+				Description: fmt.Sprintf("Attribute %s of %s passed in by the root module", attribute, address),
+				Value:       attributeReference(address, attribute),
+			}
+			if attribute == "" {
+				variable.Description = fmt.Sprintf("Resource %s passed in by the root module", address)
+			}
+			if owner != "" {
+				variable.Value = exportRef(address, attribute)
+			}
+			module.Inputs[variable.Name] = variable
+			return "var." + variable.Name, true
+		}
+		module.Main.Reset()
+		module.Main.WriteString(rewriteAttributeReferences(body, resolver))
+		for i := range module.Outputs {
+			module.Outputs[i].Text = rewriteAttributeReferences(module.Outputs[i].Text, resolver)
+		}
+	}
+
+	for _, module := range modules {
+		dir := "modules/" + module.Label + "/"
+		files[dir+"main.tf"] = module.Main.String()
+		files[dir+"variables.tf"] = renderChildVariables(module)
+		files[dir+"outputs.tf"] = renderChildOutputs(module)
+		files[dir+"versions.tf"] = renderVersions(module.Main.String(), false)
+		files[module.Label+".tf"] = renderModuleCall(module)
+
+		// 组件自身的输出在根模块中重新导出
+		for _, output := range module.Outputs {
+			value, sensitive := "value", ""
+			if strings.Contains(output.Text, "sensitive = true") {
+				value, sensitive = "value    ", "\n  sensitive = true"
+			}
+			outputs.WriteString(fmt.Sprintf("output %q {\n  %s = module.%s.%s%s\n}\n\n", output.Name, value, module.Label, output.Name, sensitive))
+		}
+	}
+
+	// provider块中的区域改为变量，主provider使用region，其他provider使用 别名_region
+	providerText, variables := parameterizeProviderRegions(config, providers.String())
+
+	files["providers.tf"] = providerText
+	mainText, locationVariable := parameterizeLocation(config, main.String())
+	if locationVariable != nil {
+		variables = append(variables, *locationVariable)
+	}
+	files["main.tf"] = mainText
+	files["outputs.tf"] = outputs.String()
+	files["versions.tf"] = renderVersions(providerText+root+joinComponentBodies(components), true)
+
+	width := 0
+	for _, variable := range variables {
+		if len(variable.Name) > width {
+			width = len(variable.Name)
+		}
+	}
+	var variablesFile, tfvars strings.Builder
+	for _, variable := range variables {
+		variablesFile.WriteString(fmt.Sprintf("variable %q {\n  description = %q\n  type        = %s\n}\n\n", variable.Name, variable.Description, variable.Type))
+		tfvars.WriteString(fmt.Sprintf("%-*s = %s\n", width, variable.Name, variable.Value))
+	}
+	files["variables.tf"] = strings.TrimSuffix(variablesFile.String(), "\n")
+	files["terraform.tfvars"] = tfvars.String()
+	return files
+}
+
+// parseHclBlocks 将配置拆分为顶层块，heredoc中的内容不会被当作块的结束
+func parseHclBlocks(body string) []hclBlock {
+	var blocks []hclBlock
+	lines := strings.SplitAfter(body, "\n")
+	for i := 0; i < len(lines); i++ {
+		match := hclTopLevelHeaderRegexp.FindStringSubmatch(strings.TrimRight(lines[i], "\n"))
+		if match == nil {
+			if len(blocks) > 0 && blocks[len(blocks)-1].Kind == "" {
+				blocks[len(blocks)-1].Text += lines[i]
+			} else if lines[i] != "" {
+				blocks = append(blocks, hclBlock{Text: lines[i]})
+			}
+			continue
+		}
+
+		end := i
+		if match[4] == "" {
+			if end = resourceBlockEnd(lines, i); end < 0 {
+				end = len(lines) - 1
+			}
+		}
+		block := hclBlock{Kind: match[1], Text: strings.Join(lines[i:end+1], "")}
+		switch match[1] {
+		case "resource", "data":
+			block.Type, block.Name = match[2], match[3]
+		case "output":
+			block.Name = match[2]
+		case "provider":
+			block.Type = match[2]
+		}
+		blocks = append(blocks, block)
+		i = end
+	}
+	return blocks
+}
+
+// rewriteReferences 替换配置中的资源引用，resolve返回false时保持原样
+func rewriteReferences(text string, resolve func(address string) (string, bool)) string {
+	return rewriteAttributeReferences(text, func(address, attribute string) (string, bool) {
+		replacement, ok := resolve(address)
+		return attributeReference(replacement, attribute), ok
+	})
+}
+
+// rewriteAttributeReferences 替换配置中的资源引用，resolve的参数为资源地址和紧跟的属性名（没有属性时为空），
+// 返回值替换包含属性在内的整个引用，返回false时保持原样
+func rewriteAttributeReferences(text string, resolve func(address, attribute string) (string, bool)) string {
+	var rewritten strings.Builder
+	last := 0
+	for _, match := range hclReferenceRegexp.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[0], match[1]
+		if start > 0 && isIdentifierChar(text[start-1]) {
+			continue
+		}
+		resourceType := text[match[4]:match[5]]
+		if _, ok := providerSources[strings.SplitN(resourceType, "_", 2)[0]]; !ok {
+			continue
+		}
+		address, attribute := text[start:match[7]], ""
+		if match[8] >= 0 {
+			attribute = text[match[8]:match[9]]
+		}
+		replacement, ok := resolve(address, attribute)
+		if !ok {
+			continue
+		}
+		rewritten.WriteString(text[last:start])
+		rewritten.WriteString(replacement)
+		last = end
+	}
+	rewritten.WriteString(text[last:])
+	return rewritten.String()
+}
+
+// attributeReference 返回资源属性的引用，attribute为空时返回资源本身
+func attributeReference(address, attribute string) string {
+	if attribute == "" {
+		return address
+	}
+	return address + "." + attribute
+}
+
+// referenceName 返回资源属性在子模块变量和输出中使用的名称，例如 aws_vpc.main.id 为 aws_vpc_main_id
+func referenceName(address, attribute string) string {
+	return strings.ReplaceAll(attributeReference(address, attribute), ".", "_")
+}
+
+// attributeType 返回资源属性作为子模块输入变量时的类型，引用整个资源对象时为any
+func attributeType(attribute string) string {
+	if attribute == "" {
+		return "any"
+	}
+	if variableType, ok := attributeTypes[attribute]; ok {
+		return variableType
+	}
+	return "string"
+}
+
+// sensitiveReference 判断子模块导出的引用是否包含敏感值，导出整个资源对象时按敏感值处理
+func sensitiveReference(reference string) bool {
+	parts := strings.Split(strings.TrimPrefix(reference, "data."), ".")
+	if len(parts) < 3 {
+		return true
+	}
+	return sensitiveAttributes[parts[2]] || sensitiveAttributes[parts[0]+"."+parts[2]]
+}
+
+// isIdentifierChar 判断字符是否可以出现在引用之前的标识符中，例如 var.aws_vpc_main 中的 .
+func isIdentifierChar(c byte) bool {
+	return c == '.' || c == '_' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// extractExternalDependsOn 删除depends_on中不在子模块内的资源，返回处理后的配置和被删除的资源地址
+func extractExternalDependsOn(body string, internal func(address string) bool) (string, []string) {
+	var external []string
+	updated := hclDependsOnRegexp.ReplaceAllStringFunc(body, func(attribute string) string {
+		match := hclDependsOnRegexp.FindStringSubmatch(attribute)
+		var kept []string
+		for _, item := range strings.Split(match[2], ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			if internal(item) {
+				kept = append(kept, item)
+			} else {
+				external = append(external, item)
+			}
+		}
+		if len(kept) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%sdepends_on = [%s]\n", match[1], strings.Join(kept, ", "))
+	})
+	return updated, external
+}
+
+// rewriteDependsOn 替换depends_on中的资源地址，重复的地址只保留一次，没有替换时保持原样
+func rewriteDependsOn(body string, replace func(address string) string) string {
+	return hclDependsOnRegexp.ReplaceAllStringFunc(body, func(attribute string) string {
+		match := hclDependsOnRegexp.FindStringSubmatch(attribute)
+		var items []string
+		changed := false
+		for _, item := range strings.Split(match[2], ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			replaced := replace(item)
+			changed = changed || replaced != item
+			items = appendUnique(items, replaced)
+		}
+		if !changed {
+			return attribute
+		}
+		return fmt.Sprintf("%sdepends_on = [%s]\n", match[1], strings.Join(items, ", "))
+	})
+}
+
+// parameterizeProviderRegions 将provider块中的区域替换为变量
+func parameterizeProviderRegions(config renderContext, providers string) (string, []moduleVariable) {
+	var variables []moduleVariable
+	used := make(map[string]bool)
+	var rendered strings.Builder
+	for _, block := range parseHclBlocks(providers) {
+		text := block.Text
+		if block.Kind == "provider" {
+			if match := hclRegionLineRegexp.FindStringSubmatch(text); match != nil {
+				name := "region"
+				if alias := hclAliasLineRegexp.FindStringSubmatch(text); alias != nil {
+					name = alias[1] + "_region"
+				} else if block.Type != providerLocalName(config.CloudProvider) {
+					name = block.Type + "_region"
+				}
+				if !used[name] {
+					used[name] = true
+					variables = append(variables, moduleVariable{
+						Name:        name,
+						Type:        "string",
+						Description: fmt.Sprintf("Region of the %s provider", block.Type),
+						Value:       fmt.Sprintf("%q", match[2]),
+					})
+				}
+				text = hclRegionLineRegexp.ReplaceAllString(text, "${1}var."+name)
+			}
+		}
+		rendered.WriteString(text)
+	}
+	return rendered.String(), variables
+}
+
+// parameterizeLocation Azure的provider块不包含区域，资源组的location改为使用region变量
+func parameterizeLocation(config renderContext, main string) (string, *moduleVariable) {
+	location := fmt.Sprintf("location = %q", config.Region)
+	if config.CloudProvider != "azure" || len(config.Sections) > 0 || !strings.Contains(main, location) {
+		return main, nil
+	}
+	return strings.ReplaceAll(main, location, "location = var.region"), &moduleVariable{
+		Name:        "region",
+		Type:        "string",
+		Description: "Location of the azurerm resources",
+		Value:       fmt.Sprintf("%q", config.Region),
+	}
+}
+
+// usedProviders 返回配置中的资源、数据源和provider块使用的provider名称，按名称排序
+func usedProviders(body string) []string {
+	used := make(map[string]bool)
+	for _, line := range strings.Split(body, "\n") {
+		if match := hclTopLevelHeaderRegexp.FindStringSubmatch(line); match != nil && match[1] != "output" {
+			used[strings.SplitN(match[2], "_", 2)[0]] = true
+		}
+	}
+
+	var names []string
+	for name := range used {
+		if _, ok := providerSources[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renderVersions 生成terraform块，列出配置中使用的provider
+func renderVersions(body string, root bool) string {
+	names := usedProviders(body)
+	width := 0
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+
+	var versions strings.Builder
+	versions.WriteString("terraform {\n")
+	if root {
+		versions.WriteString("  required_version = \">= 1.3.0\"\n\n")
+	}
+	versions.WriteString("  required_providers {\n")
+	for _, name := range names {
+		source := providerSources[name]
+		versions.WriteString(fmt.Sprintf("    %-*s = {\n      source  = %q\n      version = %q\n    }\n", width, name, source.Source, source.Version))
+	}
+	versions.WriteString("  }\n}\n")
+	return versions.String()
+}
+
+// renderChildVariables 生成子模块的输入变量
+func renderChildVariables(module *childModule) string {
+	var variables strings.Builder
+	for _, name := range sortedInputNames(module.Inputs) {
+		variable := module.Inputs[name]
+		variables.WriteString(fmt.Sprintf("variable %q {\n  description = %q\n  type        = %s\n}\n\n", name, variable.Description, variable.Type))
+	}
+	return strings.TrimSuffix(variables.String(), "\n")
+}
+
+// sortedInputNames 返回排序后的子模块输入变量名
+func sortedInputNames(inputs map[string]moduleVariable) []string {
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renderChildOutputs 生成子模块的输出：组件自身的输出和被其他模块引用的资源属性
+func renderChildOutputs(module *childModule) string {
+	var outputs strings.Builder
+	for _, output := range module.Outputs {
+		outputs.WriteString(output.Text + "\n")
+	}
+	for _, name := range sortedKeys(module.Exports) {
+		reference := module.Exports[name]
+		value, sensitive := "value", ""
+		if sensitiveReference(reference) {
+			value, sensitive = "value    ", "\n  sensitive = true"
+		}
+		outputs.WriteString(fmt.Sprintf("output %q {\n  %s = %s%s\n}\n\n", name, value, reference, sensitive))
+	}
+	return strings.TrimSuffix(outputs.String(), "\n")
+}
+
+// renderModuleCall 生成根模块中调用子模块的module块
+func renderModuleCall(module *childModule) string {
+	names := sortedInputNames(module.Inputs)
+	width := 0
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+
+	var call strings.Builder
+	call.WriteString(fmt.Sprintf("# 组件 %s\nmodule %q {\n  source = \"./modules/%s\"\n", module.Component, module.Label, module.Label))
+	if len(names) > 0 {
+		call.WriteString("\n")
+	}
+	for _, name := range names {
+		call.WriteString(fmt.Sprintf("  %-*s = %s\n", width, name, module.Inputs[name].Value))
+	}
+	if module.Providers != nil {
+		// 指定providers后子模块不再继承根模块的默认provider，子模块使用的每个provider都需要传入
+		call.WriteString(renderModuleProviders(usedProviders(module.Main.String()), module.Providers))
+	}
+	if len(module.DependsOn) > 0 {
+		call.WriteString(fmt.Sprintf("\n  depends_on = %s\n", hclList(module.DependsOn)))
+	}
+	call.WriteString("}\n")
+	return call.String()
+}
+
+// renderModuleProviders 生成module块的providers参数，有别名的provider传入分段别名，其他provider传入默认配置
+func renderModuleProviders(names []string, aliases map[string]string) string {
+	width := 0
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	var providers strings.Builder
+	providers.WriteString("\n  providers = {\n")
+	for _, name := range names {
+		reference := name
+		if alias, ok := aliases[name]; ok {
+			reference = name + "." + alias
+		}
+		providers.WriteString(fmt.Sprintf("    %-*s = %s\n", width, name, reference))
+	}
+	providers.WriteString("  }\n")
+	return providers.String()
+}
+
+// joinComponentBodies 合并所有组件的配置，用于统计使用的provider
+func joinComponentBodies(components []componentSection) string {
+	var bodies strings.Builder
+	for _, component := range components {
+		bodies.WriteString(component.Body)
+	}
+	return bodies.String()
+}
+
+// appendUnique 向列表中添加不重复的元素
+func appendUnique(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+func TestRenderTerraformModuleChildInterfaces(t *testing.T) {
+	config := testNetworkConfig("aws", "us-east-1")
+	config.Components = []string{"database", "monitoring"}
+	files := RenderTerraformModule(config)
+
+	tests := []struct {
+		file    string
+		want    []string
+		notWant []string
+	}{
+		{
+			file:    "modules/database/variables.tf",
+			want:    []string{"variable \"aws_vpc_main_id\" {\n  description = \"Attribute id of aws_vpc.main passed in by the root module\"\n  type        = string\n}"},
+			notWant: []string{"type        = any"},
+		},
+		{
+			file:    "modules/database/main.tf",
+			want:    []string{"vpc_id      = var.aws_vpc_main_id"},
+			notWant: []string{"var.aws_vpc_main.id"},
+		},
+		{
+			file:    "modules/database/outputs.tf",
+			want:    []string{"output \"aws_db_instance_database_identifier\" {\n  value = aws_db_instance.database.identifier\n}"},
+			notWant: []string{"value     = aws_db_instance.database.identifier"},
+		},
+		{
+			file: "modules/monitoring/variables.tf",
+			want: []string{"variable \"aws_db_instance_database_allocated_storage\" {\n  description = \"Attribute allocated_storage of aws_db_instance.database passed in by the root module\"\n  type        = number\n}"},
+		},
+		{
+			file: "monitoring.tf",
+			want: []string{"aws_db_instance_database_identifier        = module.database.aws_db_instance_database_identifier"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			body, ok := files[tt.file]
+			if !ok {
+				t.Fatalf("module does not contain %s", tt.file)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("%s does not contain %q:\n%s", tt.file, want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("%s should not contain %q:\n%s", tt.file, notWant, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderTerraformModuleSections(t *testing.T) {
+	east := testNetworkConfig("aws", "us-east-1")
+	east.Components = []string{"database"}
+	west := testNetworkConfig("aws", "us-west-2")
+	west.Components = []string{"database"}
+	west.ProviderAlias = "west"
+	files := RenderTerraformModule(models.DeploymentConfig{Sections: []models.DeploymentConfig{east, west}})
+
+	tests := []struct {
+		file    string
+		want    []string
+		notWant []string
+	}{
+		{
+			file: "aws_us_east_1_database.tf",
+			want: []string{
+				"  aws_vpc_main_id     = aws_vpc.main.id",
+				"  providers = {\n    aws    = aws.aws_us_east_1\n    random = random\n  }",
+			},
+		},
+		{
+			file: "west_database.tf",
+			want: []string{
+				"  aws_vpc_west_main_id     = aws_vpc.west_main.id",
+				"  providers = {\n    aws    = aws.west\n    random = random\n  }",
+			},
+		},
+		{
+			file:    "modules/west_database/main.tf",
+			want:    []string{`resource "aws_db_instance" "west_database"`, "subnet_ids = [var.aws_subnet_west_app-a_id, var.aws_subnet_west_app-b_id]"},
+			notWant: []string{"provider = aws."},
+		},
+		{
+			file:    "main.tf",
+			want:    []string{`resource "aws_vpc" "west_main" {` + "\n  provider = aws.west"},
+			notWant: []string{"aws_db_instance"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			body, ok := files[tt.file]
+			if !ok {
+				t.Fatalf("module does not contain %s", tt.file)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("%s does not contain %q:\n%s", tt.file, want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("%s should not contain %q:\n%s", tt.file, notWant, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderModuleFilesReservedLabels(t *testing.T) {
+	config := testRenderContext(testNetworkConfig("aws", "us-east-1"))
+	components := []componentSection{
+		{Name: "outputs", Module: true, Body: "\nmodule \"outputs\" {\n  source = \"./catalog/outputs\"\n}\n"},
+		{Name: "main", Body: "\nresource \"aws_sns_topic\" \"main\" {\n  name = \"main\"\n}\n"},
+	}
+	files := renderModuleFiles(config, "", components)
+
+	if !strings.Contains(files["outputs_2.tf"], `source = "./catalog/outputs"`) {
+		t.Errorf("catalog module call should not use outputs.tf:\n%s", files["outputs_2.tf"])
+	}
+	if strings.Contains(files["outputs.tf"], "catalog") {
+		t.Errorf("outputs.tf was overwritten by a component:\n%s", files["outputs.tf"])
+	}
+	if _, ok := files["modules/main_2/main.tf"]; !ok {
+		t.Errorf("component main should be rendered as modules/main_2")
+	}
+	if strings.Contains(files["main.tf"], "module \"main_2\"") {
+		t.Errorf("main.tf was overwritten by a module call:\n%s", files["main.tf"])
+	}
+}
+
+func TestSensitiveReference(t *testing.T) {
+	tests := []struct {
+		reference string
+		want      bool
+	}{
+		{"aws_db_instance.database.identifier", false},
+		{"aws_db_instance.database.password", true},
+		{"random_password.database.result", true},
+		{"random_id.suffix.result", false},
+		{"azurerm_eventhub_namespace.message_queue.default_primary_connection_string", true},
+		{"aws_lb.elb", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			if got := sensitiveReference(tt.reference); got != tt.want {
+				t.Errorf("sensitiveReference(%q) = %v, want %v", tt.reference, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteDependsOn(t *testing.T) {
+	body := "resource \"aws_route\" \"peer\" {\n  depends_on = [aws_vpc_peering_connection.peer, aws_vpc_peering_connection_accepter.peer]\n}\nresource \"aws_route\" \"local\" {\n  depends_on = [aws_vpc.main]\n}\n"
+	owners := map[string]string{"aws_vpc_peering_connection.peer": "vpc_peering", "aws_vpc_peering_connection_accepter.peer": "vpc_peering"}
+	got := rewriteDependsOn(body, func(address string) string {
+		if owner := owners[address]; owner != "" {
+			return "module." + owner
+		}
+		return address
+	})
+	want := "resource \"aws_route\" \"peer\" {\n  depends_on = [module.vpc_peering]\n}\nresource \"aws_route\" \"local\" {\n  depends_on = [aws_vpc.main]\n}\n"
+	if got != want {
+		t.Errorf("rewriteDependsOn() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,615 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// messageQueueEngines 各云提供商支持的消息队列引擎
+var messageQueueEngines = map[string][]string{
+	"aws":      {"kafka"},
+	"azure":    {"kafka"},
+	"alicloud": {"kafka", "rocketmq"},
+	"huawei":   {"kafka", "rocketmq"},
+	"tencent":  {"kafka", "rocketmq"},
+}
+
+// defaultMessageQueueVersions 各云提供商默认的引擎版本，键为 provider/engine
+var defaultMessageQueueVersions = map[string]string{
+	"aws/kafka":         "3.6.0",
+	"alicloud/kafka":    "2.2.0",
+	"huawei/kafka":      "2.7",
+	"huawei/rocketmq":   "4.8.0",
+	"tencent/kafka":     "2.4.1",
+	"alicloud/rocketmq": "5.0",
+	"tencent/rocketmq":  "5.0",
+}
+
+// defaultMessageQueueInstanceTypes 各云提供商默认的实例规格，键为 provider/engine
+var defaultMessageQueueInstanceTypes = map[string]string{
+	"aws/kafka":         "kafka.m5.large",
+	"azure/kafka":       "Standard",
+	"alicloud/kafka":    "alikafka.hw.2xlarge",
+	"alicloud/rocketmq": "rmq.s2.2xlarge",
+	"huawei/kafka":      "c6.2u4g.cluster",
+	"huawei/rocketmq":   "c6.4u8g.cluster",
+	"tencent/kafka":     "profession",
+	"tencent/rocketmq":  "basic_2k",
+}
+
+// defaultMessageQueueStorage 各云提供商默认的存储容量（GB）
+var defaultMessageQueueStorage = map[string]int{
+	"aws":      100,
+	"alicloud": 500,
+	"huawei":   300,
+	"tencent":  200,
+}
+
+// messageQueuePortRanges 消息队列在VPC内开放的端口范围
+var messageQueuePortRanges = map[string][][2]int{
+	"kafka":    {{9092, 9096}},
+	"rocketmq": {{8100, 8200}, {10100, 10199}},
+}
+
+// messageQueueSpec 表示消息队列组件的解析结果
+type messageQueueSpec struct {
+	Name           string
+	Engine         string // kafka 或 rocketmq
+	Version        string
+	InstanceType   string
+	Storage        int // 每个节点的存储容量（GB）
+	BrokerCount    int // 为0时使用各云的默认数量
+	Username       string
+	SecurityGroups []string // 安全组组件中定义的安全组引用
+	Subnets        []models.Subnet
+	Zones          []string
+}
+
+// generateMessageQueueConfig 生成托管Kafka或RocketMQ消息队列组件的Terraform配置
+// 消息队列只部署在私有子网中，SASL或ACL用户的密码由random_password生成
+func generateMessageQueueConfig(config renderContext, propsMap map[string]interface{}) string {
+	provider := config.CloudProvider
+	engines, ok := messageQueueEngines[provider]
+	if !ok {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持消息队列组件", provider))
+		return ""
+	}
+
+	spec := messageQueueSpec{
+		Name:           strings.ToLower(getStringProp(propsMap, "name", "landing-zone-mq")),
+		Engine:         strings.ToLower(strings.TrimSpace(getStringProp(propsMap, "engine", "kafka"))),
+		BrokerCount:    getIntProp(propsMap, "broker_count", 0),
+		Username:       getStringProp(propsMap, "username", "mqadmin"),
+		SecurityGroups: resolveSecurityGroupRefs(config, propsMap),
+		Subnets:        selectPrivateSubnets(config, getStringListProp(propsMap, "subnets")),
+	}
+	supported := false
+	for _, engine := range engines {
+		if engine == spec.Engine {
+			supported = true
+		}
+	}
+	if !supported {
+		reportError(config, fmt.Sprintf("云提供商 %s 不支持消息队列引擎 %s，支持的引擎: %s，跳过生成", provider, spec.Engine, strings.Join(engines, ", ")))
+		return ""
+	}
+	key := provider + "/" + spec.Engine
+	spec.Version = getStringProp(propsMap, "version", defaultMessageQueueVersions[key])
+	spec.InstanceType = getStringProp(propsMap, "instance_type", defaultMessageQueueInstanceTypes[key])
+	spec.Storage = getIntProp(propsMap, "storage", defaultMessageQueueStorage[provider])
+	// Azure Event Hubs没有引擎版本，版本为空时不校验
+	err := checkPropertyToken("instance_type", spec.InstanceType)
+	if err == nil && spec.Version != "" {
+		err = checkPropertyToken("version", spec.Version)
+	}
+	if err != nil {
+		reportError(config, fmt.Sprintf("消息队列 %s 的配置无效，跳过生成: %v", spec.Name, err))
+		return ""
+	}
+	if len(spec.Subnets) == 0 {
+		reportError(config, fmt.Sprintf("消息队列 %s 没有可用的私有子网，跳过生成", spec.Name))
+		return ""
+	}
+	spec.Zones = databaseSubnetZones(config, spec.Subnets)
+	if provider == "aws" && len(spec.Zones) < 2 {
+		reportError(config, fmt.Sprintf("消息队列 %s 的子网只覆盖了 %d 个可用区，MSK至少需要2个不同可用区的私有子网，跳过生成", spec.Name, len(spec.Zones)))
+		return ""
+	}
+
+	var messageQueueConfig strings.Builder
+	// Azure Event Hubs使用共享访问密钥，腾讯云RocketMQ角色的密钥由云服务生成
+	usesPassword := provider != "azure" && key != "tencent/rocketmq"
+	if usesPassword {
+		messageQueueConfig.WriteString(`resource "random_password" "message_queue" {
+  length           = 24
+  special          = true
+  override_special = "!#^*-_=+"
+  min_lower        = 2
+  min_upper        = 2
+  min_numeric      = 2
+  min_special      = 2
+}
+
+`)
+	}
+
+	vpc := subnetVpc(config, spec.Subnets[0])
+	switch key {
+	case "aws/kafka":
+		messageQueueConfig.WriteString(generateAwsMessageQueue(config, spec, vpc))
+	case "azure/kafka":
+		messageQueueConfig.WriteString(generateAzureMessageQueue(config, spec, vpc))
+	case "alicloud/kafka":
+		messageQueueConfig.WriteString(generateAlicloudKafka(config, spec, vpc))
+	case "alicloud/rocketmq":
+		messageQueueConfig.WriteString(generateAlicloudRocketMQ(spec, vpc))
+	case "huawei/kafka", "huawei/rocketmq":
+		messageQueueConfig.WriteString(generateHuaweiMessageQueue(config, spec, vpc))
+	case "tencent/kafka":
+		messageQueueConfig.WriteString(generateTencentKafka(config, spec, vpc))
+	case "tencent/rocketmq":
+		messageQueueConfig.WriteString(generateTencentRocketMQ(spec, vpc))
+	}
+
+	if usesPassword {
+		messageQueueConfig.WriteString(fmt.Sprintf(`
+output "message_queue_username" {
+  value = "%s"
+}
+
+output "message_queue_password" {
+  value     = random_password.message_queue.result
+  sensitive = true
+}
+`, hclStringContent(spec.Username)))
+	}
+
+	LogInfo(fmt.Sprintf("已生成消息队列配置: 名称=%s, 引擎=%s, 子网=%d", spec.Name, strings.TrimSpace(spec.Engine+" "+spec.Version), len(spec.Subnets)))
+	return messageQueueConfig.String()
+}
+
+// generateAwsMessageQueue 生成MSK集群，客户端通过SASL/SCRAM认证，凭据保存在KMS加密的Secrets Manager密钥中
+func generateAwsMessageQueue(config renderContext, spec messageQueueSpec, vpc models.VPC) string {
+	// MSK每个可用区使用一个子网，需要2到3个可用区，代理数量必须是子网数量的整数倍
+	var subnetIds []string
+	seen := map[string]bool{}
+	for _, subnet := range spec.Subnets {
+		zone := subnetZone(config, subnet)
+		if seen[zone] || len(subnetIds) == 3 {
+			continue
+		}
+		seen[zone] = true
+		subnetIds = append(subnetIds, subnetAddress("aws", subnet.Name)+".id")
+	}
+	brokerCount := spec.BrokerCount
+	if brokerCount < 1 {
+		brokerCount = len(subnetIds)
+	}
+	if brokerCount%len(subnetIds) != 0 {
+		brokerCount += len(subnetIds) - brokerCount%len(subnetIds)
+		reportWarn(config, fmt.Sprintf("MSK代理数量必须是子网数量 %d 的整数倍，已调整为 %d", len(subnetIds), brokerCount))
+	}
+
+	// 未引用安全组组件中的安全组时，创建只允许VPC内访问Kafka端口的安全组
+	securityGroupRefs := spec.SecurityGroups
+	securityGroupConfig := ""
+	if len(securityGroupRefs) == 0 {
+		securityGroupRefs = []string{"aws_security_group.message_queue_sg.id"}
+		ports := messageQueuePortRanges[spec.Engine][0]
+		securityGroupConfig = fmt.Sprintf(`resource "aws_security_group" "message_queue_sg" {
+  name        = "%s-sg"
+  description = "Allow Kafka access from within the VPC"
+  vpc_id      = %s.id
+
+  ingress {
+    description = "Kafka from VPC"
+    from_port   = %d
+    to_port     = %d
+    protocol    = "tcp"
+    cidr_blocks = ["%s"]
+  }
+
+  tags = {
+    Name = "%s-sg"
+  }
+}
+
+`, hclStringContent(spec.Name), vpcAddress("aws", vpc.Name), ports[0], ports[1], vpc.CIDR, hclStringContent(spec.Name))
+	}
+
+	return fmt.Sprintf(`resource "aws_kms_key" "message_queue" {
+  description         = "Encrypts the SASL/SCRAM secret of %s"
+  enable_key_rotation = true
+}
+
+resource "aws_secretsmanager_secret" "message_queue" {
+  name       = "AmazonMSK_%s"
+  kms_key_id = aws_kms_key.message_queue.key_id
+}
+
+resource "aws_secretsmanager_secret_version" "message_queue" {
+  secret_id     = aws_secretsmanager_secret.message_queue.id
+  secret_string = jsonencode({
+    username = "%s"
+    password = random_password.message_queue.result
+  })
+}
+
+%sresource "aws_msk_cluster" "message_queue" {
+  cluster_name           = "%s"
+  kafka_version          = "%s"
+  number_of_broker_nodes = %d
+
+  broker_node_group_info {
+    instance_type   = "%s"
+    client_subnets  = %s
+    security_groups = %s
+
+    storage_info {
+      ebs_storage_info {
+        volume_size = %d
+      }
+    }
+  }
+
+  client_authentication {
+    sasl {
+      scram = true
+    }
+  }
+
+  encryption_info {
+    encryption_in_transit {
+      client_broker = "TLS"
+      in_cluster    = true
+    }
+  }
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+resource "aws_msk_scram_secret_association" "message_queue" {
+  cluster_arn     = aws_msk_cluster.message_queue.arn
+  secret_arn_list = [aws_secretsmanager_secret.message_queue.arn]
+
+  depends_on = [aws_secretsmanager_secret_version.message_queue]
+}
+
+output "message_queue_endpoint" {
+  value = aws_msk_cluster.message_queue.bootstrap_brokers_sasl_scram
+}
+`, hclStringContent(spec.Name), hclStringContent(spec.Name), hclStringContent(spec.Username), securityGroupConfig, hclStringContent(spec.Name), spec.Version, brokerCount,
+		spec.InstanceType, hclList(subnetIds), hclList(securityGroupRefs), spec.Storage, hclStringContent(spec.Name))
+}
+
+// generateAzureMessageQueue 生成开启Kafka协议的Event Hubs命名空间，关闭公网访问并通过私有终结点接入私有子网
+func generateAzureMessageQueue(config renderContext, spec messageQueueSpec, vpc models.VPC) string {
+	// Basic层级不支持Kafka协议
+	sku := spec.InstanceType
+	if sku != "Standard" && sku != "Premium" {
+		reportWarn(config, fmt.Sprintf("Event Hubs层级 %s 不支持Kafka协议，使用 Standard", sku))
+		sku = "Standard"
+	}
+	// Event Hubs命名空间的名称至少6个字符且不能以数字结尾
+	name := spec.Name
+	if len(name) < 6 {
+		name += "-kafka"
+	}
+	throughputUnits := spec.BrokerCount
+	if throughputUnits < 1 {
+		throughputUnits = 1
+	}
+
+	return fmt.Sprintf(`resource "azurerm_eventhub_namespace" "message_queue" {
+  name                          = "%s"
+  location                      = azurerm_resource_group.rg.location
+  resource_group_name           = azurerm_resource_group.rg.name
+  sku                           = "%s"
+  capacity                      = %d
+  minimum_tls_version           = "1.2"
+  public_network_access_enabled = false
+
+  tags = local.common_tags
+}
+
+%s
+output "message_queue_endpoint" {
+  value = "${azurerm_eventhub_namespace.message_queue.name}.servicebus.windows.net:9093"
+}
+
+output "message_queue_connection_string" {
+  value     = azurerm_eventhub_namespace.message_queue.default_primary_connection_string
+  sensitive = true
+}
+`, hclStringContent(name), sku, throughputUnits,
+		generateAzurePrivateEndpoint("message_queue", hclStringContent(name), "azurerm_eventhub_namespace.message_queue", "namespace",
+			"privatelink.servicebus.windows.net", spec.Subnets[0], vpc))
+}
+
+// generateAlicloudKafka 生成阿里云消息队列Kafka版VPC实例，开启ACL并创建SASL用户
+func generateAlicloudKafka(config renderContext, spec messageQueueSpec, vpc models.VPC) string {
+	// Kafka实例只能绑定一个安全组
+	securityGroupLine := ""
+	if len(spec.SecurityGroups) > 0 {
+		if len(spec.SecurityGroups) > 1 {
+			reportWarn(config, "阿里云Kafka实例只能绑定一个安全组，仅使用第一个")
+		}
+		securityGroupLine = fmt.Sprintf(`
+  security_group  = %s`, spec.SecurityGroups[0])
+	}
+
+	return fmt.Sprintf(`resource "alicloud_alikafka_instance" "message_queue" {
+  name            = "%s"
+  deploy_type     = 5
+  spec_type       = "normal"
+  io_max_spec     = "%s"
+  partition_num   = 50
+  disk_type       = 1
+  disk_size       = %d
+  service_version = "%s"
+  vswitch_id      = %s.id%s
+  config          = jsonencode({ "enable.acl" = "true" })
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "alicloud_alikafka_sasl_user" "message_queue" {
+  instance_id = alicloud_alikafka_instance.message_queue.id
+  username    = "%s"
+  password    = random_password.message_queue.result
+}
+
+output "message_queue_endpoint" {
+  value = alicloud_alikafka_instance.message_queue.end_point
+}
+`, hclStringContent(spec.Name), spec.InstanceType, spec.Storage, spec.Version, subnetAddress("alicloud", spec.Subnets[0].Name),
+		securityGroupLine, hclStringContent(spec.Name), hclStringContent(spec.Username))
+}
+
+// generateAlicloudRocketMQ 生成阿里云RocketMQ 5.0实例，只开放VPC接入点并创建ACL账号
+func generateAlicloudRocketMQ(spec messageQueueSpec, vpc models.VPC) string {
+	vswitches := ""
+	for _, subnet := range spec.Subnets {
+		vswitches += fmt.Sprintf(`
+
+      vswitches {
+        vswitch_id = %s.id
+      }`, subnetAddress("alicloud", subnet.Name))
+	}
+
+	return fmt.Sprintf(`resource "alicloud_rocketmq_instance" "message_queue" {
+  instance_name   = "%s"
+  service_code    = "rmq"
+  series_code     = "standard"
+  sub_series_code = "cluster_ha"
+  payment_type    = "PayAsYouGo"
+
+  product_info {
+    msg_process_spec       = "%s"
+    message_retention_time = 72
+  }
+
+  network_info {
+    vpc_info {
+      vpc_id = %s.id%s
+    }
+
+    internet_info {
+      internet_spec = "disable"
+      flow_out_type = "uninvolved"
+    }
+  }
+
+  acl_info {
+    acl_types             = ["default"]
+    default_vpc_auth_free = false
+  }
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "alicloud_rocketmq_account" "message_queue" {
+  instance_id    = alicloud_rocketmq_instance.message_queue.id
+  username       = "%s"
+  password       = random_password.message_queue.result
+  account_status = "ENABLE"
+}
+
+output "message_queue_instance_id" {
+  value = alicloud_rocketmq_instance.message_queue.id
+}
+`, hclStringContent(spec.Name), spec.InstanceType, vpcAddress("alicloud", vpc.Name), vswitches, hclStringContent(spec.Name), hclStringContent(spec.Username))
+}
+
+// generateHuaweiMessageQueue 生成华为云分布式消息服务Kafka或RocketMQ实例
+// Kafka通过SASL用户认证，RocketMQ开启ACL并创建管理员用户
+func generateHuaweiMessageQueue(config renderContext, spec messageQueueSpec, vpc models.VPC) string {
+	// 分布式消息服务只能绑定一个安全组，未引用安全组组件时创建只允许VPC内访问的安全组
+	securityGroupRef := "huaweicloud_networking_secgroup.message_queue_sg.id"
+	var securityGroupConfig strings.Builder
+	if len(spec.SecurityGroups) > 0 {
+		if len(spec.SecurityGroups) > 1 {
+			reportWarn(config, "华为云分布式消息服务实例只能绑定一个安全组，仅使用第一个")
+		}
+		securityGroupRef = spec.SecurityGroups[0]
+	} else {
+		securityGroupConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_networking_secgroup" "message_queue_sg" {
+  name        = "%s-sg"
+  description = "Allow message queue access from within the VPC"
+}
+`, hclStringContent(spec.Name)))
+		for i, ports := range messageQueuePortRanges[spec.Engine] {
+			securityGroupConfig.WriteString(fmt.Sprintf(`
+resource "huaweicloud_networking_secgroup_rule" "message_queue_sg_ingress_%d" {
+  security_group_id = huaweicloud_networking_secgroup.message_queue_sg.id
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  protocol          = "tcp"
+  port_range_min    = %d
+  port_range_max    = %d
+  remote_ip_prefix  = "%s"
+}
+`, i+1, ports[0], ports[1], vpc.CIDR))
+		}
+		securityGroupConfig.WriteString("\n")
+	}
+
+	brokerCount := spec.BrokerCount
+	if brokerCount < 1 {
+		brokerCount = 3
+	}
+
+	// Kafka实例部署在1个或3个可用区
+	availabilityZones := []string{config.AZ}
+	if len(spec.Zones) >= 3 {
+		availabilityZones = spec.Zones[:3]
+	}
+	subnet := spec.Subnets[0]
+
+	if spec.Engine == "rocketmq" {
+		return fmt.Sprintf(`%sresource "huaweicloud_dms_rocketmq_instance" "message_queue" {
+  name               = "%s"
+  engine_version     = "%s"
+  flavor_id          = "%s"
+  storage_spec_code  = "dms.physical.storage.high.v2"
+  storage_space      = %d
+  broker_num         = 1
+  vpc_id             = %s.id
+  subnet_id          = %s.id
+  security_group_id  = %s
+  availability_zones = %s
+  enable_acl         = true
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "huaweicloud_dms_rocketmq_user" "message_queue" {
+  instance_id = huaweicloud_dms_rocketmq_instance.message_queue.id
+  access_key  = "%s"
+  secret_key  = random_password.message_queue.result
+  admin       = true
+}
+
+output "message_queue_endpoint" {
+  value = huaweicloud_dms_rocketmq_instance.message_queue.namesrv_address
+}
+`, securityGroupConfig.String(), hclStringContent(spec.Name), spec.Version, spec.InstanceType, spec.Storage,
+			vpcAddress("huawei", vpc.Name), subnetAddress("huawei", subnet.Name), securityGroupRef,
+			hclStringList(availabilityZones), hclStringContent(spec.Name), hclStringContent(spec.Username))
+	}
+
+	return fmt.Sprintf(`%sresource "huaweicloud_dms_kafka_instance" "message_queue" {
+  name               = "%s"
+  engine_version     = "%s"
+  flavor_id          = "%s"
+  storage_spec_code  = "dms.physical.storage.ultra.v2"
+  storage_space      = %d
+  broker_num         = %d
+  vpc_id             = %s.id
+  network_id         = %s.id
+  security_group_id  = %s
+  availability_zones = %s
+  access_user        = "%s"
+  password           = random_password.message_queue.result
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+output "message_queue_endpoint" {
+  value = huaweicloud_dms_kafka_instance.message_queue.connect_address
+}
+`, securityGroupConfig.String(), hclStringContent(spec.Name), spec.Version, spec.InstanceType, spec.Storage*brokerCount,
+		brokerCount, vpcAddress("huawei", vpc.Name), subnetAddress("huawei", subnet.Name), securityGroupRef,
+		hclStringList(availabilityZones), hclStringContent(spec.Username), hclStringContent(spec.Name))
+}
+
+// generateTencentKafka 生成腾讯云CKafka专业版实例并创建SASL用户，CKafka的可用区使用数字ID
+func generateTencentKafka(config renderContext, spec messageQueueSpec, vpc models.VPC) string {
+	return fmt.Sprintf(`data "tencentcloud_availability_zones_by_product" "message_queue" {
+  product = "ckafka"
+  name    = "%s"
+}
+
+resource "tencentcloud_ckafka_instance" "message_queue" {
+  instance_name       = "%s"
+  zone_id             = tonumber(data.tencentcloud_availability_zones_by_product.message_queue.zones[0].id)
+  specifications_type = "%s"
+  kafka_version       = "%s"
+  disk_type           = "CLOUD_BASIC"
+  disk_size           = %d
+  band_width          = 20
+  partition           = 400
+  msg_retention_time  = 4320
+  charge_type         = "POSTPAID_BY_HOUR"
+  vpc_id              = %s.id
+  subnet_id           = %s.id
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "tencentcloud_ckafka_user" "message_queue" {
+  instance_id  = tencentcloud_ckafka_instance.message_queue.id
+  account_name = "%s"
+  password     = random_password.message_queue.result
+}
+
+output "message_queue_endpoint" {
+  value = "${tencentcloud_ckafka_instance.message_queue.vip}:${tencentcloud_ckafka_instance.message_queue.vport}"
+}
+`, config.AZ, hclStringContent(spec.Name), spec.InstanceType, spec.Version, spec.Storage, vpcAddress("tencent", vpc.Name),
+		subnetAddress("tencent", spec.Subnets[0].Name), hclStringContent(spec.Name), hclStringContent(spec.Username))
+}
+
+// generateTencentRocketMQ 生成腾讯云RocketMQ 5.x实例，只开放VPC接入点
+// 腾讯云RocketMQ的角色密钥由云服务生成，作为敏感输出导出
+func generateTencentRocketMQ(spec messageQueueSpec, vpc models.VPC) string {
+	return fmt.Sprintf(`resource "tencentcloud_trocket_rocketmq_instance" "message_queue" {
+  name          = "%s"
+  instance_type = "BASIC"
+  sku_code      = "%s"
+  remark        = "Landing zone message queue"
+  vpc_id        = %s.id
+  subnet_id     = %s.id
+
+  tags = merge(local.common_tags, {
+    Name = "%s"
+  })
+}
+
+resource "tencentcloud_trocket_rocketmq_role" "message_queue" {
+  instance_id = tencentcloud_trocket_rocketmq_instance.message_queue.id
+  role        = "%s"
+  remark      = "Landing zone message queue role"
+  perm_write  = true
+  perm_read   = true
+}
+
+output "message_queue_endpoint" {
+  value = tencentcloud_trocket_rocketmq_instance.message_queue.vpc_end_point
+}
+
+output "message_queue_access_key" {
+  value = tencentcloud_trocket_rocketmq_role.message_queue.access_key
+}
+
+output "message_queue_secret_key" {
+  value     = tencentcloud_trocket_rocketmq_role.message_queue.secret_key
+  sensitive = true
+}
+`, hclStringContent(spec.Name), spec.InstanceType, vpcAddress("tencent", vpc.Name), subnetAddress("tencent", spec.Subnets[0].Name),
+		hclStringContent(spec.Name), hclStringContent(spec.Username))
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateMessageQueueConfigUserStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		region   string
+		props    map[string]interface{}
+		want     string
+		wantErr  bool
+	}{
+		{"aws name is escaped", "aws", "us-east-1", map[string]interface{}{"name": `mq" } x = "${file("/etc/passwd")}`}, `cluster_name           = "mq\" } x = \"$${file(\"/etc/passwd\")}"`, false},
+		{"username is escaped", "alicloud", "cn-hangzhou", map[string]interface{}{"username": `admin"x`}, `value = "admin\"x"`, false},
+		{"azure has no engine version", "azure", "eastus", map[string]interface{}{}, `resource "azurerm_eventhub_namespace" "message_queue"`, false},
+		{"version with quotes", "aws", "us-east-1", map[string]interface{}{"version": `3.6.0" }`}, "", true},
+		{"instance type with spaces", "tencent", "ap-guangzhou", map[string]interface{}{"instance_type": "basic 2k"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig(tt.provider, tt.region))
+			body := generateMessageQueueConfig(config, tt.props)
+			if got := HasErrorFindings(config.findings.Findings()); got != tt.wantErr {
+				t.Fatalf("error findings = %v, want %v: %v", got, tt.wantErr, config.findings.Findings())
+			}
+			if tt.wantErr {
+				if body != "" {
+					t.Errorf("invalid message queue should not be rendered:\n%s", body)
+				}
+				return
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("config does not contain %q:\n%s", tt.want, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,506 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// DefaultModuleCatalogPath 未设置MODULE_CATALOG_PATH时读取的模块目录文件
+const DefaultModuleCatalogPath = "config/module_catalog.json"
+
+var (
+	moduleCatalogMutex sync.RWMutex
+	moduleCatalog      []models.ModuleComponent
+)
+
+// moduleComponentValueRegexp 模块组件的标识只能包含小写字母、数字和连字符
+var moduleComponentValueRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// moduleRegistrySourceRegexp 匹配模块仓库地址，例如 terraform-aws-modules/vpc/aws 或 app.terraform.io/acme/bucket/aws
+var moduleRegistrySourceRegexp = regexp.MustCompile(`^(?:[a-zA-Z0-9.-]+\.[a-z]+/)?[a-zA-Z0-9_-]+/[a-zA-Z0-9_-]+/[a-zA-Z0-9_-]+(?://[A-Za-z0-9_./-]+)?$`)
+
+// moduleGitSourceRegexp 匹配git地址，例如 git::https://example.com/bucket.git 或 github.com/acme/bucket
+var moduleGitSourceRegexp = regexp.MustCompile(`^(?:git::|github\.com/|bitbucket\.org/|git@)[^\s"]+$`)
+
+// moduleRegistryVersionRegexp 模块仓库的版本约束，例如 ~> 1.2 或 >= 1.0, < 2.0
+var moduleRegistryVersionRegexp = regexp.MustCompile(`^(?:(?:=|!=|>|>=|<|<=|~>)?\s*v?[0-9]+(?:\.[0-9]+){0,2}(?:-[0-9A-Za-z.-]+)?\s*,?\s*)+$`)
+
+// moduleGitRefRegexp git地址的版本为分支、标签或提交
+var moduleGitRefRegexp = regexp.MustCompile(`^[0-9A-Za-z._/-]+$`)
+
+// builtinComponentValues 内置组件的标识，模块组件不能与之重名
+var builtinComponentValues = map[string]bool{
+	"ec2": true, "rds": true, "database": true, "kubernetes": true, "bastion": true, "monitoring": true, "cache": true, "message-queue": true, "elb": true, "s3": true,
+	"load-balancer": true, "object-storage": true, "compute": true, "security-group": true,
+	"transit-gateway": true, "vpc-peering": true, "cross-cloud-vpn": true, "private-dns": true, "iam-baseline": true,
+	"lambda": true, "azure-functions": true, "function-compute": true, "functiongraph": true, "scf": true,
+}
+
+// moduleVariableTypes 模块变量类型 -> 前端属性类型
+var moduleVariableTypes = map[string]string{
+	"string": "text",
+	"number": "number",
+	"bool":   "boolean",
+	"list":   "text",
+	"map":    "text",
+}
+
+// moduleWirings 支持由部署自动填充的变量
+var moduleWirings = map[string]bool{
+	"vpc_id":             true,
+	"vpc_cidr":           true,
+	"subnet_id":          true,
+	"subnet_ids":         true,
+	"availability_zones": true,
+	"region":             true,
+	"tags":               true,
+}
+
+// LoadModuleCatalog 读取模块目录文件，文件内容为ModuleComponent数组
+// 本地路径的模块来源按目录文件所在目录解析为绝对路径；无效的条目会被跳过，不影响其他条目
+func LoadModuleCatalog(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var entries []models.ModuleComponent
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("解析模块目录文件 %s 失败: %w", path, err)
+	}
+
+	baseDir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	var catalog []models.ModuleComponent
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if err := validateModuleComponent(&entry, baseDir); err != nil {
+			LogError(fmt.Sprintf("模块组件 %s 配置无效，已跳过: %v", entry.Value, err))
+			continue
+		}
+		if seen[entry.Value] {
+			LogError(fmt.Sprintf("模块组件 %s 重复定义，已跳过", entry.Value))
+			continue
+		}
+		seen[entry.Value] = true
+		catalog = append(catalog, entry)
+	}
+
+	moduleCatalogMutex.Lock()
+	moduleCatalog = catalog
+	moduleCatalogMutex.Unlock()
+	LogInfo(fmt.Sprintf("已加载模块目录 %s，共 %d 个模块组件", path, len(catalog)))
+	return nil
+}
+
+// validateModuleComponent 校验模块组件的标识、来源、版本、变量和输出
+func validateModuleComponent(entry *models.ModuleComponent, baseDir string) error {
+	if !moduleComponentValueRegexp.MatchString(entry.Value) {
+		return fmt.Errorf("标识 %q 只能包含小写字母、数字和连字符，并以字母开头", entry.Value)
+	}
+	if builtinComponentValues[entry.Value] {
+		return fmt.Errorf("标识 %s 与内置组件重名", entry.Value)
+	}
+	if entry.Name == "" {
+		entry.Name = entry.Value
+	}
+	for _, provider := range entry.Providers {
+		if _, ok := vpcResourceTypes[provider]; !ok {
+			return fmt.Errorf("不支持的云提供商 %s", provider)
+		}
+	}
+
+	switch moduleSourceKind(entry.Source) {
+	case "local":
+		if !filepath.IsAbs(entry.Source) {
+			entry.Source = filepath.Join(baseDir, entry.Source)
+		}
+		if info, err := os.Stat(entry.Source); err != nil || !info.IsDir() {
+			LogWarn(fmt.Sprintf("模块组件 %s 的本地路径 %s 不存在", entry.Value, entry.Source))
+		}
+		if entry.Version != "" {
+			LogWarn(fmt.Sprintf("模块组件 %s 使用本地路径，版本 %s 将被忽略", entry.Value, entry.Version))
+			entry.Version = ""
+		}
+	case "git":
+		if entry.Version != "" && !moduleGitRefRegexp.MatchString(entry.Version) {
+			return fmt.Errorf("git版本 %q 必须是分支、标签或提交", entry.Version)
+		}
+	case "registry":
+		if entry.Version == "" {
+			LogWarn(fmt.Sprintf("模块组件 %s 没有指定版本，将使用模块仓库中的最新版本", entry.Value))
+		} else if !moduleRegistryVersionRegexp.MatchString(entry.Version) {
+			return fmt.Errorf("版本约束 %q 格式无效", entry.Version)
+		}
+	default:
+		return fmt.Errorf("模块来源 %q 必须是本地路径、git地址或模块仓库地址", entry.Source)
+	}
+
+	names := make(map[string]bool)
+	for i, variable := range entry.Variables {
+		if !terraformIdentifierRegexp.MatchString(variable.Name) || names[variable.Name] {
+			return fmt.Errorf("变量名称 %q 无效或重复", variable.Name)
+		}
+		names[variable.Name] = true
+		if variable.Type == "" {
+			entry.Variables[i].Type = "string"
+		} else if _, ok := moduleVariableTypes[variable.Type]; !ok {
+			return fmt.Errorf("变量 %s 的类型 %s 不受支持，可选值为 string、number、bool、list、map", variable.Name, variable.Type)
+		}
+		if variable.Wiring != "" && !moduleWirings[variable.Wiring] {
+			return fmt.Errorf("变量 %s 的自动填充方式 %s 不受支持", variable.Name, variable.Wiring)
+		}
+	}
+	for _, output := range entry.Outputs {
+		if !terraformIdentifierRegexp.MatchString(output.Name) {
+			return fmt.Errorf("输出名称 %q 无效", output.Name)
+		}
+	}
+	return nil
+}
+
+// moduleSourceKind 判断模块来源的类型：local、git或registry
+func moduleSourceKind(source string) string {
+	switch {
+	case strings.HasPrefix(source, "./"), strings.HasPrefix(source, "../"), filepath.IsAbs(source):
+		return "local"
+	case moduleGitSourceRegexp.MatchString(source):
+		return "git"
+	case moduleRegistrySourceRegexp.MatchString(source):
+		return "registry"
+	}
+	return ""
+}
+
+// findModuleComponent 按标识查找模块组件
+func findModuleComponent(value string) (models.ModuleComponent, bool) {
+	moduleCatalogMutex.RLock()
+	defer moduleCatalogMutex.RUnlock()
+	for _, entry := range moduleCatalog {
+		if entry.Value == value {
+			return entry, true
+		}
+	}
+	return models.ModuleComponent{}, false
+}
+
+// ModuleCatalogComponents 返回适用于指定云提供商的模块组件，由部署自动填充的变量不显示为属性
+func ModuleCatalogComponents(provider string) []models.Component {
+	moduleCatalogMutex.RLock()
+	defer moduleCatalogMutex.RUnlock()
+
+	var components []models.Component
+	for _, entry := range moduleCatalog {
+		if !moduleSupportsProvider(entry, provider) {
+			continue
+		}
+		component := models.Component{
+			Name:        entry.Name,
+			Value:       entry.Value,
+			Description: entry.Description,
+			Properties:  []models.ComponentProperty{},
+		}
+		for _, variable := range entry.Variables {
+			if variable.Wiring != "" {
+				continue
+			}
+			defaultValue := ""
+			if variable.Default != nil {
+				if value, err := moduleInputValue(variable.Type, variable.Default); err == nil {
+					defaultValue = strings.Trim(value, `"`)
+				}
+			}
+			placeholder := "请输入" + variable.Name
+			if variable.Type == "list" || variable.Type == "map" {
+				placeholder = "JSON格式的" + variable.Type
+			}
+			component.Properties = append(component.Properties, models.ComponentProperty{
+				Name:         variable.Name,
+				Key:          variable.Name,
+				Type:         moduleVariableTypes[variable.Type],
+				DefaultValue: defaultValue,
+				Placeholder:  placeholder,
+				Description:  variable.Description,
+			})
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// moduleSupportsProvider 判断模块组件是否适用于云提供商
+func moduleSupportsProvider(entry models.ModuleComponent, provider string) bool {
+	if len(entry.Providers) == 0 {
+		return true
+	}
+	for _, supported := range entry.Providers {
+		if supported == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// generateModuleComponentConfig 生成调用模块组件的module块，VPC和子网相关变量按部署自动填充
+// 目录中声明的输出在根模块中以 <模块名>_<输出名> 重新导出
+func generateModuleComponentConfig(config renderContext, entry models.ModuleComponent, propsMap map[string]interface{}) string {
+	provider := config.CloudProvider
+	if !moduleSupportsProvider(entry, provider) {
+		reportWarn(config, fmt.Sprintf("模块组件 %s 不适用于云提供商 %s，已忽略", entry.Value, provider))
+		return ""
+	}
+	label := strings.ReplaceAll(entry.Value, "-", "_")
+
+	// VPC和子网的自动填充使用第一个VPC及其子网，只开通账号等没有网络的部署不填充
+	var vpc models.VPC
+	var subnets []models.Subnet
+	if vpcs := resolveVpcs(config); len(vpcs) > 0 && vpcs[0].Name != "" {
+		vpc = vpcs[0]
+		for _, subnet := range resolveSubnets(config) {
+			if subnet.Name != "" && subnetVpc(config, subnet).Name == vpc.Name {
+				subnets = append(subnets, subnet)
+			}
+		}
+	}
+
+	var inputs [][2]string
+	for _, variable := range entry.Variables {
+		if variable.Wiring != "" {
+			value := moduleWiringValue(config, variable.Wiring, vpc, subnets)
+			if value == "" && variable.Required {
+				reportError(config, fmt.Sprintf("模块组件 %s 的必填变量 %s 无法自动填充，跳过生成", entry.Value, variable.Name))
+				return ""
+			}
+			if value == "" {
+				reportWarn(config, fmt.Sprintf("模块组件 %s 的变量 %s 无法自动填充，已忽略", entry.Value, variable.Name))
+				continue
+			}
+			inputs = append(inputs, [2]string{variable.Name, value})
+			continue
+		}
+
+		raw, ok := propsMap[variable.Name]
+		if !ok || raw == nil || raw == "" {
+			raw = variable.Default
+		}
+		if raw == nil {
+			if variable.Required {
+				reportError(config, fmt.Sprintf("模块组件 %s 缺少必填变量 %s，跳过生成", entry.Value, variable.Name))
+				return ""
+			}
+			continue
+		}
+		value, err := moduleInputValue(variable.Type, raw)
+		if err != nil {
+			reportError(config, fmt.Sprintf("模块组件 %s 的变量 %s 无效，跳过生成: %v", entry.Value, variable.Name, err))
+			return ""
+		}
+		inputs = append(inputs, [2]string{variable.Name, value})
+	}
+
+	source := entry.Source
+	version := ""
+	switch moduleSourceKind(entry.Source) {
+	case "git":
+		if entry.Version != "" {
+			separator := "?"
+			if strings.Contains(source, "?") {
+				separator = "&"
+			}
+			source += separator + "ref=" + entry.Version
+		}
+	case "registry":
+		version = entry.Version
+	}
+
+	var moduleConfig strings.Builder
+	moduleConfig.WriteString(fmt.Sprintf("\n# 模块组件 %s\nmodule %q {\n", entry.Name, label))
+	if version != "" {
+		moduleConfig.WriteString(fmt.Sprintf("  source  = %s\n  version = %s\n", hclString(source), hclString(version)))
+	} else {
+		moduleConfig.WriteString(fmt.Sprintf("  source = %s\n", hclString(source)))
+	}
+	if config.ProviderAlias != "" && len(config.Peers) > 0 {
+		// 多云部署中模块使用分段的provider别名
+		name := providerLocalName(provider)
+		moduleConfig.WriteString(fmt.Sprintf("\n  providers = {\n    %s = %s.%s\n  }\n", name, name, config.ProviderAlias))
+	}
+	if len(inputs) > 0 {
+		width := 0
+		for _, input := range inputs {
+			if len(input[0]) > width {
+				width = len(input[0])
+			}
+		}
+		moduleConfig.WriteString("\n")
+		for _, input := range inputs {
+			moduleConfig.WriteString(fmt.Sprintf("  %-*s = %s\n", width, input[0], input[1]))
+		}
+	}
+	moduleConfig.WriteString("}\n")
+
+	for _, output := range entry.Outputs {
+		if output.Sensitive {
+			moduleConfig.WriteString(fmt.Sprintf("\noutput \"%s_%s\" {\n  description = %s\n  value       = module.%s.%s\n  sensitive   = true\n}\n", label, output.Name, hclString(output.Description), label, output.Name))
+		} else {
+			moduleConfig.WriteString(fmt.Sprintf("\noutput \"%s_%s\" {\n  description = %s\n  value       = module.%s.%s\n}\n", label, output.Name, hclString(output.Description), label, output.Name))
+		}
+	}
+
+	LogInfo(fmt.Sprintf("已生成模块组件配置: 名称=%s, 来源=%s, 版本=%s, 变量=%d", entry.Value, entry.Source, entry.Version, len(inputs)))
+	return moduleConfig.String()
+}
+
+// moduleWiringValue 返回自动填充变量的表达式
+func moduleWiringValue(config renderContext, wiring string, vpc models.VPC, subnets []models.Subnet) string {
+	provider := config.CloudProvider
+	var subnetIds, zones []string
+	seenZones := make(map[string]bool)
+	for _, subnet := range subnets {
+		subnetIds = append(subnetIds, subnetAddress(provider, subnet.Name)+".id")
+		if zone := subnetZone(config, subnet); zone != "" && !seenZones[zone] {
+			seenZones[zone] = true
+			zones = append(zones, zone)
+		}
+	}
+
+	switch wiring {
+	case "vpc_id":
+		if vpc.Name != "" {
+			return vpcAddress(provider, vpc.Name) + ".id"
+		}
+	case "vpc_cidr":
+		if vpc.CIDR != "" {
+			return hclString(vpc.CIDR)
+		}
+	case "subnet_id":
+		if len(subnetIds) > 0 {
+			return subnetIds[0]
+		}
+	case "subnet_ids":
+		if len(subnetIds) > 0 {
+			return hclList(subnetIds)
+		}
+	case "availability_zones":
+		if len(zones) > 0 {
+			return hclStringList(zones)
+		}
+	case "region":
+		return hclString(config.Region)
+	case "tags":
+		return hclTagMap(filterTags(provider, resolveTags(config)), "  ")
+	}
+	return ""
+}
+
+// moduleInputValue 按变量类型将属性值渲染为HCL表达式
+// 列表和映射可以是JSON值或JSON字符串，列表也可以是逗号分隔的字符串
+func moduleInputValue(variableType string, raw interface{}) (string, error) {
+	switch variableType {
+	case "number":
+		switch v := raw.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case string:
+			if _, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return strings.TrimSpace(v), nil
+			}
+		}
+		return "", fmt.Errorf("%v 不是数字", raw)
+	case "bool":
+		switch v := raw.(type) {
+		case bool:
+			return strconv.FormatBool(v), nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return strconv.FormatBool(b), nil
+			}
+		}
+		return "", fmt.Errorf("%v 不是布尔值", raw)
+	case "list":
+		if text, ok := raw.(string); ok {
+			var decoded []interface{}
+			if err := json.Unmarshal([]byte(text), &decoded); err == nil {
+				raw = decoded
+			} else {
+				var items []interface{}
+				for _, item := range strings.Split(text, ",") {
+					if item = strings.TrimSpace(item); item != "" {
+						items = append(items, item)
+					}
+				}
+				raw = items
+			}
+		}
+		if _, ok := raw.([]interface{}); !ok {
+			return "", fmt.Errorf("%v 不是列表", raw)
+		}
+		return hclLiteral(raw)
+	case "map":
+		if text, ok := raw.(string); ok {
+			var decoded map[string]interface{}
+			if err := json.Unmarshal([]byte(text), &decoded); err != nil {
+				return "", fmt.Errorf("%q 不是JSON对象", text)
+			}
+			raw = decoded
+		}
+		if _, ok := raw.(map[string]interface{}); !ok {
+			return "", fmt.Errorf("%v 不是映射", raw)
+		}
+		return hclLiteral(raw)
+	}
+
+	text, ok := raw.(string)
+	if !ok {
+		return hclLiteral(raw)
+	}
+	return hclString(text), nil
+}
+
+// hclLiteral 将JSON值渲染为单行HCL字面量
+func hclLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return hclString(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			literal, err := hclLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, literal)
+		}
+		return hclList(items), nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		entries := make([]string, 0, len(keys))
+		for _, key := range keys {
+			literal, err := hclLiteral(v[key])
+			if err != nil {
+				return "", err
+			}
+			entries = append(entries, fmt.Sprintf("%s = %s", hclString(key), literal))
+		}
+		return "{ " + strings.Join(entries, ", ") + " }", nil
+	}
+	return "", fmt.Errorf("不支持的值 %v", value)
+}
@@ -0,0 +1,560 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// monitoringProviders 支持监控告警组件的云提供商及其通知目标
+var monitoringProviders = map[string]string{
+	"aws":      "SNS topic",
+	"azure":    "action group",
+	"alicloud": "CMS contact group",
+	"tencent":  "notice template",
+}
+
+// natResourceTypes 各云提供商NAT网关的资源类型
+var natResourceTypes = map[string]string{
+	"aws":      "aws_nat_gateway",
+	"azure":    "azurerm_nat_gateway",
+	"alicloud": "alicloud_nat_gateway",
+	"tencent":  "tencentcloud_nat_gateway",
+}
+
+// azureAlertDurations Azure指标告警支持的评估频率和时间窗口
+var azureAlertDurations = []struct {
+	Seconds  int
+	Duration string
+}{
+	{60, "PT1M"}, {300, "PT5M"}, {900, "PT15M"}, {1800, "PT30M"}, {3600, "PT1H"},
+	{21600, "PT6H"}, {43200, "PT12H"}, {86400, "P1D"},
+}
+
+// monitoringTarget 表示需要创建告警的已部署资源
+type monitoringTarget struct {
+	Kind    string // lb, database, nat
+	Label   string // 告警资源名称的前缀
+	Address string // 被监控资源的Terraform地址
+	Engine  string // 数据库引擎，仅数据库使用
+}
+
+// monitoringSpec 表示监控告警组件的解析结果，阈值都可以通过组件属性覆盖
+type monitoringSpec struct {
+	Name               string
+	Emails             []string
+	WebhookUrl         string
+	NoticeUserIds      []string
+	Lb5xxThreshold     int // 负载均衡5xx响应占请求数的百分比
+	DbCpuThreshold     int // 数据库CPU使用率百分比
+	DbStorageThreshold int // 数据库存储使用率百分比
+	NatDropThreshold   int // NAT网关每个统计周期的丢包数
+	Period             int // 统计周期（秒）
+	EvaluationPeriods  int // 连续超过阈值的周期数
+}
+
+// generateMonitoringConfig 生成监控告警组件的Terraform配置
+// 为部署中的负载均衡、数据库和NAT网关创建指标告警，告警发送到SNS主题、Azure操作组、阿里云联系人组或腾讯云通知模板
+func generateMonitoringConfig(config renderContext, propsMap map[string]interface{}) string {
+	provider := config.CloudProvider
+	if _, ok := monitoringProviders[provider]; !ok {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持监控告警组件", provider))
+		return ""
+	}
+
+	spec := monitoringSpec{
+		Name:               strings.ToLower(getStringProp(propsMap, "name", "landing-zone")),
+		Emails:             getStringListProp(propsMap, "emails"),
+		WebhookUrl:         getStringProp(propsMap, "webhook_url", ""),
+		NoticeUserIds:      getStringListProp(propsMap, "notice_user_ids"),
+		Lb5xxThreshold:     getIntProp(propsMap, "lb_5xx_threshold", 5),
+		DbCpuThreshold:     getIntProp(propsMap, "db_cpu_threshold", 80),
+		DbStorageThreshold: getIntProp(propsMap, "db_storage_threshold", 85),
+		NatDropThreshold:   getIntProp(propsMap, "nat_drop_threshold", 100),
+		Period:             getIntProp(propsMap, "period", 300),
+		EvaluationPeriods:  getIntProp(propsMap, "evaluation_periods", 3),
+	}
+	if spec.Period < 60 {
+		reportWarn(config, fmt.Sprintf("告警统计周期 %d 秒过短，已调整为60秒", spec.Period))
+		spec.Period = 60
+	}
+	if spec.EvaluationPeriods < 1 {
+		spec.EvaluationPeriods = 1
+	}
+	for _, threshold := range []*int{&spec.Lb5xxThreshold, &spec.DbCpuThreshold, &spec.DbStorageThreshold} {
+		if *threshold <= 0 || *threshold >= 100 {
+			reportWarn(config, fmt.Sprintf("百分比阈值 %d 无效，必须在1到99之间，已调整为80", *threshold))
+			*threshold = 80
+		}
+	}
+
+	targets := collectMonitoringTargets(config)
+	if len(targets) == 0 {
+		reportWarn(config, "部署中没有可监控的负载均衡、数据库或NAT网关，跳过生成监控告警")
+		return ""
+	}
+	if len(spec.Emails) == 0 && spec.WebhookUrl == "" && len(spec.NoticeUserIds) == 0 {
+		reportWarn(config, fmt.Sprintf("监控告警没有配置接收人，告警只会发送到空的%s", monitoringProviders[provider]))
+	}
+
+	var monitoringConfig strings.Builder
+	switch provider {
+	case "aws":
+		monitoringConfig.WriteString(generateAwsMonitoring(spec, targets))
+	case "azure":
+		monitoringConfig.WriteString(generateAzureMonitoring(spec, targets))
+	case "alicloud":
+		monitoringConfig.WriteString(generateAlicloudMonitoring(spec, targets))
+	case "tencent":
+		monitoringConfig.WriteString(generateTencentMonitoring(config, spec, targets))
+	}
+
+	LogInfo(fmt.Sprintf("已生成监控告警配置: 通知目标=%s, 监控资源=%d", monitoringProviders[provider], len(targets)))
+	return monitoringConfig.String()
+}
+
+// collectMonitoringTargets 根据部署的组件和路由规划收集需要告警的资源
+func collectMonitoringTargets(config renderContext) []monitoringTarget {
+	provider := config.CloudProvider
+	var targets []monitoringTarget
+
+	// 负载均衡组件目前只生成AWS的负载均衡，5xx指标只有应用负载均衡提供
+	if lbProps := componentPropsMap(config, "elb"); lbProps != nil && provider == "aws" {
+		if getStringProp(lbProps, "lb_type", "application") == "application" {
+			targets = append(targets, monitoringTarget{Kind: "lb", Label: "elb", Address: "aws_lb.elb"})
+		} else {
+			reportWarn(config, "网络负载均衡没有5xx指标，已跳过负载均衡告警")
+		}
+	}
+
+	dbProps := componentPropsMap(config, "database")
+	if dbProps == nil && provider == "aws" {
+		dbProps = componentPropsMap(config, "rds")
+	}
+	if dbProps != nil {
+		engine := normalizeDatabaseEngine(getStringProp(dbProps, "engine", "mysql"))
+		if engine == "mariadb" && provider != "aws" {
+			engine = "mysql"
+		}
+		address := ""
+		switch provider {
+		case "aws":
+			address = "aws_db_instance.database"
+		case "azure":
+			address = "azurerm_mysql_flexible_server.database"
+			if engine == "postgres" {
+				address = "azurerm_postgresql_flexible_server.database"
+			}
+		case "alicloud":
+			address = "alicloud_db_instance.database"
+		case "tencent":
+			address = "tencentcloud_mysql_instance.database"
+			if engine == "postgres" {
+				reportWarn(config, "腾讯云PostgreSQL暂不支持创建数据库告警，已跳过")
+				address = ""
+			}
+		}
+		if address != "" {
+			targets = append(targets, monitoringTarget{Kind: "database", Label: "database", Address: address, Engine: engine})
+		}
+	}
+
+	if config.ComponentConfig.EnableRouteTables {
+		for _, plan := range planVpcRouting(config) {
+			for _, nat := range plan.NatGateways {
+				targets = append(targets, monitoringTarget{Kind: "nat", Label: nat.Label, Address: natResourceTypes[provider] + "." + nat.Label})
+			}
+		}
+	}
+	return targets
+}
+
+// generateAwsMonitoring 生成SNS主题及订阅，以及负载均衡5xx比例、RDS CPU和存储、NAT网关丢包的CloudWatch告警
+func generateAwsMonitoring(spec monitoringSpec, targets []monitoringTarget) string {
+	var monitoring strings.Builder
+	monitoring.WriteString(fmt.Sprintf(`resource "aws_sns_topic" "monitoring" {
+  name = "%s-alarms"
+
+  tags = {
+    Name = "%s-alarms"
+  }
+}
+`, spec.Name, spec.Name))
+
+	if len(spec.Emails) > 0 {
+		monitoring.WriteString(fmt.Sprintf(`
+resource "aws_sns_topic_subscription" "monitoring_email" {
+  for_each = toset(%s)
+
+  topic_arn = aws_sns_topic.monitoring.arn
+  protocol  = "email"
+  endpoint  = each.value
+}
+`, hclStringList(spec.Emails)))
+	}
+	if spec.WebhookUrl != "" {
+		monitoring.WriteString(fmt.Sprintf(`
+resource "aws_sns_topic_subscription" "monitoring_webhook" {
+  topic_arn = aws_sns_topic.monitoring.arn
+  protocol  = "https"
+  endpoint  = "%s"
+}
+`, spec.WebhookUrl))
+	}
+
+	// metricAlarm 生成单指标告警
+	metricAlarm := func(label, description, namespace, metric, statistic, comparison, threshold, dimensions string) string {
+		return fmt.Sprintf(`
+resource "aws_cloudwatch_metric_alarm" "%s" {
+  alarm_name          = "%s-%s"
+  alarm_description   = "%s"
+  namespace           = "%s"
+  metric_name         = "%s"
+  statistic           = "%s"
+  period              = %d
+  evaluation_periods  = %d
+  comparison_operator = "%s"
+  threshold           = %s
+  treat_missing_data  = "notBreaching"
+  dimensions          = { %s }
+  alarm_actions       = [aws_sns_topic.monitoring.arn]
+  ok_actions          = [aws_sns_topic.monitoring.arn]
+}
+`, label, spec.Name, strings.ReplaceAll(label, "_", "-"), description, namespace, metric, statistic,
+			spec.Period, spec.EvaluationPeriods, comparison, threshold, dimensions)
+	}
+
+	for _, target := range targets {
+		switch target.Kind {
+		case "lb":
+			// 5xx比例 = (负载均衡5xx + 目标5xx) / 请求数，没有请求时不告警
+			queries := ""
+			for _, query := range []struct{ Id, Metric string }{
+				{"elb_5xx", "HTTPCode_ELB_5XX_Count"},
+				{"target_5xx", "HTTPCode_Target_5XX_Count"},
+				{"requests", "RequestCount"},
+			} {
+				queries += fmt.Sprintf(`
+
+  metric_query {
+    id = "%s"
+
+    metric {
+      namespace   = "AWS/ApplicationELB"
+      metric_name = "%s"
+      period      = %d
+      stat        = "Sum"
+      dimensions  = { LoadBalancer = %s.arn_suffix }
+    }
+  }`, query.Id, query.Metric, spec.Period, target.Address)
+			}
+			monitoring.WriteString(fmt.Sprintf(`
+resource "aws_cloudwatch_metric_alarm" "%s_5xx_rate" {
+  alarm_name          = "%s-%s-5xx-rate"
+  alarm_description   = "Load balancer 5xx responses exceed %d%% of requests"
+  evaluation_periods  = %d
+  comparison_operator = "GreaterThanThreshold"
+  threshold           = %d
+  treat_missing_data  = "notBreaching"
+  alarm_actions       = [aws_sns_topic.monitoring.arn]
+  ok_actions          = [aws_sns_topic.monitoring.arn]
+
+  metric_query {
+    id          = "rate"
+    expression  = "100 * (FILL(elb_5xx, 0) + FILL(target_5xx, 0)) / requests"
+    label       = "5xx rate"
+    return_data = true
+  }%s
+}
+`, target.Label, spec.Name, target.Label, spec.Lb5xxThreshold, spec.EvaluationPeriods, spec.Lb5xxThreshold, queries))
+
+		case "database":
+			dimensions := fmt.Sprintf("DBInstanceIdentifier = %s.identifier", target.Address)
+			monitoring.WriteString(metricAlarm(target.Label+"_cpu",
+				fmt.Sprintf("Database CPU utilization above %d%%", spec.DbCpuThreshold),
+				"AWS/RDS", "CPUUtilization", "Average", "GreaterThanThreshold", fmt.Sprint(spec.DbCpuThreshold), dimensions))
+			// RDS只提供剩余存储空间指标，按分配的存储大小换算使用率阈值
+			monitoring.WriteString(metricAlarm(target.Label+"_storage",
+				fmt.Sprintf("Database storage usage above %d%%", spec.DbStorageThreshold),
+				"AWS/RDS", "FreeStorageSpace", "Minimum", "LessThanThreshold",
+				fmt.Sprintf("%s.allocated_storage * 1073741824 * %s", target.Address, storageFreeRatio(spec.DbStorageThreshold)), dimensions))
+
+		case "nat":
+			monitoring.WriteString(metricAlarm(target.Label+"_packet_drops",
+				fmt.Sprintf("NAT gateway dropped more than %d packets per period", spec.NatDropThreshold),
+				"AWS/NATGateway", "PacketsDropCount", "Sum", "GreaterThanThreshold", fmt.Sprint(spec.NatDropThreshold),
+				fmt.Sprintf("NatGatewayId = %s.id", target.Address)))
+		}
+	}
+	return monitoring.String()
+}
+
+// storageFreeRatio 将存储使用率阈值换算为剩余空间比例，例如85换算为0.15
+func storageFreeRatio(usedPercent int) string {
+	return fmt.Sprintf("%.2f", float64(100-usedPercent)/100)
+}
+
+// azureAlertDuration 返回不小于指定秒数的Azure告警时长
+func azureAlertDuration(seconds int) string {
+	for _, duration := range azureAlertDurations {
+		if duration.Seconds >= seconds {
+			return duration.Duration
+		}
+	}
+	return azureAlertDurations[len(azureAlertDurations)-1].Duration
+}
+
+// generateAzureMonitoring 生成Azure操作组，以及数据库CPU和存储、NAT网关丢包的指标告警
+func generateAzureMonitoring(spec monitoringSpec, targets []monitoringTarget) string {
+	// 操作组的短名称最多12个字符
+	shortName := strings.ReplaceAll(spec.Name, "-", "")
+	if len(shortName) > 12 {
+		shortName = shortName[:12]
+	}
+
+	var receivers strings.Builder
+	for i, email := range spec.Emails {
+		receivers.WriteString(fmt.Sprintf(`
+
+  email_receiver {
+    name                    = "email-%d"
+    email_address           = "%s"
+    use_common_alert_schema = true
+  }`, i+1, email))
+	}
+	if spec.WebhookUrl != "" {
+		receivers.WriteString(fmt.Sprintf(`
+
+  webhook_receiver {
+    name                    = "webhook"
+    service_uri             = "%s"
+    use_common_alert_schema = true
+  }`, spec.WebhookUrl))
+	}
+
+	var monitoring strings.Builder
+	monitoring.WriteString(fmt.Sprintf(`resource "azurerm_monitor_action_group" "monitoring" {
+  name                = "%s-alarms"
+  resource_group_name = azurerm_resource_group.rg.name
+  short_name          = "%s"%s
+
+  tags = local.common_tags
+}
+`, spec.Name, shortName, receivers.String()))
+
+	frequency := azureAlertDuration(spec.Period)
+	if spec.Period > 3600 {
+		frequency = "PT1H"
+	}
+	window := azureAlertDuration(spec.Period * spec.EvaluationPeriods)
+
+	// metricAlert 生成单指标告警
+	metricAlert := func(label, description, scope, namespace, metric, aggregation string, threshold int) string {
+		return fmt.Sprintf(`
+resource "azurerm_monitor_metric_alert" "%s" {
+  name                = "%s-%s"
+  resource_group_name = azurerm_resource_group.rg.name
+  scopes              = [%s.id]
+  description         = "%s"
+  severity            = 2
+  frequency           = "%s"
+  window_size         = "%s"
+
+  criteria {
+    metric_namespace = "%s"
+    metric_name      = "%s"
+    aggregation      = "%s"
+    operator         = "GreaterThan"
+    threshold        = %d
+  }
+
+  action {
+    action_group_id = azurerm_monitor_action_group.monitoring.id
+  }
+
+  tags = local.common_tags
+}
+`, label, spec.Name, strings.ReplaceAll(label, "_", "-"), scope, description, frequency, window,
+			namespace, metric, aggregation, threshold)
+	}
+
+	for _, target := range targets {
+		switch target.Kind {
+		case "database":
+			namespace := "Microsoft.DBforMySQL/flexibleServers"
+			if target.Engine == "postgres" {
+				namespace = "Microsoft.DBforPostgreSQL/flexibleServers"
+			}
+			monitoring.WriteString(metricAlert(target.Label+"_cpu",
+				fmt.Sprintf("Database CPU utilization above %d%%", spec.DbCpuThreshold),
+				target.Address, namespace, "cpu_percent", "Average", spec.DbCpuThreshold))
+			monitoring.WriteString(metricAlert(target.Label+"_storage",
+				fmt.Sprintf("Database storage usage above %d%%", spec.DbStorageThreshold),
+				target.Address, namespace, "storage_percent", "Maximum", spec.DbStorageThreshold))
+
+		case "nat":
+			monitoring.WriteString(metricAlert(target.Label+"_packet_drops",
+				fmt.Sprintf("NAT gateway dropped more than %d packets", spec.NatDropThreshold),
+				target.Address, "Microsoft.Network/natGateways", "PacketDropCount", "Total", spec.NatDropThreshold))
+		}
+	}
+	return monitoring.String()
+}
+
+// generateAlicloudMonitoring 生成云监控报警联系人和联系人组，以及RDS CPU和存储、NAT网关丢包的报警规则
+func generateAlicloudMonitoring(spec monitoringSpec, targets []monitoringTarget) string {
+	var monitoring strings.Builder
+	contacts := make([]string, 0, len(spec.Emails))
+	for i, email := range spec.Emails {
+		monitoring.WriteString(fmt.Sprintf(`resource "alicloud_cms_alarm_contact" "monitoring_%d" {
+  alarm_contact_name = "%s-contact-%d"
+  describe           = "Landing zone alarm contact"
+  channels_mail      = "%s"
+}
+
+`, i+1, spec.Name, i+1, email))
+		contacts = append(contacts, fmt.Sprintf("alicloud_cms_alarm_contact.monitoring_%d.alarm_contact_name", i+1))
+	}
+	monitoring.WriteString(fmt.Sprintf(`resource "alicloud_cms_alarm_contact_group" "monitoring" {
+  alarm_contact_group_name = "%s-alarms"
+  describe                 = "Landing zone alarm contacts"
+  contacts                 = %s
+}
+`, spec.Name, hclList(contacts)))
+
+	webhookLine := ""
+	if spec.WebhookUrl != "" {
+		webhookLine = fmt.Sprintf("\n  webhook            = \"%s\"", spec.WebhookUrl)
+	}
+
+	// cmsAlarm 生成单指标报警规则
+	cmsAlarm := func(label, project, metric, statistics string, threshold int, instanceId string) string {
+		return fmt.Sprintf(`
+resource "alicloud_cms_alarm" "%s" {
+  name               = "%s-%s"
+  project            = "%s"
+  metric             = "%s"
+  period             = %d
+  contact_groups     = [alicloud_cms_alarm_contact_group.monitoring.alarm_contact_group_name]
+  effective_interval = "00:00-23:59"
+  metric_dimensions  = jsonencode([{ instanceId = %s }])%s
+
+  escalations_critical {
+    statistics          = "%s"
+    comparison_operator = ">"
+    threshold           = "%d"
+    times               = %d
+  }
+
+  tags = local.common_tags
+}
+`, label, spec.Name, strings.ReplaceAll(label, "_", "-"), project, metric, spec.Period, instanceId, webhookLine,
+			statistics, threshold, spec.EvaluationPeriods)
+	}
+
+	for _, target := range targets {
+		switch target.Kind {
+		case "database":
+			monitoring.WriteString(cmsAlarm(target.Label+"_cpu", "acs_rds_dashboard", "CpuUsage", "Average", spec.DbCpuThreshold, target.Address+".id"))
+			monitoring.WriteString(cmsAlarm(target.Label+"_storage", "acs_rds_dashboard", "DiskUsage", "Maximum", spec.DbStorageThreshold, target.Address+".id"))
+		case "nat":
+			monitoring.WriteString(cmsAlarm(target.Label+"_packet_drops", "acs_nat_gateway", "DropTotalPps", "Maximum", spec.NatDropThreshold, target.Address+".id"))
+		}
+	}
+	return monitoring.String()
+}
+
+// generateTencentMonitoring 生成云监控通知模板，以及MySQL CPU和存储、NAT网关丢包的告警策略
+// 腾讯云通知模板按子用户UID通知，邮件地址需要在子用户中配置
+func generateTencentMonitoring(config renderContext, spec monitoringSpec, targets []monitoringTarget) string {
+	if len(spec.Emails) > 0 {
+		reportWarn(config, "腾讯云通知模板不支持直接填写邮件地址，请通过notice_user_ids指定接收告警的子用户UID")
+	}
+
+	userIds := make([]string, 0, len(spec.NoticeUserIds))
+	for _, userId := range spec.NoticeUserIds {
+		if _, err := strconv.ParseUint(userId, 10, 64); err != nil {
+			reportWarn(config, fmt.Sprintf("通知用户UID %s 不是有效的数字，已忽略", userId))
+			continue
+		}
+		userIds = append(userIds, userId)
+	}
+
+	var notices strings.Builder
+	if len(userIds) > 0 {
+		notices.WriteString(fmt.Sprintf(`
+
+  user_notices {
+    receiver_type = "USER"
+    user_ids      = [%s]
+    notice_way    = ["EMAIL", "SMS"]
+    start_time    = 0
+    end_time      = 86399
+  }`, strings.Join(userIds, ", ")))
+	}
+	if spec.WebhookUrl != "" {
+		notices.WriteString(fmt.Sprintf(`
+
+  url_notices {
+    url        = "%s"
+    start_time = 0
+    end_time   = 86399
+  }`, spec.WebhookUrl))
+	}
+
+	var monitoring strings.Builder
+	monitoring.WriteString(fmt.Sprintf(`resource "tencentcloud_monitor_alarm_notice" "monitoring" {
+  name            = "%s-alarms"
+  notice_type     = "ALL"
+  notice_language = "zh-CN"%s
+}
+`, spec.Name, notices.String()))
+
+	// alarmPolicy 生成单指标告警策略，并绑定被监控的实例
+	alarmPolicy := func(label, namespace, metric, dimensionKey, instanceId string, threshold int) string {
+		return fmt.Sprintf(`
+resource "tencentcloud_monitor_alarm_policy" "%s" {
+  policy_name  = "%s-%s"
+  monitor_type = "MT_QCE"
+  namespace    = "%s"
+  project_id   = 0
+  enable       = 1
+  notice_ids   = [tencentcloud_monitor_alarm_notice.monitoring.id]
+
+  conditions {
+    is_union_rule = 0
+
+    rules {
+      metric_name      = "%s"
+      period           = %d
+      operator         = "gt"
+      value            = "%d"
+      continue_period  = %d
+      notice_frequency = 3600
+      is_power_notice  = 0
+    }
+  }
+}
+
+resource "tencentcloud_monitor_policy_binding_object" "%s" {
+  policy_id = tencentcloud_monitor_alarm_policy.%s.id
+
+  dimensions {
+    dimensions_json = jsonencode({ %s = %s })
+  }
+}
+`, label, spec.Name, strings.ReplaceAll(label, "_", "-"), namespace, metric, spec.Period, threshold, spec.EvaluationPeriods,
+			label, label, dimensionKey, instanceId)
+	}
+
+	for _, target := range targets {
+		switch target.Kind {
+		case "database":
+			monitoring.WriteString(alarmPolicy(target.Label+"_cpu", "cdb_detail", "CpuUseRate", "InstanceId", target.Address+".id", spec.DbCpuThreshold))
+			monitoring.WriteString(alarmPolicy(target.Label+"_storage", "cdb_detail", "VolumeRate", "InstanceId", target.Address+".id", spec.DbStorageThreshold))
+		case "nat":
+			monitoring.WriteString(alarmPolicy(target.Label+"_packet_drops", "nat_tc_stat", "Droppkg", "natId", target.Address+".id", spec.NatDropThreshold))
+		}
+	}
+	return monitoring.String()
+}
@@ -0,0 +1,422 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// providerAliasRegexp Terraform provider别名必须是合法的标识符
+var providerAliasRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// hclBlockHeaderRegexp 匹配顶层的resource和data块，捕获块类型、资源类型和名称
+var hclBlockHeaderRegexp = regexp.MustCompile(`^(resource|data) "([a-zA-Z0-9_-]+)" "([a-zA-Z0-9_-]+)" \{(\})?\s*$`)
+
+// hclProviderHeaderRegexp 匹配顶层的provider块
+var hclProviderHeaderRegexp = regexp.MustCompile(`^provider "([a-zA-Z0-9_-]+)" \{`)
+
+// hclModuleHeaderRegexp 匹配顶层的module块
+var hclModuleHeaderRegexp = regexp.MustCompile(`^module "([a-zA-Z0-9_-]+)" \{\s*$`)
+
+// deploymentSection 表示多云部署文档中的一个提供商/区域分段
+type deploymentSection struct {
+	Config renderContext
+	Alias  string
+}
+
+// generateMultiProviderConfig 将多云部署文档的所有分段生成到同一个Terraform根模块中
+// 每个分段生成一个带别名的provider，分段内的资源和数据源通过provider参数绑定到该别名，
+// 因此分段之间可以直接引用彼此的资源，例如跨云VPN引用另一分段的VPC
+func generateMultiProviderConfig(config renderContext) string {
+	sections := resolveDeploymentSections(config)
+	LogInfo(fmt.Sprintf("开始生成多云部署的Terraform配置，共 %d 个分段", len(sections)))
+
+	var terraformConfig strings.Builder
+	terraformConfig.WriteString(generateSectionProviders(sections))
+
+	declared := make(map[string]string)
+	for _, section := range sections {
+		aliases := sectionProviderAliases(sections, section)
+
+		// 每个分段使用自己的统一标签局部变量
+		tagsLocal := section.Alias + "_common_tags"
+		body := strings.ReplaceAll(generateResourcesConfig(section.Config), commonTagsLocal, "local."+tagsLocal)
+		body = generateCommonTagsLocals(body, tagsLocal, resolveTags(section.Config)) + scopeProviderAlias(body, aliases)
+
+		// 不同分段中相同的资源地址会导致Terraform配置无效，例如两个AWS分段使用相同的VPC名称，
+		// 冲突的资源、数据源、模块和输出加上分段别名前缀，分段内的引用同步更新
+		body, renamed := namespaceSectionLabels(body, section.Alias, declared)
+		if len(renamed) > 0 {
+			reportWarn(config, fmt.Sprintf("分段 %s 与其他分段存在重复的名称，已加上分段别名前缀: %s", section.Alias, strings.Join(renamed, ", ")))
+		}
+
+		terraformConfig.WriteString(fmt.Sprintf("\n# ---- %s (%s %s) ----\n", section.Alias, section.Config.CloudProvider, section.Config.Region))
+		terraformConfig.WriteString(body)
+		LogInfo(fmt.Sprintf("已生成分段配置: 别名=%s, 云提供商=%s, 区域=%s", section.Alias, section.Config.CloudProvider, section.Config.Region))
+	}
+	return terraformConfig.String()
+}
+
+// generateMultiProviderSections 与generateMultiProviderConfig相同，但组件配置不合并到根模块中，用于按组件渲染子模块
+// 根模块包含所有分段的provider、统一标签和网络配置；组件中的资源不绑定provider别名，
+// 由调用子模块的module块通过providers传入所在分段的provider
+func generateMultiProviderSections(config renderContext) (string, []componentSection) {
+	sections := resolveDeploymentSections(config)
+	LogInfo(fmt.Sprintf("开始生成多云部署的Terraform模块，共 %d 个分段", len(sections)))
+
+	var root strings.Builder
+	root.WriteString(generateSectionProviders(sections))
+
+	declared := make(map[string]string)
+	var components []componentSection
+	for _, section := range sections {
+		aliases := sectionProviderAliases(sections, section)
+		tagsLocal := section.Alias + "_common_tags"
+		network, sectionComponents := generateResourceSections(section.Config)
+		locals := generateCommonTagsLocals(strings.ReplaceAll(network+joinComponentBodies(sectionComponents), commonTagsLocal, "local."+tagsLocal), tagsLocal, resolveTags(section.Config))
+
+		// 子模块中的统一标签通过tags变量传入，模块目录中的组件本身是根模块中的module块，与网络配置一样使用分段的局部变量和provider别名
+		bodies := []string{scopeProviderAlias(strings.ReplaceAll(network, commonTagsLocal, "local."+tagsLocal), aliases)}
+		for _, component := range sectionComponents {
+			if component.Module {
+				bodies = append(bodies, scopeProviderAlias(strings.ReplaceAll(component.Body, commonTagsLocal, "local."+tagsLocal), aliases))
+			} else {
+				bodies = append(bodies, strings.ReplaceAll(component.Body, commonTagsLocal, "var.tags"))
+			}
+		}
+		bodies, renamed := namespaceSectionBodies(bodies, section.Alias, declared)
+		if len(renamed) > 0 {
+			reportWarn(config, fmt.Sprintf("分段 %s 与其他分段存在重复的名称，已加上分段别名前缀: %s", section.Alias, strings.Join(renamed, ", ")))
+		}
+
+		root.WriteString(fmt.Sprintf("\n# ---- %s (%s %s) ----\n", section.Alias, section.Config.CloudProvider, section.Config.Region))
+		root.WriteString(locals + bodies[0])
+		for i, component := range sectionComponents {
+			component.Body = bodies[i+1]
+			component.Section = section.Alias
+			if !component.Module {
+				component.Providers = aliases
+				component.Tags = "local." + tagsLocal
+			}
+			components = append(components, component)
+		}
+		LogInfo(fmt.Sprintf("已生成分段配置: 别名=%s, 云提供商=%s, 区域=%s, 组件=%d", section.Alias, section.Config.CloudProvider, section.Config.Region, len(sectionComponents)))
+	}
+	return root.String(), components
+}
+
+// generateSectionProviders 为每个分段生成带别名的provider配置块
+func generateSectionProviders(sections []deploymentSection) string {
+	var providers strings.Builder
+	for _, section := range sections {
+		extra := providerAssumeRole(section.Config) + providerDefaultTags(section.Config.CloudProvider, resolveTags(section.Config))
+		providers.WriteString(generateProviderBlock(section.Config.CloudProvider, section.Config.Region, section.Alias, extra))
+	}
+	return providers.String()
+}
+
+// sectionProviderAliases 返回分段中资源使用的provider别名，键为provider名称（即资源类型前缀）
+// 分段自身的云提供商使用分段别名；同一云提供商有多个分段时，其他分段中引用该云的资源使用第一个分段的别名
+func sectionProviderAliases(sections []deploymentSection, section deploymentSection) map[string]string {
+	aliases := make(map[string]string)
+	for _, other := range sections {
+		name := providerLocalName(other.Config.CloudProvider)
+		if _, exists := aliases[name]; !exists {
+			aliases[name] = other.Alias
+		}
+	}
+	aliases[providerLocalName(section.Config.CloudProvider)] = section.Alias
+	return aliases
+}
+
+// resolveDeploymentSections 校验分段并为每个分段确定唯一的provider别名
+// 未指定别名时使用 提供商_区域，例如 aws_us_east_1
+func resolveDeploymentSections(config renderContext) []deploymentSection {
+	var sections []deploymentSection
+	used := make(map[string]bool)
+	for i, section := range config.Sections {
+		if section.CloudProvider == "" {
+			reportWarn(config, fmt.Sprintf("第 %d 个分段未指定云提供商，已忽略", i+1))
+			continue
+		}
+		if len(section.Sections) > 0 {
+			reportWarn(config, fmt.Sprintf("第 %d 个分段不能再包含分段，嵌套的分段已忽略", i+1))
+			section.Sections = nil
+		}
+
+		alias := section.ProviderAlias
+		if alias != "" && !providerAliasRegexp.MatchString(alias) {
+			reportWarn(config, fmt.Sprintf("分段别名 %s 不是合法的Terraform标识符，将使用默认别名", alias))
+			alias = ""
+		}
+		if alias == "" {
+			alias = strings.NewReplacer("-", "_", ".", "_").Replace(section.CloudProvider + "_" + section.Region)
+		}
+		// 文档级别的标签应用到所有分段，分段中的同名标签优先
+		if len(config.Tags) > 0 {
+			tags := make(map[string]string, len(config.Tags)+len(section.Tags))
+			for key, value := range config.Tags {
+				tags[key] = value
+			}
+			for key, value := range section.Tags {
+				tags[key] = value
+			}
+			section.Tags = tags
+		}
+		section.DeploymentID = config.DeploymentID
+		if section.Naming.Pattern == "" {
+			section.Naming = config.Naming
+		}
+		sectionConfig := applyNamingConvention(config.withConfig(section))
+
+		base := alias
+		for n := 2; used[alias]; n++ {
+			alias = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[alias] = true
+		sectionConfig.ProviderAlias = alias
+
+		sections = append(sections, deploymentSection{Config: sectionConfig, Alias: alias})
+	}
+
+	// 每个分段都能看到文档中的所有分段，用于跨云引用
+	peers := make([]models.DeploymentConfig, len(sections))
+	for i := range sections {
+		peers[i] = sections[i].Config.DeploymentConfig
+	}
+	for i := range sections {
+		sections[i].Config.Peers = peers
+	}
+	return sections
+}
+
+// scopeProviderAlias 为顶层resource和data块添加provider参数，并删除已由分段别名覆盖的provider块
+// aliases的键为provider名称（即资源类型前缀），random、archive等没有别名的provider保持不变
+// 已经声明provider参数的块和带别名的provider块（例如组织资源使用的管理账号provider）保持不变
+func scopeProviderAlias(body string, aliases map[string]string) string {
+	var scoped strings.Builder
+	skipping := false
+
+	lines := strings.SplitAfter(body, "\n")
+	for i, line := range lines {
+		next := ""
+		if i+1 < len(lines) {
+			next = lines[i+1]
+		}
+		if skipping {
+			if strings.HasPrefix(line, "}") {
+				skipping = false
+			}
+			continue
+		}
+
+		if match := hclProviderHeaderRegexp.FindStringSubmatch(line); match != nil {
+			if _, ok := aliases[match[1]]; ok && !hclAliasLineRegexp.MatchString(strings.TrimRight(next, "\n")) {
+				skipping = !strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(line, match[0])), "}")
+				continue
+			}
+		}
+
+		match := hclBlockHeaderRegexp.FindStringSubmatch(strings.TrimRight(line, "\n"))
+		if match == nil || strings.HasPrefix(strings.TrimSpace(next), "provider ") {
+			scoped.WriteString(line)
+			continue
+		}
+
+		alias, ok := aliases[strings.SplitN(match[2], "_", 2)[0]]
+		if !ok {
+			scoped.WriteString(line)
+			continue
+		}
+		providerLine := fmt.Sprintf("  provider = %s.%s\n", strings.SplitN(match[2], "_", 2)[0], alias)
+		if match[4] != "" {
+			// 单行的空块，例如 data "tencentcloud_user_info" "peering" {}
+			scoped.WriteString(fmt.Sprintf("%s \"%s\" \"%s\" {\n%s}\n", match[1], match[2], match[3], providerLine))
+			continue
+		}
+		scoped.WriteString(line)
+		scoped.WriteString(providerLine)
+	}
+	return scoped.String()
+}
+
+// namespaceSectionLabels 为与之前分段重复的资源、数据源、模块和输出名称加上分段别名前缀，并更新分段内对它们的引用
+// declared记录已声明的名称（例如 aws_vpc.main、data.aws_ami.web、module.bucket、output.vpc_id）及其所在分段，
+// 返回处理后的配置和被重命名的名称。其他分段引用同一云提供商的资源时使用第一个分段，因此只需要更新分段内的引用
+func namespaceSectionLabels(body, alias string, declared map[string]string) (string, []string) {
+	bodies, renamed := namespaceSectionBodies([]string{body}, alias, declared)
+	return bodies[0], renamed
+}
+
+// namespaceSectionBodies 与namespaceSectionLabels相同，用于分段配置拆分为多个部分的情况，例如按组件渲染子模块，
+// 所有部分中的名称一起判断是否重复，任一部分中对被重命名资源的引用都会更新
+func namespaceSectionBodies(bodies []string, alias string, declared map[string]string) ([]string, []string) {
+	// 分段内声明的名称 -> 重命名后的标签
+	renames := make(map[string]string)
+	keys := make(map[string]bool)
+	for _, body := range bodies {
+		for _, line := range strings.SplitAfter(body, "\n") {
+			if key, label := sectionBlockKey(line); key != "" {
+				keys[key] = true
+				if _, exists := declared[key]; exists {
+					renames[key] = label
+				}
+			}
+		}
+	}
+
+	var renamed []string
+	for _, key := range sortedKeys(renames) {
+		prefix := strings.TrimSuffix(key, renames[key])
+		label := alias + "_" + renames[key]
+		for n := 2; keys[prefix+label] || declared[prefix+label] != ""; n++ {
+			label = fmt.Sprintf("%s_%s_%d", alias, renames[key], n)
+		}
+		keys[prefix+label] = true
+		renames[key] = label
+		renamed = append(renamed, fmt.Sprintf("%s -> %s", key, prefix+label))
+	}
+
+	namespaced := make([]string, len(bodies))
+	for i, body := range bodies {
+		var text strings.Builder
+		for _, line := range strings.SplitAfter(body, "\n") {
+			if key, oldLabel := sectionBlockKey(line); key != "" {
+				if label, ok := renames[key]; ok {
+					line = strings.Replace(line, fmt.Sprintf("%q {", oldLabel), fmt.Sprintf("%q {", label), 1)
+					key = strings.TrimSuffix(key, oldLabel) + label
+				}
+				declared[key] = alias
+			}
+			text.WriteString(line)
+		}
+		namespaced[i] = text.String()
+	}
+	if len(renames) == 0 {
+		return namespaced, nil
+	}
+
+	for i := range namespaced {
+		updated := rewriteReferences(namespaced[i], func(address string) (string, bool) {
+			if label, ok := renames[address]; ok {
+				return address[:strings.LastIndex(address, ".")+1] + label, true
+			}
+			return "", false
+		})
+		for key, label := range renames {
+			if strings.HasPrefix(key, "module.") {
+				moduleReference := regexp.MustCompile(`\b` + regexp.QuoteMeta(key) + `\.`)
+				updated = moduleReference.ReplaceAllString(updated, "module."+label+".")
+			}
+		}
+		namespaced[i] = updated
+	}
+	return namespaced, renamed
+}
+
+// sectionBlockKey 返回顶层块声明的名称和标签，例如 aws_vpc.main 和 main，不是块的开始行时返回空字符串
+func sectionBlockKey(line string) (string, string) {
+	trimmed := strings.TrimRight(line, "\n")
+	if match := hclModuleHeaderRegexp.FindStringSubmatch(trimmed); match != nil {
+		return "module." + match[1], match[1]
+	}
+	match := hclTopLevelHeaderRegexp.FindStringSubmatch(trimmed)
+	if match == nil {
+		return "", ""
+	}
+	switch match[1] {
+	case "resource":
+		return match[2] + "." + match[3], match[3]
+	case "data":
+		return "data." + match[2] + "." + match[3], match[3]
+	case "output":
+		return "output." + match[2], match[2]
+	}
+	return "", ""
+}
+
+// generateMultiCloudTopology 生成多云部署的拓扑图，所有分段的资源显示在同一张图中
+// 每个分段增加一个云节点，节点ID以分段别名为前缀，避免不同分段中同名资源冲突
+func generateMultiCloudTopology(config renderContext) map[string]interface{} {
+	nodes := []map[string]interface{}{}
+	edges := []map[string]interface{}{}
+
+	sections := resolveDeploymentSections(config)
+	vpcAliases := make(map[string]string) // 云提供商/VPC名称 -> 分段别名
+	for _, section := range sections {
+		cloudID := section.Alias
+		nodes = append(nodes, map[string]interface{}{
+			"id":   cloudID,
+			"type": "cloud",
+			"name": fmt.Sprintf("%s (%s)", section.Config.CloudProvider, section.Config.Region),
+			"data": map[string]interface{}{
+				"provider": section.Config.CloudProvider,
+				"region":   section.Config.Region,
+			},
+		})
+
+		topology := generateTopology(section.Config)
+		for _, node := range topology["nodes"].([]map[string]interface{}) {
+			scopedNode := make(map[string]interface{}, len(node))
+			for key, value := range node {
+				scopedNode[key] = value
+			}
+			scopedNode["id"] = fmt.Sprintf("%s/%v", section.Alias, node["id"])
+			nodes = append(nodes, scopedNode)
+		}
+		for _, edge := range topology["edges"].([]map[string]interface{}) {
+			edges = append(edges, map[string]interface{}{
+				"source": fmt.Sprintf("%s/%v", section.Alias, edge["source"]),
+				"target": fmt.Sprintf("%s/%v", section.Alias, edge["target"]),
+				"label":  edge["label"],
+			})
+		}
+
+		for _, vpc := range resolveVpcs(section.Config) {
+			key := section.Config.CloudProvider + "/" + vpc.Name
+			if _, exists := vpcAliases[key]; !exists {
+				vpcAliases[key] = section.Alias
+			}
+			edges = append(edges, map[string]interface{}{
+				"source": fmt.Sprintf("%s/%s", section.Alias, vpc.Name),
+				"target": cloudID,
+				"label":  "hosted-in",
+			})
+		}
+	}
+
+	// 跨云VPN连接两个分段中的VPC
+	for _, section := range sections {
+		propsMap := componentPropsMap(section.Config, "cross-cloud-vpn")
+		if propsMap == nil {
+			continue
+		}
+		for _, vpn := range resolveCrossCloudVpns(section.Config, propsMap) {
+			if vpn.Remote.Existing {
+				continue
+			}
+			remoteVpc := vpcNameFromAddress(vpn.Remote.VpcId)
+			edges = append(edges, map[string]interface{}{
+				"source": fmt.Sprintf("%s/%s", section.Alias, vpcNameFromAddress(vpn.Local.VpcId)),
+				"target": fmt.Sprintf("%s/%s", vpcAliases[vpn.Remote.Provider+"/"+remoteVpc], remoteVpc),
+				"label":  "vpn",
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"nodes": nodes,
+		"edges": edges,
+	}
+}
+
+// vpcNameFromAddress 从VPC ID表达式（如 aws_vpc.main.id）中取出VPC名称
+func vpcNameFromAddress(address string) string {
+	parts := strings.Split(address, ".")
+	if len(parts) < 2 {
+		return address
+	}
+	return parts[1]
+}
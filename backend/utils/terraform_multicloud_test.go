@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// testSection 返回一个AWS分段，VPC名为main，包含两个私有子网
+func testSection(region, cidrPrefix string, components ...string) models.DeploymentConfig {
+	return models.DeploymentConfig{
+		CloudProvider: "aws",
+		Region:        region,
+		AZ:            region + "a",
+		VPC:           models.VPC{Name: "main", CIDR: cidrPrefix + ".0.0/16"},
+		AllVpcs:       []models.VPC{{Name: "main", CIDR: cidrPrefix + ".0.0/16"}},
+		AllSubnets: []models.Subnet{
+			{Name: "app", CIDR: cidrPrefix + ".1.0/24", AZ: region + "a"},
+			{Name: "app2", CIDR: cidrPrefix + ".2.0/24", AZ: region + "b"},
+		},
+		Components: components,
+	}
+}
+
+// declaredAddresses 返回配置中声明的资源、数据源和输出，以及重复声明的名称
+func declaredAddresses(body string) (map[string]bool, []string) {
+	header := regexp.MustCompile(`(?m)^(resource|data) "([a-z0-9_]+)" "([a-zA-Z0-9_-]+)"|^output "([a-zA-Z0-9_-]+)"`)
+	declared := make(map[string]bool)
+	var duplicates []string
+	for _, match := range header.FindAllStringSubmatch(body, -1) {
+		address := "output." + match[4]
+		if match[1] != "" {
+			address = match[1] + "." + match[2] + "." + match[3]
+		}
+		if declared[address] {
+			duplicates = append(duplicates, address)
+		}
+		declared[address] = true
+	}
+	return declared, duplicates
+}
+
+func TestMultiProviderSectionsWithSameNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections []models.DeploymentConfig
+		want     []string // 必须出现在配置中的片段
+	}{
+		{
+			name:     "network only",
+			sections: []models.DeploymentConfig{testSection("us-east-1", "10.0"), testSection("us-west-2", "10.1")},
+			want: []string{
+				`resource "aws_vpc" "main" {`,
+				`resource "aws_vpc" "aws_us_west_2_main" {`,
+				`vpc_id                  = aws_vpc.aws_us_west_2_main.id`,
+				`cidr_block              = "10.1.1.0/24"`,
+			},
+		},
+		{
+			name:     "same components",
+			sections: []models.DeploymentConfig{testSection("us-east-1", "10.0", "rds"), testSection("us-west-2", "10.1", "rds")},
+			want: []string{
+				`resource "aws_db_instance" "database" {`,
+				`resource "aws_db_instance" "aws_us_west_2_database" {`,
+				`subnet_ids = [aws_subnet.aws_us_west_2_app.id, aws_subnet.aws_us_west_2_app2.id]`,
+				`password                  = random_password.aws_us_west_2_database.result`,
+				`output "aws_us_west_2_database_endpoint" {`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := GenerateTerraformConfig(models.DeploymentConfig{Sections: tt.sections})
+			if _, duplicates := declaredAddresses(body); len(duplicates) > 0 {
+				t.Errorf("duplicate addresses: %v", duplicates)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q", want)
+				}
+			}
+			if !strings.Contains(body, "# ---- aws_us_west_2 (aws us-west-2) ----") {
+				t.Errorf("second section was dropped")
+			}
+		})
+	}
+}
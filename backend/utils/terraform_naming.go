@@ -0,0 +1,302 @@
+package utils
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// terraformIdentifierRegexp Terraform资源名称必须以字母或下划线开头，只能包含字母、数字、下划线和连字符
+var terraformIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// identifierInvalidRegexp 匹配Terraform资源名称中不允许的字符
+var identifierInvalidRegexp = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// namingPlaceholderRegexp 匹配命名规则中的占位符
+var namingPlaceholderRegexp = regexp.MustCompile(`\{([a-z]+)\}`)
+
+// namingSeparatorRegexp 用于合并占位符为空时留下的连续分隔符
+var namingSeparatorRegexp = regexp.MustCompile(`([-_.])[-_.]+`)
+
+// displayNameRule 云提供商对VPC、子网和安全组显示名称的限制
+type displayNameRule struct {
+	MinLength   int
+	MaxLength   int
+	Allowed     func(r rune) bool // 为空时不限制字符
+	StartLetter bool              // 必须以字母或中文开头
+}
+
+// nameCharsetRule 返回允许字母、数字、中文和指定符号的字符检查函数
+func nameCharsetRule(symbols string, allowChinese bool) func(r rune) bool {
+	return func(r rune) bool {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return true
+		}
+		if allowChinese && unicode.Is(unicode.Han, r) {
+			return true
+		}
+		return strings.ContainsRune(symbols, r)
+	}
+}
+
+// nameExcludeRule 返回允许除控制字符和指定符号外所有字符的检查函数
+func nameExcludeRule(symbols string) func(r rune) bool {
+	return func(r rune) bool {
+		return !unicode.IsControl(r) && !strings.ContainsRune(symbols, r)
+	}
+}
+
+// displayNameRules 各云提供商VPC（vpc）、子网（subnet）和安全组（sg）显示名称的长度和字符限制
+var displayNameRules = map[string]map[string]displayNameRule{
+	"aws": {
+		"vpc":    {MinLength: 1, MaxLength: 255, Allowed: nameCharsetRule(" +-=._:/@", true)},
+		"subnet": {MinLength: 1, MaxLength: 255, Allowed: nameCharsetRule(" +-=._:/@", true)},
+		"sg":     {MinLength: 1, MaxLength: 255, Allowed: nameCharsetRule(" ._-:/()#,@[]+=&;!*", false)},
+	},
+	"azure": {
+		"vpc":    {MinLength: 2, MaxLength: 64, Allowed: nameCharsetRule("._-", false), StartLetter: true},
+		"subnet": {MinLength: 1, MaxLength: 80, Allowed: nameCharsetRule("._-", false), StartLetter: true},
+		"sg":     {MinLength: 1, MaxLength: 80, Allowed: nameCharsetRule("._-", false), StartLetter: true},
+	},
+	"alicloud": {
+		"vpc":    {MinLength: 1, MaxLength: 128, Allowed: nameCharsetRule("._-", true), StartLetter: true},
+		"subnet": {MinLength: 1, MaxLength: 128, Allowed: nameCharsetRule("._-", true), StartLetter: true},
+		"sg":     {MinLength: 2, MaxLength: 128, Allowed: nameCharsetRule("._-:", true), StartLetter: true},
+	},
+	"baidu": {
+		"vpc":    {MinLength: 1, MaxLength: 65, Allowed: nameCharsetRule("-_/.", true), StartLetter: true},
+		"subnet": {MinLength: 1, MaxLength: 65, Allowed: nameCharsetRule("-_/.", true), StartLetter: true},
+		"sg":     {MinLength: 1, MaxLength: 65, Allowed: nameCharsetRule("-_/.", true), StartLetter: true},
+	},
+	"huawei": {
+		"vpc":    {MinLength: 1, MaxLength: 64, Allowed: nameCharsetRule("._-", true)},
+		"subnet": {MinLength: 1, MaxLength: 64, Allowed: nameCharsetRule("._-", true)},
+		"sg":     {MinLength: 1, MaxLength: 64, Allowed: nameCharsetRule("._-", true)},
+	},
+	"tencent": {
+		"vpc":    {MinLength: 1, MaxLength: 60, Allowed: nameExcludeRule(`"\${}%`)},
+		"subnet": {MinLength: 1, MaxLength: 60, Allowed: nameExcludeRule(`"\${}%`)},
+		"sg":     {MinLength: 1, MaxLength: 60, Allowed: nameExcludeRule(`"\${}%`)},
+	},
+	"volcengine": {
+		"vpc":    {MinLength: 1, MaxLength: 128, Allowed: nameCharsetRule("._-", true), StartLetter: true},
+		"subnet": {MinLength: 1, MaxLength: 128, Allowed: nameCharsetRule("._-", true), StartLetter: true},
+		"sg":     {MinLength: 1, MaxLength: 128, Allowed: nameCharsetRule("._-", true), StartLetter: true},
+	},
+}
+
+// applyNamingConvention 为VPC和子网生成合法且唯一的Terraform资源名称，并按命名规则生成云上显示名称
+// 合法且不重复的名称保持不变，保证已有部署的资源地址稳定；原始名称保存在LogicalID中用于组件引用
+func applyNamingConvention(config renderContext) renderContext {
+	normalizeVpc := func(vpc models.VPC, identifier string) models.VPC {
+		vpc.Name = identifier
+		if vpc.Name != vpc.LogicalID {
+			reportWarn(config, fmt.Sprintf("VPC名称 %s 不是合法或唯一的Terraform资源名称，已转换为 %s", vpc.LogicalID, vpc.Name))
+		}
+		if vpc.DisplayName == "" {
+			vpc.DisplayName = renderDisplayName(config, "vpc", vpc.LogicalID, "")
+		}
+		vpc.DisplayName = checkDisplayName(config, config.CloudProvider, "vpc", vpc.DisplayName)
+		return vpc
+	}
+
+	if len(config.AllVpcs) > 0 {
+		vpcs := make([]models.VPC, len(config.AllVpcs))
+		logicalIDs := make([]string, len(config.AllVpcs))
+		for i, vpc := range config.AllVpcs {
+			if vpc.LogicalID == "" {
+				vpc.LogicalID = vpc.Name
+			}
+			vpcs[i] = vpc
+			logicalIDs[i] = vpc.LogicalID
+		}
+		for i, identifier := range assignIdentifiers(logicalIDs, "vpc") {
+			vpcs[i] = normalizeVpc(vpcs[i], identifier)
+		}
+		config.AllVpcs = vpcs
+	} else if config.VPC.Name != "" {
+		if config.VPC.LogicalID == "" {
+			config.VPC.LogicalID = config.VPC.Name
+		}
+		config.VPC = normalizeVpc(config.VPC, assignIdentifiers([]string{config.VPC.LogicalID}, "vpc")[0])
+	}
+
+	// 子网使用单独的名称空间，资源类型不同时同名不会冲突
+	normalizeSubnet := func(subnet models.Subnet, identifier string) models.Subnet {
+		subnet.Name = identifier
+		if subnet.Name != subnet.LogicalID {
+			reportWarn(config, fmt.Sprintf("子网名称 %s 不是合法或唯一的Terraform资源名称，已转换为 %s", subnet.LogicalID, subnet.Name))
+		}
+		if subnet.DisplayName == "" {
+			subnet.DisplayName = renderDisplayName(config, "subnet", subnet.LogicalID, subnet.AZ)
+		}
+		subnet.DisplayName = checkDisplayName(config, config.CloudProvider, "subnet", subnet.DisplayName)
+		return subnet
+	}
+
+	if len(config.AllSubnets) > 0 {
+		subnets := make([]models.Subnet, len(config.AllSubnets))
+		logicalIDs := make([]string, len(config.AllSubnets))
+		for i, subnet := range config.AllSubnets {
+			if subnet.LogicalID == "" {
+				subnet.LogicalID = subnet.Name
+			}
+			subnets[i] = subnet
+			logicalIDs[i] = subnet.LogicalID
+		}
+		for i, identifier := range assignIdentifiers(logicalIDs, "subnet") {
+			subnets[i] = normalizeSubnet(subnets[i], identifier)
+		}
+		config.AllSubnets = subnets
+	} else if config.Subnet.Name != "" {
+		if config.Subnet.LogicalID == "" {
+			config.Subnet.LogicalID = config.Subnet.Name
+		}
+		config.Subnet = normalizeSubnet(config.Subnet, assignIdentifiers([]string{config.Subnet.LogicalID}, "subnet")[0])
+	}
+	return config
+}
+
+// assignIdentifiers 为一组名称分配唯一的Terraform资源名称
+// 先保留所有合法且首次出现的名称，再转换其余名称，避免转换后的名称占用后面本来合法的名称
+// 例如 ["prod vpc", "prod_vpc"] 中 prod_vpc 保持不变，prod vpc 转换为 prod_vpc_2
+func assignIdentifiers(names []string, kind string) []string {
+	identifiers := make([]string, len(names))
+	used := make(map[string]bool)
+	for i, name := range names {
+		if terraformIdentifierRegexp.MatchString(name) && !used[name] {
+			identifiers[i] = name
+			used[name] = true
+		}
+	}
+	for i, name := range names {
+		if identifiers[i] == "" {
+			identifiers[i] = uniqueIdentifier(terraformIdentifier(name, kind), used)
+		}
+	}
+	return identifiers
+}
+
+// terraformIdentifier 从用户填写的名称生成合法的Terraform资源名称
+// 空格等非法字符替换为下划线；去掉了中文等非ASCII字符时追加名称的哈希，避免不同名称转换后相同
+func terraformIdentifier(name, kind string) string {
+	if terraformIdentifierRegexp.MatchString(name) {
+		return name
+	}
+
+	identifier := strings.Trim(identifierInvalidRegexp.ReplaceAllString(name, "_"), "_")
+	for _, r := range name {
+		if r > unicode.MaxASCII {
+			hash := fnv.New32a()
+			hash.Write([]byte(name))
+			identifier = strings.TrimPrefix(fmt.Sprintf("%s_%08x", identifier, hash.Sum32()), "_")
+			break
+		}
+	}
+	if identifier == "" || !terraformIdentifierRegexp.MatchString(identifier) {
+		identifier = kind + "_" + identifier
+	}
+	return strings.TrimSuffix(identifier, "_")
+}
+
+// uniqueIdentifier 名称重复时按出现顺序追加序号
+func uniqueIdentifier(identifier string, used map[string]bool) string {
+	unique := identifier
+	for n := 2; used[unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", identifier, n)
+	}
+	used[unique] = true
+	return unique
+}
+
+// renderDisplayName 按命名规则生成显示名称，未配置命名规则时使用原始名称
+func renderDisplayName(config renderContext, kind, name, az string) string {
+	pattern := config.Naming.Pattern
+	if pattern == "" {
+		return name
+	}
+
+	env := config.Naming.Environment
+	if env == "" {
+		env = config.Tags["environment"]
+	}
+	values := map[string]string{
+		"env":      env,
+		"region":   config.Region,
+		"provider": config.CloudProvider,
+		"type":     kind,
+		"name":     name,
+		"az":       az,
+	}
+	rendered := namingPlaceholderRegexp.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+		key := strings.Trim(placeholder, "{}")
+		if value, ok := values[key]; ok {
+			return value
+		}
+		reportWarn(config, fmt.Sprintf("命名规则中的占位符 %s 不受支持，已保留原文", placeholder))
+		return placeholder
+	})
+	return strings.Trim(namingSeparatorRegexp.ReplaceAllString(rendered, "$1"), "-_.")
+}
+
+// checkDisplayName 按云提供商的长度和字符限制校验显示名称，不符合时替换非法字符并截断
+func checkDisplayName(config renderContext, provider, kind, name string) string {
+	rule, ok := displayNameRules[provider][kind]
+	if !ok {
+		return name
+	}
+
+	runes := []rune(name)
+	if rule.Allowed != nil {
+		// 连续的非法字符只替换为一个连字符
+		var allowed []rune
+		for _, r := range runes {
+			if rule.Allowed(r) {
+				allowed = append(allowed, r)
+			} else if len(allowed) == 0 || allowed[len(allowed)-1] != '-' {
+				allowed = append(allowed, '-')
+			}
+		}
+		runes = []rune(strings.Trim(string(allowed), "-"))
+	}
+	if rule.StartLetter && len(runes) > 0 && !unicode.IsLetter(runes[0]) {
+		runes = append([]rune(kind+"-"), runes...)
+	}
+	if len(runes) > rule.MaxLength {
+		runes = runes[:rule.MaxLength]
+	}
+	if len(runes) < rule.MinLength {
+		reportWarn(config, fmt.Sprintf("%s 的%s名称 %s 少于%d个字符，云上创建可能失败", provider, kind, name, rule.MinLength))
+	}
+
+	checked := string(runes)
+	if checked != name {
+		reportWarn(config, fmt.Sprintf("%s 的%s名称 %s 不符合长度或字符限制（%d-%d个字符），已调整为 %s", provider, kind, name, rule.MinLength, rule.MaxLength, checked))
+	}
+	return checked
+}
+
+// vpcDisplayName 返回VPC在云上的显示名称
+func vpcDisplayName(vpc models.VPC) string {
+	if vpc.DisplayName != "" {
+		return vpc.DisplayName
+	}
+	return vpc.Name
+}
+
+// subnetDisplayName 返回子网在云上的显示名称
+func subnetDisplayName(subnet models.Subnet) string {
+	if subnet.DisplayName != "" {
+		return subnet.DisplayName
+	}
+	return subnet.Name
+}
+
+// matchesLogicalName 判断引用是否指向该资源，组件属性中既可以填写原始名称也可以填写转换后的资源名称
+func matchesLogicalName(ref, name, logicalID string) bool {
+	return ref == name || (logicalID != "" && ref == logicalID)
+}
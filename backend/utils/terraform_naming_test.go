@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+func TestApplyNamingConventionRenames(t *testing.T) {
+	tests := []struct {
+		name    string
+		vpcs    []string
+		subnets []string
+		wantVpc []string
+		wantSub []string
+	}{
+		{
+			name:    "valid names are kept",
+			vpcs:    []string{"main", "shared-vpc"},
+			subnets: []string{"app", "db"},
+			wantVpc: []string{"main", "shared-vpc"},
+			wantSub: []string{"app", "db"},
+		},
+		{
+			name:    "renamed name does not take a later valid name",
+			vpcs:    []string{"prod vpc", "prod_vpc"},
+			subnets: []string{"app 1", "app_1"},
+			wantVpc: []string{"prod_vpc_2", "prod_vpc"},
+			wantSub: []string{"app_1_2", "app_1"},
+		},
+		{
+			name:    "duplicates are numbered in order",
+			vpcs:    []string{"main", "main"},
+			subnets: []string{"1-app", "app", "app"},
+			wantVpc: []string{"main", "main_2"},
+			wantSub: []string{"subnet_1-app", "app", "app_2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := models.DeploymentConfig{CloudProvider: "aws", Region: "us-east-1"}
+			for _, name := range tt.vpcs {
+				config.AllVpcs = append(config.AllVpcs, models.VPC{Name: name, CIDR: "10.0.0.0/16"})
+			}
+			for _, name := range tt.subnets {
+				config.AllSubnets = append(config.AllSubnets, models.Subnet{Name: name, CIDR: "10.0.1.0/24"})
+			}
+			config = applyNamingConvention(testRenderContext(config)).DeploymentConfig
+
+			var gotVpc, gotSub []string
+			for i, vpc := range config.AllVpcs {
+				gotVpc = append(gotVpc, vpc.Name)
+				if vpc.LogicalID != tt.vpcs[i] {
+					t.Errorf("vpc %d LogicalID = %q, want %q", i, vpc.LogicalID, tt.vpcs[i])
+				}
+			}
+			for _, subnet := range config.AllSubnets {
+				gotSub = append(gotSub, subnet.Name)
+			}
+			if !reflect.DeepEqual(gotVpc, tt.wantVpc) {
+				t.Errorf("vpc names = %v, want %v", gotVpc, tt.wantVpc)
+			}
+			if !reflect.DeepEqual(gotSub, tt.wantSub) {
+				t.Errorf("subnet names = %v, want %v", gotSub, tt.wantSub)
+			}
+		})
+	}
+}
+
+func TestCheckDisplayNameCharset(t *testing.T) {
+	tests := []struct {
+		provider string
+		name     string
+		want     string
+	}{
+		{"aws", "prod vpc", "prod vpc"},
+		{"aws", `prod "vpc"`, "prod -vpc"},
+		{"aws", `prod\vpc`, "prod-vpc"},
+		{"aws", "prod-${var.env}", "prod-var.env"},
+		{"tencent", "生产 vpc", "生产 vpc"},
+		{"tencent", `prod"vpc`, "prod-vpc"},
+		{"tencent", "prod-%{if}", "prod-if"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider+" "+tt.name, func(t *testing.T) {
+			config := models.DeploymentConfig{CloudProvider: tt.provider}
+			if got := checkDisplayName(testRenderContext(config), tt.provider, "vpc", tt.name); got != tt.want {
+				t.Errorf("checkDisplayName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,795 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// natSubnetProviders NAT网关需要部署在子网（交换机）中的云提供商
+var natSubnetProviders = map[string]bool{
+	"aws":        true,
+	"alicloud":   true,
+	"huawei":     true,
+	"volcengine": true,
+}
+
+// natGateway 表示一个NAT网关的规划结果
+type natGateway struct {
+	Label  string
+	Zone   string         // 按可用区部署时NAT网关所服务的可用区，共享模式下为空
+	Subnet *models.Subnet // NAT网关所在的子网，不需要子网的云提供商为nil
+}
+
+// vpcRouting 表示单个VPC的路由规划结果
+type vpcRouting struct {
+	Vpc            models.VPC
+	PublicSubnets  []models.Subnet
+	PrivateSubnets []models.Subnet
+	NatGateways    []natGateway
+	SubnetNat      map[string]string // 私有子网名称 -> NAT网关标签
+	PeerRoutes     []peeringRoute    // 指向VPC对等连接的路由，写入该VPC的所有子网路由表
+	VpnRoutes      []vpnRoute        // 指向跨云VPN网关的路由，写入该VPC的所有子网路由表
+}
+
+// subnetTier 返回子网的层级，未指定时根据MapPublicIpOnLaunch判断
+func subnetTier(subnet models.Subnet) string {
+	switch strings.ToLower(strings.TrimSpace(subnet.Tier)) {
+	case "public":
+		return "public"
+	case "private":
+		return "private"
+	}
+	if subnet.MapPublicIpOnLaunch {
+		return "public"
+	}
+	return "private"
+}
+
+// generateRoutingConfig 生成互联网网关、NAT网关、EIP以及按子网划分的路由表
+// 公有子网默认路由指向互联网网关，私有子网默认路由指向NAT网关
+func generateRoutingConfig(config renderContext) string {
+	var routingConfig strings.Builder
+	for _, plan := range planVpcRouting(config) {
+		switch config.CloudProvider {
+		case "aws":
+			routingConfig.WriteString(generateAwsRouting(plan))
+		case "azure":
+			routingConfig.WriteString(generateAzureRouting(plan))
+		case "alicloud":
+			routingConfig.WriteString(generateAlicloudRouting(plan))
+		case "baidu":
+			routingConfig.WriteString(generateBaiduRouting(plan))
+		case "huawei":
+			routingConfig.WriteString(generateHuaweiRouting(plan))
+		case "tencent":
+			routingConfig.WriteString(generateTencentRouting(plan))
+		case "volcengine":
+			routingConfig.WriteString(generateVolcengineRouting(plan))
+		default:
+			reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持路由表和网关配置", config.CloudProvider))
+			return ""
+		}
+
+		LogInfo(fmt.Sprintf("已生成VPC %s 的路由配置: 公有子网=%d, 私有子网=%d, NAT网关=%d",
+			plan.Vpc.Name, len(plan.PublicSubnets), len(plan.PrivateSubnets), len(plan.NatGateways)))
+	}
+	return routingConfig.String()
+}
+
+// planVpcRouting 按VPC划分子网层级，并根据NAT网关模式规划NAT网关的数量和位置
+func planVpcRouting(config renderContext) []vpcRouting {
+	provider := config.CloudProvider
+	perAz := false
+	switch strings.ToLower(config.ComponentConfig.NatGatewayMode) {
+	case "per-az", "per_az", "az":
+		perAz = true
+	}
+	if perAz && provider == "azure" {
+		reportWarn(config, "Azure NAT网关为区域资源，按可用区部署模式不适用，每个VNet使用一个共享NAT网关")
+		perAz = false
+	}
+
+	peerRoutes := vpcPeeringRoutes(config)
+	vpnRoutes := vpcVpnRoutes(config)
+
+	var plans []vpcRouting
+	for _, vpc := range resolveVpcs(config) {
+		plan := vpcRouting{Vpc: vpc, SubnetNat: make(map[string]string), PeerRoutes: peerRoutes[vpc.Name], VpnRoutes: vpnRoutes[vpc.Name]}
+		for _, subnet := range resolveSubnets(config) {
+			if subnetVpc(config, subnet).Name != vpc.Name {
+				continue
+			}
+			if subnetTier(subnet) == "public" {
+				plan.PublicSubnets = append(plan.PublicSubnets, subnet)
+			} else {
+				plan.PrivateSubnets = append(plan.PrivateSubnets, subnet)
+			}
+		}
+
+		if len(plan.PrivateSubnets) > 0 {
+			// 共享模式下所有私有子网使用同一个NAT网关，按可用区模式下每个可用区一个
+			zones := []string{""}
+			if perAz {
+				zones = nil
+				seen := make(map[string]bool)
+				for _, subnet := range plan.PrivateSubnets {
+					zone := subnetZone(config, subnet)
+					if !seen[zone] {
+						seen[zone] = true
+						zones = append(zones, zone)
+					}
+				}
+			}
+
+			for i, zone := range zones {
+				nat := natGateway{Label: vpc.Name + "_nat", Zone: zone}
+				if perAz {
+					nat.Label = fmt.Sprintf("%s_nat_%d", vpc.Name, i+1)
+				}
+				if natSubnetProviders[provider] {
+					nat.Subnet = pickNatSubnet(config, plan, zone)
+					if nat.Subnet == nil {
+						reportError(config, fmt.Sprintf("VPC %s 没有可放置NAT网关的公有子网，私有子网将无法访问互联网", vpc.Name))
+						continue
+					}
+				}
+				plan.NatGateways = append(plan.NatGateways, nat)
+
+				for _, subnet := range plan.PrivateSubnets {
+					if !perAz || subnetZone(config, subnet) == zone {
+						plan.SubnetNat[subnet.Name] = nat.Label
+					}
+				}
+			}
+		}
+
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+// pickNatSubnet 为NAT网关选择子网：优先同可用区的公有子网，其次任意公有子网
+// AWS的NAT网关必须位于公有子网，其他云提供商没有公有子网时可放置在私有子网中
+func pickNatSubnet(config renderContext, plan vpcRouting, zone string) *models.Subnet {
+	for i, subnet := range plan.PublicSubnets {
+		if zone == "" || subnetZone(config, subnet) == zone {
+			return &plan.PublicSubnets[i]
+		}
+	}
+	if len(plan.PublicSubnets) > 0 {
+		reportWarn(config, fmt.Sprintf("可用区 %s 中没有公有子网，NAT网关将部署在其他可用区的公有子网 %s 中", zone, plan.PublicSubnets[0].Name))
+		return &plan.PublicSubnets[0]
+	}
+	if config.CloudProvider == "aws" {
+		return nil
+	}
+	for i, subnet := range plan.PrivateSubnets {
+		if zone == "" || subnetZone(config, subnet) == zone {
+			return &plan.PrivateSubnets[i]
+		}
+	}
+	return &plan.PrivateSubnets[0]
+}
+
+// generateAwsRouting 生成AWS互联网网关、NAT网关和子网路由表
+func generateAwsRouting(plan vpcRouting) string {
+	var routing strings.Builder
+	vpcRef := vpcAddress("aws", plan.Vpc.Name)
+
+	if len(plan.PublicSubnets) > 0 {
+		routing.WriteString(fmt.Sprintf(`resource "aws_internet_gateway" "%s" {
+  vpc_id = %s.id
+
+  tags = {
+    Name = "%s-igw"
+  }
+}
+
+`, plan.Vpc.Name, vpcRef, plan.Vpc.Name))
+	}
+
+	for _, nat := range plan.NatGateways {
+		routing.WriteString(fmt.Sprintf(`resource "aws_eip" "%s" {
+  domain = "vpc"
+
+  tags = {
+    Name = "%s-eip"
+  }
+}
+
+resource "aws_nat_gateway" "%s" {
+  allocation_id = aws_eip.%s.id
+  subnet_id     = %s.id
+
+  tags = {
+    Name = "%s"
+  }
+
+  depends_on = [aws_internet_gateway.%s]
+}
+
+`, nat.Label, nat.Label, nat.Label, nat.Label, subnetAddress("aws", nat.Subnet.Name), nat.Label, plan.Vpc.Name))
+	}
+
+	for _, subnet := range append(append([]models.Subnet{}, plan.PublicSubnets...), plan.PrivateSubnets...) {
+		tier := subnetTier(subnet)
+		route := ""
+		if tier == "public" {
+			route = fmt.Sprintf(`
+
+  route {
+    cidr_block = "0.0.0.0/0"
+    gateway_id = aws_internet_gateway.%s.id
+  }`, plan.Vpc.Name)
+		} else if natLabel, ok := plan.SubnetNat[subnet.Name]; ok {
+			route = fmt.Sprintf(`
+
+  route {
+    cidr_block     = "0.0.0.0/0"
+    nat_gateway_id = aws_nat_gateway.%s.id
+  }`, natLabel)
+		}
+		// 开启IPv6的公有子网通过互联网网关访问IPv6互联网，私有子网只允许出方向访问
+		if subnetIpv6Enabled(plan.Vpc, subnet) {
+			if tier == "public" {
+				route += fmt.Sprintf(`
+
+  route {
+    ipv6_cidr_block = "::/0"
+    gateway_id      = aws_internet_gateway.%s.id
+  }`, plan.Vpc.Name)
+			} else {
+				route += fmt.Sprintf(`
+
+  route {
+    ipv6_cidr_block        = "::/0"
+    egress_only_gateway_id = aws_egress_only_internet_gateway.%s.id
+  }`, plan.Vpc.Name)
+			}
+		}
+		for _, peerRoute := range plan.PeerRoutes {
+			route += fmt.Sprintf(`
+
+  route {
+    cidr_block                = "%s"
+    vpc_peering_connection_id = aws_vpc_peering_connection.%s.id
+  }`, peerRoute.DestinationCidr, peerRoute.PeeringLabel)
+		}
+
+		routing.WriteString(fmt.Sprintf(`resource "aws_route_table" "%s" {
+  vpc_id = %s.id%s
+
+  tags = {
+    Name = "%s-rt"
+    Tier = "%s"
+  }
+}
+
+resource "aws_route_table_association" "%s" {
+  subnet_id      = %s.id
+  route_table_id = aws_route_table.%s.id
+}
+
+`, subnet.Name, vpcRef, route, subnet.Name, tier, subnet.Name, subnetAddress("aws", subnet.Name), subnet.Name))
+
+		// VPN连接使用静态路由，由虚拟私有网关传播到子网路由表
+		for _, vpnRoute := range plan.VpnRoutes {
+			routing.WriteString(fmt.Sprintf(`resource "aws_vpn_gateway_route_propagation" "%s_%s" {
+  vpn_gateway_id = aws_vpn_gateway.%s.id
+  route_table_id = aws_route_table.%s.id
+}
+
+`, subnet.Name, vpnRoute.VpnLabel, vpnRoute.VpnLabel, subnet.Name))
+		}
+	}
+
+	return routing.String()
+}
+
+// generateAzureRouting 生成Azure NAT网关和子网路由表
+// Azure没有互联网网关资源，公有子网的默认路由下一跳为Internet，私有子网通过关联NAT网关出站
+func generateAzureRouting(plan vpcRouting) string {
+	var routing strings.Builder
+
+	for _, nat := range plan.NatGateways {
+		routing.WriteString(fmt.Sprintf(`resource "azurerm_public_ip" "%s" {
+  name                = "%s-pip"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+  allocation_method   = "Static"
+  sku                 = "Standard"
+
+  tags = local.common_tags
+}
+
+resource "azurerm_nat_gateway" "%s" {
+  name                = "%s"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+  sku_name            = "Standard"
+
+  tags = local.common_tags
+}
+
+resource "azurerm_nat_gateway_public_ip_association" "%s" {
+  nat_gateway_id       = azurerm_nat_gateway.%s.id
+  public_ip_address_id = azurerm_public_ip.%s.id
+}
+
+`, nat.Label, nat.Label, nat.Label, nat.Label, nat.Label, nat.Label, nat.Label))
+	}
+
+	for _, subnet := range append(append([]models.Subnet{}, plan.PublicSubnets...), plan.PrivateSubnets...) {
+		route := ""
+		if subnetTier(subnet) == "public" {
+			route = `
+
+  route {
+    name           = "internet"
+    address_prefix = "0.0.0.0/0"
+    next_hop_type  = "Internet"
+  }`
+		}
+
+		routing.WriteString(fmt.Sprintf(`resource "azurerm_route_table" "%s" {
+  name                = "%s-rt"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name%s
+
+  tags = local.common_tags
+}
+
+resource "azurerm_subnet_route_table_association" "%s" {
+  subnet_id      = %s.id
+  route_table_id = azurerm_route_table.%s.id
+}
+
+`, subnet.Name, subnet.Name, route, subnet.Name, subnetAddress("azure", subnet.Name), subnet.Name))
+
+		if natLabel, ok := plan.SubnetNat[subnet.Name]; ok {
+			routing.WriteString(fmt.Sprintf(`resource "azurerm_subnet_nat_gateway_association" "%s" {
+  subnet_id      = %s.id
+  nat_gateway_id = azurerm_nat_gateway.%s.id
+}
+
+`, subnet.Name, subnetAddress("azure", subnet.Name), natLabel))
+		}
+	}
+
+	return routing.String()
+}
+
+// generateAlicloudRouting 生成阿里云IPv4网关、NAT网关、SNAT条目和交换机路由表
+func generateAlicloudRouting(plan vpcRouting) string {
+	var routing strings.Builder
+	vpcRef := vpcAddress("alicloud", plan.Vpc.Name)
+
+	if len(plan.PublicSubnets) > 0 {
+		routing.WriteString(fmt.Sprintf(`resource "alicloud_vpc_ipv4_gateway" "%s" {
+  ipv4_gateway_name = "%s-igw"
+  vpc_id            = %s.id
+  enabled           = true
+}
+
+`, plan.Vpc.Name, plan.Vpc.Name, vpcRef))
+	}
+
+	for _, nat := range plan.NatGateways {
+		routing.WriteString(fmt.Sprintf(`resource "alicloud_eip_address" "%s" {
+  address_name         = "%s-eip"
+  bandwidth            = "10"
+  internet_charge_type = "PayByTraffic"
+  payment_type         = "PayAsYouGo"
+
+  tags = local.common_tags
+}
+
+resource "alicloud_nat_gateway" "%s" {
+  vpc_id           = %s.id
+  nat_gateway_name = "%s"
+  payment_type     = "PayAsYouGo"
+  nat_type         = "Enhanced"
+  network_type     = "internet"
+  vswitch_id       = %s.id
+
+  tags = local.common_tags
+}
+
+resource "alicloud_eip_association" "%s" {
+  allocation_id = alicloud_eip_address.%s.id
+  instance_id   = alicloud_nat_gateway.%s.id
+  instance_type = "Nat"
+}
+
+`, nat.Label, nat.Label, nat.Label, vpcRef, nat.Label, subnetAddress("alicloud", nat.Subnet.Name), nat.Label, nat.Label, nat.Label))
+	}
+
+	for _, subnet := range append(append([]models.Subnet{}, plan.PublicSubnets...), plan.PrivateSubnets...) {
+		routing.WriteString(fmt.Sprintf(`resource "alicloud_route_table" "%s" {
+  vpc_id           = %s.id
+  route_table_name = "%s-rt"
+  associate_type   = "VSwitch"
+
+  tags = local.common_tags
+}
+
+resource "alicloud_route_table_attachment" "%s" {
+  vswitch_id     = %s.id
+  route_table_id = alicloud_route_table.%s.id
+}
+
+`, subnet.Name, vpcRef, subnet.Name, subnet.Name, subnetAddress("alicloud", subnet.Name), subnet.Name))
+
+		if subnetTier(subnet) == "public" {
+			routing.WriteString(fmt.Sprintf(`resource "alicloud_route_entry" "%s" {
+  route_table_id        = alicloud_route_table.%s.id
+  destination_cidrblock = "0.0.0.0/0"
+  nexthop_type          = "Ipv4Gateway"
+  nexthop_id            = alicloud_vpc_ipv4_gateway.%s.id
+}
+
+`, subnet.Name, subnet.Name, plan.Vpc.Name))
+		} else if natLabel, ok := plan.SubnetNat[subnet.Name]; ok {
+			routing.WriteString(fmt.Sprintf(`resource "alicloud_route_entry" "%s" {
+  route_table_id        = alicloud_route_table.%s.id
+  destination_cidrblock = "0.0.0.0/0"
+  nexthop_type          = "NatGateway"
+  nexthop_id            = alicloud_nat_gateway.%s.id
+}
+
+resource "alicloud_snat_entry" "%s" {
+  snat_table_id     = alicloud_nat_gateway.%s.snat_table_ids
+  source_vswitch_id = %s.id
+  snat_ip           = alicloud_eip_address.%s.ip_address
+
+  depends_on = [alicloud_eip_association.%s]
+}
+
+`, subnet.Name, subnet.Name, natLabel, subnet.Name, natLabel, subnetAddress("alicloud", subnet.Name), natLabel, natLabel))
+		}
+
+		for _, vpnRoute := range plan.VpnRoutes {
+			routing.WriteString(fmt.Sprintf(`resource "alicloud_route_entry" "%s_%s" {
+  route_table_id        = alicloud_route_table.%s.id
+  destination_cidrblock = "%s"
+  nexthop_type          = "VpnGateway"
+  nexthop_id            = alicloud_vpn_gateway.%s.id
+}
+
+`, subnet.Name, vpnRoute.VpnLabel, subnet.Name, vpnRoute.DestinationCidr, vpnRoute.VpnLabel))
+		}
+
+		if subnetIpv6Enabled(plan.Vpc, subnet) {
+			routing.WriteString(fmt.Sprintf(`resource "alicloud_route_entry" "%s_ipv6" {
+  route_table_id        = alicloud_route_table.%s.id
+  destination_cidrblock = "::/0"
+  nexthop_type          = "Ipv6Gateway"
+  nexthop_id            = alicloud_vpc_ipv6_gateway.%s.id
+}
+
+`, subnet.Name, subnet.Name, plan.Vpc.Name))
+		}
+	}
+
+	return routing.String()
+}
+
+// generateBaiduRouting 生成百度云NAT网关和路由规则
+// 百度云每个VPC只有一张路由表，私有子网通过按源地址匹配的路由规则指向NAT网关
+func generateBaiduRouting(plan vpcRouting) string {
+	var routing strings.Builder
+	vpcRef := vpcAddress("baidu", plan.Vpc.Name)
+
+	for _, nat := range plan.NatGateways {
+		routing.WriteString(fmt.Sprintf(`resource "baiducloud_eip" "%s" {
+  name              = "%s-eip"
+  bandwidth_in_mbps = 10
+  payment_timing    = "Postpaid"
+  billing_method    = "ByTraffic"
+}
+
+resource "baiducloud_nat_gateway" "%s" {
+  name   = "%s"
+  vpc_id = %s.id
+  spec   = "small"
+
+  billing = {
+    payment_timing = "Postpaid"
+  }
+}
+
+resource "baiducloud_eip_association" "%s" {
+  eip           = baiducloud_eip.%s.eip
+  instance_type = "NAT"
+  instance_id   = baiducloud_nat_gateway.%s.id
+}
+
+`, nat.Label, nat.Label, nat.Label, nat.Label, vpcRef, nat.Label, nat.Label, nat.Label))
+	}
+
+	for _, subnet := range plan.PrivateSubnets {
+		natLabel, ok := plan.SubnetNat[subnet.Name]
+		if !ok {
+			continue
+		}
+		routing.WriteString(fmt.Sprintf(`resource "baiducloud_route_rule" "%s" {
+  route_table_id      = %s.route_table_id
+  source_address      = "%s"
+  destination_address = "0.0.0.0/0"
+  next_hop_id         = baiducloud_nat_gateway.%s.id
+  next_hop_type       = "nat"
+  description         = "Default route for private subnet %s"
+}
+
+`, subnet.Name, vpcRef, subnet.CIDR, natLabel, subnet.Name))
+	}
+
+	return routing.String()
+}
+
+// generateHuaweiRouting 生成华为云NAT网关、SNAT规则和子网路由表
+// 华为云没有互联网网关资源，公有子网中的实例通过绑定EIP访问互联网
+func generateHuaweiRouting(plan vpcRouting) string {
+	var routing strings.Builder
+	vpcRef := vpcAddress("huawei", plan.Vpc.Name)
+
+	for _, nat := range plan.NatGateways {
+		routing.WriteString(fmt.Sprintf(`resource "huaweicloud_vpc_eip" "%s" {
+  publicip {
+    type = "5_bgp"
+  }
+
+  bandwidth {
+    name        = "%s-bandwidth"
+    size        = 10
+    share_type  = "PER"
+    charge_mode = "traffic"
+  }
+
+  tags = local.common_tags
+}
+
+resource "huaweicloud_nat_gateway" "%s" {
+  name      = "%s"
+  spec      = "1"
+  vpc_id    = %s.id
+  subnet_id = %s.id
+
+  tags = local.common_tags
+}
+
+`, nat.Label, nat.Label, nat.Label, nat.Label, vpcRef, subnetAddress("huawei", nat.Subnet.Name)))
+	}
+
+	for _, subnet := range append(append([]models.Subnet{}, plan.PublicSubnets...), plan.PrivateSubnets...) {
+		route := ""
+		if natLabel, ok := plan.SubnetNat[subnet.Name]; ok {
+			route = fmt.Sprintf(`
+
+  route {
+    destination = "0.0.0.0/0"
+    type        = "nat"
+    nexthop     = huaweicloud_nat_gateway.%s.id
+  }`, natLabel)
+
+			routing.WriteString(fmt.Sprintf(`resource "huaweicloud_nat_snat_rule" "%s" {
+  nat_gateway_id = huaweicloud_nat_gateway.%s.id
+  floating_ip_id = huaweicloud_vpc_eip.%s.id
+  subnet_id      = %s.id
+}
+
+`, subnet.Name, natLabel, natLabel, subnetAddress("huawei", subnet.Name)))
+		}
+		for _, peerRoute := range plan.PeerRoutes {
+			route += fmt.Sprintf(`
+
+  route {
+    destination = "%s"
+    type        = "peering"
+    nexthop     = huaweicloud_vpc_peering_connection.%s.id
+  }`, peerRoute.DestinationCidr, peerRoute.PeeringLabel)
+		}
+		for _, vpnRoute := range plan.VpnRoutes {
+			route += fmt.Sprintf(`
+
+  route {
+    destination = "%s"
+    type        = "vpn"
+    nexthop     = huaweicloud_vpn_gateway.%s.id
+  }`, vpnRoute.DestinationCidr, vpnRoute.VpnLabel)
+		}
+
+		routing.WriteString(fmt.Sprintf(`resource "huaweicloud_vpc_route_table" "%s" {
+  name    = "%s-rt"
+  vpc_id  = %s.id
+  subnets = [%s.id]%s
+}
+
+`, subnet.Name, subnet.Name, vpcRef, subnetAddress("huawei", subnet.Name), route))
+	}
+
+	return routing.String()
+}
+
+// generateTencentRouting 生成腾讯云NAT网关和子网路由表
+// 腾讯云没有互联网网关资源，公有子网中的实例通过绑定EIP访问互联网
+func generateTencentRouting(plan vpcRouting) string {
+	var routing strings.Builder
+	vpcRef := vpcAddress("tencent", plan.Vpc.Name)
+
+	for _, nat := range plan.NatGateways {
+		zoneLine := ""
+		if nat.Zone != "" {
+			zoneLine = fmt.Sprintf("\n  zone             = \"%s\"", nat.Zone)
+		}
+		routing.WriteString(fmt.Sprintf(`resource "tencentcloud_eip" "%s" {
+  name = "%s-eip"
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_nat_gateway" "%s" {
+  name             = "%s"
+  vpc_id           = %s.id
+  bandwidth        = 100
+  max_concurrent   = 1000000
+  assigned_eip_set = [tencentcloud_eip.%s.public_ip]%s
+
+  tags = local.common_tags
+}
+
+`, nat.Label, nat.Label, nat.Label, nat.Label, vpcRef, nat.Label, zoneLine))
+	}
+
+	for _, subnet := range append(append([]models.Subnet{}, plan.PublicSubnets...), plan.PrivateSubnets...) {
+		routing.WriteString(fmt.Sprintf(`resource "tencentcloud_route_table" "%s" {
+  vpc_id = %s.id
+  name   = "%s-rt"
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_route_table_association" "%s" {
+  subnet_id      = %s.id
+  route_table_id = tencentcloud_route_table.%s.id
+}
+
+`, subnet.Name, vpcRef, subnet.Name, subnet.Name, subnetAddress("tencent", subnet.Name), subnet.Name))
+
+		if natLabel, ok := plan.SubnetNat[subnet.Name]; ok {
+			routing.WriteString(fmt.Sprintf(`resource "tencentcloud_route_table_entry" "%s" {
+  route_table_id         = tencentcloud_route_table.%s.id
+  destination_cidr_block = "0.0.0.0/0"
+  next_type              = "NAT"
+  next_hub               = tencentcloud_nat_gateway.%s.id
+  description            = "Default route for private subnet %s"
+}
+
+`, subnet.Name, subnet.Name, natLabel, subnet.Name))
+		}
+
+		for _, peerRoute := range plan.PeerRoutes {
+			routing.WriteString(fmt.Sprintf(`resource "tencentcloud_route_table_entry" "%s_%s" {
+  route_table_id         = tencentcloud_route_table.%s.id
+  destination_cidr_block = "%s"
+  next_type              = "PEERCONNECTION"
+  next_hub               = tencentcloud_vpc_peer_connect_manager.%s.id
+  description            = "Peering route for subnet %s"
+
+  depends_on = [tencentcloud_vpc_peer_connect_accept_operation.%s]
+}
+
+`, subnet.Name, peerRoute.PeeringLabel, subnet.Name, peerRoute.DestinationCidr, peerRoute.PeeringLabel, subnet.Name, peerRoute.PeeringLabel))
+		}
+
+		for _, vpnRoute := range plan.VpnRoutes {
+			routing.WriteString(fmt.Sprintf(`resource "tencentcloud_route_table_entry" "%s_%s" {
+  route_table_id         = tencentcloud_route_table.%s.id
+  destination_cidr_block = "%s"
+  next_type              = "VPN"
+  next_hub               = tencentcloud_vpn_gateway.%s.id
+  description            = "VPN route for subnet %s"
+}
+
+`, subnet.Name, vpnRoute.VpnLabel, subnet.Name, vpnRoute.DestinationCidr, vpnRoute.VpnLabel, subnet.Name))
+		}
+	}
+
+	return routing.String()
+}
+
+// generateVolcengineRouting 生成火山引擎NAT网关、SNAT规则和子网路由表
+// 火山引擎没有互联网网关资源，公有子网中的实例通过绑定EIP访问互联网
+func generateVolcengineRouting(plan vpcRouting) string {
+	var routing strings.Builder
+	vpcRef := vpcAddress("volcengine", plan.Vpc.Name)
+
+	for _, nat := range plan.NatGateways {
+		routing.WriteString(fmt.Sprintf(`resource "volcengine_eip_address" "%s" {
+  name         = "%s-eip"
+  billing_type = "PostPaidByTraffic"
+  bandwidth    = 10
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+
+resource "volcengine_nat_gateway" "%s" {
+  nat_gateway_name = "%s"
+  vpc_id           = %s.id
+  subnet_id        = %s.id
+  spec             = "Small"
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+
+resource "volcengine_eip_associate" "%s" {
+  allocation_id = volcengine_eip_address.%s.id
+  instance_id   = volcengine_nat_gateway.%s.id
+  instance_type = "Nat"
+}
+
+`, nat.Label, nat.Label, nat.Label, nat.Label, vpcRef, subnetAddress("volcengine", nat.Subnet.Name), nat.Label, nat.Label, nat.Label))
+	}
+
+	for _, subnet := range append(append([]models.Subnet{}, plan.PublicSubnets...), plan.PrivateSubnets...) {
+		routing.WriteString(fmt.Sprintf(`resource "volcengine_route_table" "%s" {
+  vpc_id           = %s.id
+  route_table_name = "%s-rt"
+}
+
+resource "volcengine_route_table_associate" "%s" {
+  route_table_id = volcengine_route_table.%s.id
+  subnet_id      = %s.id
+}
+
+`, subnet.Name, vpcRef, subnet.Name, subnet.Name, subnet.Name, subnetAddress("volcengine", subnet.Name)))
+
+		if natLabel, ok := plan.SubnetNat[subnet.Name]; ok {
+			routing.WriteString(fmt.Sprintf(`resource "volcengine_route_entry" "%s" {
+  route_table_id         = volcengine_route_table.%s.id
+  destination_cidr_block = "0.0.0.0/0"
+  next_hop_type          = "NatGW"
+  next_hop_id            = volcengine_nat_gateway.%s.id
+  route_entry_name       = "%s-default"
+}
+
+resource "volcengine_snat_entry" "%s" {
+  nat_gateway_id = volcengine_nat_gateway.%s.id
+  subnet_id      = %s.id
+  eip_id         = volcengine_eip_address.%s.id
+
+  depends_on = [volcengine_eip_associate.%s]
+}
+
+`, subnet.Name, subnet.Name, natLabel, subnet.Name, subnet.Name, natLabel, subnetAddress("volcengine", subnet.Name), natLabel, natLabel))
+		}
+
+		if subnetIpv6Enabled(plan.Vpc, subnet) {
+			routing.WriteString(fmt.Sprintf(`resource "volcengine_route_entry" "%s_ipv6" {
+  route_table_id         = volcengine_route_table.%s.id
+  destination_cidr_block = "::/0"
+  next_hop_type          = "IPv6GW"
+  next_hop_id            = volcengine_vpc_ipv6_gateway.%s.id
+  route_entry_name       = "%s-ipv6-default"
+}
+
+`, subnet.Name, subnet.Name, plan.Vpc.Name, subnet.Name))
+		}
+	}
+
+	return routing.String()
+}
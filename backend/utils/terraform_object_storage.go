@@ -0,0 +1,858 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// storageClassMapping 将通用存储类型映射为各云提供商的存储类型
+var storageClassMapping = map[string]map[string]string{
+	"aws":        {"Standard": "STANDARD", "IA": "STANDARD_IA", "Archive": "GLACIER"},
+	"azure":      {"Standard": "Hot", "IA": "Cool", "Archive": "Archive"},
+	"alicloud":   {"Standard": "Standard", "IA": "IA", "Archive": "Archive"},
+	"baidu":      {"Standard": "STANDARD", "IA": "STANDARD_IA", "Archive": "ARCHIVE"},
+	"huawei":     {"Standard": "STANDARD", "IA": "WARM", "Archive": "COLD"},
+	"tencent":    {"Standard": "STANDARD", "IA": "STANDARD_IA", "Archive": "ARCHIVE"},
+	"volcengine": {"Standard": "STANDARD", "IA": "IA", "Archive": "ARCHIVE_FR"},
+}
+
+// minTransitionDays 生命周期规则转换为低频存储的最少天数，对象需要在标准存储中保存足够天数后才能转换
+var minTransitionDays = map[string]int{
+	"aws":     30,
+	"tencent": 30,
+}
+
+// azureStorageAccountNameRegexp 用于去除Azure存储账户名称中的非法字符
+var azureStorageAccountNameRegexp = regexp.MustCompile(`[^a-z0-9]`)
+
+// bucketNameRule 存储桶名称的字符和长度要求
+type bucketNameRule struct {
+	Pattern  *regexp.Regexp
+	Min, Max int
+}
+
+// bucketNameRules 各云提供商存储桶名称的命名规则，Azure检查的是转为小写后的容器名称，
+// 腾讯云的名称还会加上 -APPID 后缀，自定义部分不能超过40个字符
+var bucketNameRules = map[string]bucketNameRule{
+	"aws":        {Pattern: regexp.MustCompile(`^[a-z0-9]([a-z0-9-]|\.[a-z0-9])*[a-z0-9]$`), Min: 3, Max: 63},
+	"azure":      {Pattern: regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`), Min: 3, Max: 63},
+	"alicloud":   {Pattern: regexp.MustCompile(`^[a-z0-9][a-z0-9-]*[a-z0-9]$`), Min: 3, Max: 63},
+	"baidu":      {Pattern: regexp.MustCompile(`^[a-z0-9][a-z0-9-]*[a-z0-9]$`), Min: 4, Max: 63},
+	"huawei":     {Pattern: regexp.MustCompile(`^[a-z0-9]([a-z0-9-]|\.[a-z0-9])*[a-z0-9]$`), Min: 3, Max: 63},
+	"tencent":    {Pattern: regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`), Min: 1, Max: 40},
+	"volcengine": {Pattern: regexp.MustCompile(`^[a-z0-9][a-z0-9-]*[a-z0-9]$`), Min: 3, Max: 63},
+}
+
+// ipv4AddressRegexp 匹配IPv4地址格式，S3和OBS的存储桶名称不能是IP地址
+var ipv4AddressRegexp = regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)
+
+// generateObjectStorageConfig 生成对象存储组件的Terraform配置
+func generateObjectStorageConfig(config renderContext, propsMap map[string]interface{}) string {
+	var storageConfig strings.Builder
+
+	buckets := resolveStorageBuckets(config, propsMap)
+	if len(buckets) == 0 {
+		reportWarn(config, "对象存储组件未配置存储桶名称，跳过生成")
+		return ""
+	}
+
+	// 腾讯云COS的存储桶名称必须以APPID结尾
+	if config.CloudProvider == "tencent" {
+		storageConfig.WriteString(`data "tencentcloud_user_info" "current" {}
+`)
+	}
+
+	for i, bucket := range buckets {
+		// 旧版s3组件使用storage和bucket_N作为资源名称，加上前缀避免两个组件同时使用时冲突
+		resourceName := "object_storage"
+		if len(buckets) > 1 {
+			resourceName = fmt.Sprintf("object_storage_%d", i)
+		}
+
+		switch config.CloudProvider {
+		case "aws":
+			storageConfig.WriteString(generateAwsBucket(resourceName, bucket))
+		case "azure":
+			storageConfig.WriteString(generateAzureBucket(config, resourceName, bucket))
+		case "alicloud":
+			storageConfig.WriteString(generateAlicloudBucket(resourceName, bucket))
+		case "baidu":
+			storageConfig.WriteString(generateBaiduBucket(config, resourceName, bucket))
+		case "huawei":
+			storageConfig.WriteString(generateHuaweiBucket(resourceName, bucket))
+		case "tencent":
+			storageConfig.WriteString(generateTencentBucket(resourceName, bucket))
+		case "volcengine":
+			storageConfig.WriteString(generateVolcengineBucket(config, resourceName, bucket))
+		default:
+			reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持对象存储组件", config.CloudProvider))
+			return storageConfig.String()
+		}
+
+		LogInfo(fmt.Sprintf("已生成对象存储配置 %d: 名称=%s, 存储类型=%s, 策略=%s", i+1, bucket.BucketName, bucket.StorageClass, bucket.PolicyType))
+	}
+
+	return storageConfig.String()
+}
+
+// resolveStorageBuckets 汇总存储桶配置
+// 优先使用存储桶列表，其次使用ComponentConfig中的单个存储桶，最后使用组件属性
+func resolveStorageBuckets(config renderContext, propsMap map[string]interface{}) []models.StorageBucket {
+	var buckets []models.StorageBucket
+	if !decodeListProp(propsMap, "storageBuckets", &buckets) {
+		buckets = config.ComponentConfig.StorageBuckets
+	}
+
+	if len(buckets) == 0 {
+		componentConfig := config.ComponentConfig
+		bucket := models.StorageBucket{
+			BucketName:           componentConfig.BucketName,
+			StorageClass:         componentConfig.StorageClass,
+			PolicyType:           componentConfig.BucketPolicyType,
+			CustomPolicy:         componentConfig.CustomBucketPolicy,
+			EnableVersioning:     componentConfig.EnableVersioning,
+			Encryption:           componentConfig.BucketEncryption,
+			EnableLifecycleRules: componentConfig.EnableLifecycleRules,
+			LifecycleRule:        componentConfig.LifecycleRule,
+		}
+		if bucket.BucketName == "" {
+			bucket.BucketName = getStringProp(propsMap, "bucket_name", "")
+		}
+		if bucket.StorageClass == "" {
+			bucket.StorageClass = getStringProp(propsMap, "storage_class", "")
+		}
+		if bucket.PolicyType == "" {
+			bucket.PolicyType = getStringProp(propsMap, "policy_type", "")
+		}
+		if bucket.Encryption == "" {
+			bucket.Encryption = getStringProp(propsMap, "encryption", "")
+			if bucket.Encryption == "" && getBoolProp(propsMap, "encryption", false) {
+				bucket.Encryption = "AES256"
+			}
+		}
+		bucket.EnableVersioning = bucket.EnableVersioning || getBoolProp(propsMap, "versioning", false)
+		if bucket.BucketName != "" {
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	// 统一规范化各字段，便于后续按云提供商映射
+	var result []models.StorageBucket
+	for i, bucket := range buckets {
+		if bucket.BucketName == "" {
+			bucket.BucketName = fmt.Sprintf("my-bucket-%d", i+1)
+		}
+		if err := checkBucketName(config.CloudProvider, bucket.BucketName); err != nil {
+			reportError(config, fmt.Sprintf("存储桶名称无效，跳过生成: %v", err))
+			continue
+		}
+		bucket.StorageClass = normalizeStorageClass(bucket.StorageClass)
+		bucket.PolicyType = normalizeBucketPolicyType(bucket.PolicyType)
+		bucket.Encryption = normalizeBucketEncryption(bucket.Encryption)
+		if bucket.PolicyType == "custom" && strings.TrimSpace(bucket.CustomPolicy) == "" {
+			reportWarn(config, fmt.Sprintf("存储桶 %s 选择了自定义策略但未提供策略内容，按私有存储桶处理", bucket.BucketName))
+			bucket.PolicyType = "private"
+		}
+		if minDays := minTransitionDays[config.CloudProvider]; minDays > 0 {
+			if bucket.StorageClass == "IA" {
+				reportWarn(config, fmt.Sprintf("%s 的低频存储最少保存%d天后才能转换，存储桶 %s 的对象上传%d天后转为低频存储", config.CloudProvider, minDays, bucket.BucketName, minDays))
+			}
+			if bucket.StorageClass == "Standard" && bucket.LifecycleRule.TransitionDays > 0 && bucket.LifecycleRule.TransitionDays < minDays {
+				reportWarn(config, fmt.Sprintf("存储桶 %s 的生命周期规则转换为低频存储的天数不能少于%d天，已从%d天调整为%d天", bucket.BucketName, minDays, bucket.LifecycleRule.TransitionDays, minDays))
+				bucket.LifecycleRule.TransitionDays = minDays
+			}
+		}
+		if bucket.LifecycleRule.Name == "" {
+			bucket.LifecycleRule.Name = "lifecycle-rule"
+		}
+		if strings.EqualFold(bucket.LifecycleRule.Status, "disabled") {
+			bucket.LifecycleRule.Status = "Disabled"
+		} else {
+			bucket.LifecycleRule.Status = "Enabled"
+		}
+		result = append(result, bucket)
+	}
+	return result
+}
+
+// checkBucketName 按云提供商的命名规则检查存储桶名称
+func checkBucketName(provider, name string) error {
+	rule, ok := bucketNameRules[provider]
+	if !ok {
+		return nil
+	}
+	if provider == "azure" {
+		name = strings.ToLower(name)
+	}
+	if len(name) < rule.Min || len(name) > rule.Max {
+		return fmt.Errorf("存储桶名称 %q 的长度必须为%d-%d个字符", name, rule.Min, rule.Max)
+	}
+	if !rule.Pattern.MatchString(name) {
+		return fmt.Errorf("存储桶名称 %q 不符合 %s 的存储桶命名规则：只能包含小写字母、数字和连字符（AWS和华为云还可以包含点号），并以字母或数字开头和结尾", name, provider)
+	}
+	if ipv4AddressRegexp.MatchString(name) {
+		return fmt.Errorf("存储桶名称 %q 不能是IP地址", name)
+	}
+	return nil
+}
+
+// normalizeStorageClass 将用户输入的存储类型规范化为 Standard、IA 或 Archive
+func normalizeStorageClass(storageClass string) string {
+	switch strings.ToUpper(strings.TrimSpace(storageClass)) {
+	case "IA", "STANDARD_IA", "INFREQUENT", "WARM", "COOL":
+		return "IA"
+	case "ARCHIVE", "COLD", "COLDARCHIVE", "GLACIER", "ARCHIVE_FR", "DEEP_ARCHIVE":
+		return "Archive"
+	default:
+		return "Standard"
+	}
+}
+
+// normalizeBucketPolicyType 将存储桶策略类型规范化为 private、public-read 或 custom
+func normalizeBucketPolicyType(policyType string) string {
+	switch strings.ToLower(strings.TrimSpace(policyType)) {
+	case "public-read", "public":
+		return "public-read"
+	case "custom":
+		return "custom"
+	default:
+		return "private"
+	}
+}
+
+// normalizeBucketEncryption 将服务端加密方式规范化为空、AES256 或 KMS
+func normalizeBucketEncryption(encryption string) string {
+	switch strings.ToUpper(strings.TrimSpace(encryption)) {
+	case "AES256", "SSE-S3", "SSE-OSS", "SSE-OBS", "SSE-COS", "TRUE":
+		return "AES256"
+	case "KMS", "AWS:KMS", "SSE-KMS":
+		return "KMS"
+	default:
+		return ""
+	}
+}
+
+// defaultClassTransitionDays 返回通过生命周期规则实现默认存储类型时的转换天数，归档存储可以立即转换
+func defaultClassTransitionDays(provider, storageClass string) int {
+	if storageClass == "IA" {
+		return minTransitionDays[provider]
+	}
+	return 0
+}
+
+// bucketPolicyHeredoc 转义自定义存储桶策略中的Terraform插值语法，例如 ${aws:username}，用于写入heredoc
+func bucketPolicyHeredoc(policy string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(strings.TrimSpace(policy))
+}
+
+// bucketTransitionClass 返回生命周期规则转换的目标存储类型（比当前存储类型更冷一级）
+func bucketTransitionClass(provider, storageClass string) string {
+	if storageClass == "Standard" {
+		return storageClassMapping[provider]["IA"]
+	}
+	return storageClassMapping[provider]["Archive"]
+}
+
+// generateAwsBucket 生成AWS S3存储桶配置
+func generateAwsBucket(resourceName string, bucket models.StorageBucket) string {
+	var bucketConfig strings.Builder
+
+	bucketConfig.WriteString(fmt.Sprintf(`resource "aws_s3_bucket" "%s" {
+  bucket = %s
+
+  tags = {
+    Name = %s
+  }
+}
+`, resourceName, hclString(bucket.BucketName), hclString(bucket.BucketName)))
+
+	// 根据策略类型添加访问控制
+	switch bucket.PolicyType {
+	case "public-read":
+		bucketConfig.WriteString(fmt.Sprintf(`resource "aws_s3_bucket_ownership_controls" "%s_ownership" {
+  bucket = aws_s3_bucket.%s.id
+  rule {
+    object_ownership = "BucketOwnerPreferred"
+  }
+}
+
+resource "aws_s3_bucket_public_access_block" "%s_public_access" {
+  bucket                  = aws_s3_bucket.%s.id
+  block_public_acls       = false
+  block_public_policy     = false
+  ignore_public_acls      = false
+  restrict_public_buckets = false
+}
+
+resource "aws_s3_bucket_acl" "%s_acl" {
+  bucket = aws_s3_bucket.%s.id
+  acl    = "public-read"
+
+  depends_on = [
+    aws_s3_bucket_ownership_controls.%s_ownership,
+    aws_s3_bucket_public_access_block.%s_public_access,
+  ]
+}
+`, resourceName, resourceName, resourceName, resourceName, resourceName, resourceName, resourceName, resourceName))
+	case "custom":
+		bucketConfig.WriteString(fmt.Sprintf(`resource "aws_s3_bucket_policy" "%s_policy" {
+  bucket = aws_s3_bucket.%s.id
+  policy = <<POLICY
+%s
+POLICY
+}
+`, resourceName, resourceName, bucketPolicyHeredoc(bucket.CustomPolicy)))
+	default:
+		bucketConfig.WriteString(fmt.Sprintf(`resource "aws_s3_bucket_public_access_block" "%s_public_access" {
+  bucket                  = aws_s3_bucket.%s.id
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+`, resourceName, resourceName))
+	}
+
+	if bucket.EnableVersioning {
+		bucketConfig.WriteString(fmt.Sprintf(`resource "aws_s3_bucket_versioning" "%s_versioning" {
+  bucket = aws_s3_bucket.%s.id
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+`, resourceName, resourceName))
+	}
+
+	if bucket.Encryption != "" {
+		sseAlgorithm := "AES256"
+		kmsKey := ""
+		if bucket.Encryption == "KMS" {
+			sseAlgorithm = "aws:kms"
+			if bucket.KmsKeyId != "" {
+				kmsKey = fmt.Sprintf("\n      kms_master_key_id = %s", hclString(bucket.KmsKeyId))
+			}
+		}
+		bucketConfig.WriteString(fmt.Sprintf(`resource "aws_s3_bucket_server_side_encryption_configuration" "%s_sse" {
+  bucket = aws_s3_bucket.%s.id
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm = "%s"%s
+    }
+  }
+}
+`, resourceName, resourceName, sseAlgorithm, kmsKey))
+	}
+
+	// S3存储桶没有默认存储类型，非标准存储类型通过生命周期规则转换实现，低频存储需要先在标准存储中保存30天
+	rule := bucket.LifecycleRule
+	if bucket.EnableLifecycleRules || bucket.StorageClass != "Standard" {
+		var ruleBody strings.Builder
+		if bucket.StorageClass != "Standard" {
+			ruleBody.WriteString(fmt.Sprintf(`
+  rule {
+    id     = "default-storage-class"
+    status = "Enabled"
+    filter {}
+    transition {
+      days          = %d
+      storage_class = "%s"
+    }
+  }`, defaultClassTransitionDays("aws", bucket.StorageClass), storageClassMapping["aws"][bucket.StorageClass]))
+		}
+		if bucket.EnableLifecycleRules {
+			ruleBody.WriteString(fmt.Sprintf(`
+  rule {
+    id     = %s
+    status = "%s"
+    filter {}`, hclString(rule.Name), rule.Status))
+			if rule.TransitionDays > 0 {
+				ruleBody.WriteString(fmt.Sprintf(`
+    transition {
+      days          = %d
+      storage_class = "%s"
+    }`, rule.TransitionDays, bucketTransitionClass("aws", bucket.StorageClass)))
+			}
+			if rule.ExpirationDays > 0 {
+				ruleBody.WriteString(fmt.Sprintf(`
+    expiration {
+      days = %d
+    }`, rule.ExpirationDays))
+			}
+			ruleBody.WriteString(`
+  }`)
+		}
+		bucketConfig.WriteString(fmt.Sprintf(`resource "aws_s3_bucket_lifecycle_configuration" "%s_lifecycle" {
+  bucket = aws_s3_bucket.%s.id%s
+}
+`, resourceName, resourceName, ruleBody.String()))
+	}
+
+	return bucketConfig.String()
+}
+
+// generateAzureBucket 生成Azure存储账户和Blob容器配置
+func generateAzureBucket(config renderContext, resourceName string, bucket models.StorageBucket) string {
+	var bucketConfig strings.Builder
+
+	// 存储账户名称只能包含小写字母和数字，长度为3-24
+	accountName := azureStorageAccountNameRegexp.ReplaceAllString(strings.ToLower(bucket.BucketName), "")
+	if len(accountName) < 3 {
+		accountName = accountName + "storage"
+	}
+	if len(accountName) > 24 {
+		accountName = accountName[:24]
+	}
+
+	// Azure存储账户层级只支持Hot和Cool，归档通过生命周期规则实现
+	accessTier := "Hot"
+	if bucket.StorageClass != "Standard" {
+		accessTier = "Cool"
+	}
+
+	containerAccessType := "private"
+	if bucket.PolicyType == "public-read" {
+		containerAccessType = "blob"
+	} else if bucket.PolicyType == "custom" {
+		reportWarn(config, fmt.Sprintf("Azure Blob存储不支持存储桶策略，存储桶 %s 的自定义策略将被忽略，请使用RBAC授权", bucket.BucketName))
+	}
+
+	bucketConfig.WriteString(fmt.Sprintf(`resource "azurerm_storage_account" "%s" {
+  name                              = "%s"
+  resource_group_name               = azurerm_resource_group.rg.name
+  location                          = azurerm_resource_group.rg.location
+  account_tier                      = "Standard"
+  account_replication_type          = "LRS"
+  access_tier                       = "%s"
+  min_tls_version                   = "TLS1_2"
+  allow_nested_items_to_be_public   = %t
+  infrastructure_encryption_enabled = %t
+
+  blob_properties {
+    versioning_enabled = %t
+  }
+
+  tags = merge(local.common_tags, {
+    Name = %s
+  })
+}
+
+resource "azurerm_storage_container" "%s" {
+  name                  = %s
+  storage_account_name  = azurerm_storage_account.%s.name
+  container_access_type = "%s"
+}
+`, resourceName, accountName, accessTier, bucket.PolicyType == "public-read", bucket.Encryption != "",
+		bucket.EnableVersioning, hclString(bucket.BucketName), resourceName, hclString(strings.ToLower(bucket.BucketName)), resourceName, containerAccessType))
+
+	rule := bucket.LifecycleRule
+	if bucket.EnableLifecycleRules || bucket.StorageClass == "Archive" {
+		var actions strings.Builder
+		if bucket.StorageClass == "Archive" {
+			actions.WriteString(`
+        tier_to_archive_after_days_since_modification_greater_than = 0`)
+		} else if bucket.EnableLifecycleRules && rule.TransitionDays > 0 {
+			tierAction := "tier_to_cool_after_days_since_modification_greater_than"
+			if bucket.StorageClass == "IA" {
+				tierAction = "tier_to_archive_after_days_since_modification_greater_than"
+			}
+			actions.WriteString(fmt.Sprintf(`
+        %s = %d`, tierAction, rule.TransitionDays))
+		}
+		if bucket.EnableLifecycleRules && rule.ExpirationDays > 0 {
+			actions.WriteString(fmt.Sprintf(`
+        delete_after_days_since_modification_greater_than = %d`, rule.ExpirationDays))
+		}
+
+		bucketConfig.WriteString(fmt.Sprintf(`resource "azurerm_storage_management_policy" "%s_lifecycle" {
+  storage_account_id = azurerm_storage_account.%s.id
+
+  rule {
+    name    = %s
+    enabled = %t
+    filters {
+      blob_types   = ["blockBlob"]
+      prefix_match = [%s]
+    }
+    actions {
+      base_blob {%s
+      }
+    }
+  }
+}
+`, resourceName, resourceName, hclString(rule.Name), rule.Status == "Enabled" || !bucket.EnableLifecycleRules,
+			hclString(strings.ToLower(bucket.BucketName)+"/"), actions.String()))
+	}
+
+	return bucketConfig.String()
+}
+
+// generateAlicloudBucket 生成阿里云OSS存储桶配置
+func generateAlicloudBucket(resourceName string, bucket models.StorageBucket) string {
+	var bucketConfig strings.Builder
+
+	acl := "private"
+	if bucket.PolicyType == "public-read" {
+		acl = "public-read"
+	}
+
+	bucketConfig.WriteString(fmt.Sprintf(`resource "alicloud_oss_bucket" "%s" {
+  bucket        = %s
+  storage_class = "%s"
+  acl           = "%s"
+`, resourceName, hclString(bucket.BucketName), storageClassMapping["alicloud"][bucket.StorageClass], acl))
+
+	if bucket.PolicyType == "custom" {
+		bucketConfig.WriteString(fmt.Sprintf(`  policy        = <<POLICY
+%s
+POLICY
+`, bucketPolicyHeredoc(bucket.CustomPolicy)))
+	}
+
+	if bucket.EnableVersioning {
+		bucketConfig.WriteString(`
+  versioning {
+    status = "Enabled"
+  }
+`)
+	}
+
+	if bucket.Encryption != "" {
+		sseAlgorithm := "AES256"
+		kmsKey := ""
+		if bucket.Encryption == "KMS" {
+			sseAlgorithm = "KMS"
+			if bucket.KmsKeyId != "" {
+				kmsKey = fmt.Sprintf("\n    kms_master_key_id = %s", hclString(bucket.KmsKeyId))
+			}
+		}
+		bucketConfig.WriteString(fmt.Sprintf(`
+  server_side_encryption_rule {
+    sse_algorithm = "%s"%s
+  }
+`, sseAlgorithm, kmsKey))
+	}
+
+	rule := bucket.LifecycleRule
+	if bucket.EnableLifecycleRules {
+		bucketConfig.WriteString(fmt.Sprintf(`
+  lifecycle_rule {
+    id      = %s
+    prefix  = ""
+    enabled = %t
+`, hclString(rule.Name), rule.Status == "Enabled"))
+		if rule.TransitionDays > 0 {
+			bucketConfig.WriteString(fmt.Sprintf(`    transitions {
+      days          = %d
+      storage_class = "%s"
+    }
+`, rule.TransitionDays, bucketTransitionClass("alicloud", bucket.StorageClass)))
+		}
+		if rule.ExpirationDays > 0 {
+			bucketConfig.WriteString(fmt.Sprintf(`    expiration {
+      days = %d
+    }
+`, rule.ExpirationDays))
+		}
+		bucketConfig.WriteString(`  }
+`)
+	}
+
+	bucketConfig.WriteString(fmt.Sprintf(`
+  tags = merge(local.common_tags, {
+    Name = %s
+  })
+}
+`, hclString(bucket.BucketName)))
+
+	return bucketConfig.String()
+}
+
+// generateBaiduBucket 生成百度云BOS存储桶配置
+func generateBaiduBucket(config renderContext, resourceName string, bucket models.StorageBucket) string {
+	var bucketConfig strings.Builder
+
+	acl := "private"
+	if bucket.PolicyType == "public-read" {
+		acl = "public-read"
+	} else if bucket.PolicyType == "custom" {
+		reportWarn(config, fmt.Sprintf("百度云BOS暂不支持通过Terraform配置存储桶策略，存储桶 %s 按私有存储桶处理", bucket.BucketName))
+	}
+	if bucket.EnableVersioning {
+		reportWarn(config, fmt.Sprintf("百度云BOS暂不支持版本控制，存储桶 %s 的版本控制设置将被忽略", bucket.BucketName))
+	}
+
+	bucketConfig.WriteString(fmt.Sprintf(`resource "baiducloud_bos_bucket" "%s" {
+  bucket        = %s
+  acl           = "%s"
+  storage_class = "%s"
+`, resourceName, hclString(bucket.BucketName), acl, storageClassMapping["baidu"][bucket.StorageClass]))
+
+	if bucket.Encryption != "" {
+		// BOS仅支持AES256服务端加密
+		bucketConfig.WriteString(`
+  server_side_encryption_rule = "AES256"
+`)
+	}
+
+	rule := bucket.LifecycleRule
+	if bucket.EnableLifecycleRules {
+		status := "enabled"
+		if rule.Status != "Enabled" {
+			status = "disabled"
+		}
+		if rule.TransitionDays > 0 {
+			bucketConfig.WriteString(fmt.Sprintf(`
+  lifecycle_rule {
+    status   = "%s"
+    resource = [%s]
+    condition {
+      time {
+        date_greater_than = "$(lastModified)+P%dD"
+      }
+    }
+    action {
+      name          = "Transition"
+      storage_class = "%s"
+    }
+  }
+`, status, hclString(bucket.BucketName+"/*"), rule.TransitionDays, bucketTransitionClass("baidu", bucket.StorageClass)))
+		}
+		if rule.ExpirationDays > 0 {
+			bucketConfig.WriteString(fmt.Sprintf(`
+  lifecycle_rule {
+    status   = "%s"
+    resource = [%s]
+    condition {
+      time {
+        date_greater_than = "$(lastModified)+P%dD"
+      }
+    }
+    action {
+      name = "DeleteObject"
+    }
+  }
+`, status, hclString(bucket.BucketName+"/*"), rule.ExpirationDays))
+		}
+	}
+
+	bucketConfig.WriteString(`}
+`)
+
+	return bucketConfig.String()
+}
+
+// generateHuaweiBucket 生成华为云OBS存储桶配置
+func generateHuaweiBucket(resourceName string, bucket models.StorageBucket) string {
+	var bucketConfig strings.Builder
+
+	acl := "private"
+	if bucket.PolicyType == "public-read" {
+		acl = "public-read"
+	}
+
+	bucketConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_obs_bucket" "%s" {
+  bucket        = %s
+  storage_class = "%s"
+  acl           = "%s"
+  versioning    = %t
+`, resourceName, hclString(bucket.BucketName), storageClassMapping["huawei"][bucket.StorageClass], acl, bucket.EnableVersioning))
+
+	if bucket.Encryption != "" {
+		sseAlgorithm := "AES256"
+		kmsKey := ""
+		if bucket.Encryption == "KMS" {
+			sseAlgorithm = "kms"
+			if bucket.KmsKeyId != "" {
+				kmsKey = fmt.Sprintf("\n  kms_key_id    = %s", hclString(bucket.KmsKeyId))
+			}
+		}
+		bucketConfig.WriteString(fmt.Sprintf(`  encryption    = true
+  sse_algorithm = "%s"%s
+`, sseAlgorithm, kmsKey))
+	}
+
+	rule := bucket.LifecycleRule
+	if bucket.EnableLifecycleRules {
+		bucketConfig.WriteString(fmt.Sprintf(`
+  lifecycle_rule {
+    name    = %s
+    enabled = %t
+`, hclString(rule.Name), rule.Status == "Enabled"))
+		if rule.TransitionDays > 0 {
+			bucketConfig.WriteString(fmt.Sprintf(`    transition {
+      days          = %d
+      storage_class = "%s"
+    }
+`, rule.TransitionDays, bucketTransitionClass("huawei", bucket.StorageClass)))
+		}
+		if rule.ExpirationDays > 0 {
+			bucketConfig.WriteString(fmt.Sprintf(`    expiration {
+      days = %d
+    }
+`, rule.ExpirationDays))
+		}
+		bucketConfig.WriteString(`  }
+`)
+	}
+
+	bucketConfig.WriteString(fmt.Sprintf(`
+  tags = merge(local.common_tags, {
+    Name = %s
+  })
+}
+`, hclString(bucket.BucketName)))
+
+	if bucket.PolicyType == "custom" {
+		bucketConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_obs_bucket_policy" "%s_policy" {
+  bucket = huaweicloud_obs_bucket.%s.bucket
+  policy = <<POLICY
+%s
+POLICY
+}
+`, resourceName, resourceName, bucketPolicyHeredoc(bucket.CustomPolicy)))
+	}
+
+	return bucketConfig.String()
+}
+
+// generateTencentBucket 生成腾讯云COS存储桶配置
+func generateTencentBucket(resourceName string, bucket models.StorageBucket) string {
+	var bucketConfig strings.Builder
+
+	acl := "private"
+	if bucket.PolicyType == "public-read" {
+		acl = "public-read"
+	}
+
+	bucketConfig.WriteString(fmt.Sprintf(`resource "tencentcloud_cos_bucket" "%s" {
+  bucket            = "%s-${data.tencentcloud_user_info.current.app_id}"
+  acl               = "%s"
+  versioning_enable = %t
+`, resourceName, bucket.BucketName, acl, bucket.EnableVersioning))
+
+	if bucket.Encryption != "" {
+		encryptionAlgorithm := "AES256"
+		if bucket.Encryption == "KMS" {
+			encryptionAlgorithm = "KMS"
+		}
+		bucketConfig.WriteString(fmt.Sprintf(`  encryption_algorithm = "%s"
+`, encryptionAlgorithm))
+	}
+
+	// COS没有存储桶级别的默认存储类型，非标准存储类型通过生命周期规则转换实现，低频存储需要先在标准存储中保存30天
+	rule := bucket.LifecycleRule
+	if bucket.StorageClass != "Standard" {
+		bucketConfig.WriteString(fmt.Sprintf(`
+  lifecycle_rules {
+    filter_prefix = ""
+    transition {
+      days          = %d
+      storage_class = "%s"
+    }
+  }
+`, defaultClassTransitionDays("tencent", bucket.StorageClass), storageClassMapping["tencent"][bucket.StorageClass]))
+	}
+	if bucket.EnableLifecycleRules && rule.Status == "Enabled" {
+		bucketConfig.WriteString(fmt.Sprintf(`
+  lifecycle_rules {
+    id            = %s
+    filter_prefix = ""
+`, hclString(rule.Name)))
+		if rule.TransitionDays > 0 {
+			bucketConfig.WriteString(fmt.Sprintf(`    transition {
+      days          = %d
+      storage_class = "%s"
+    }
+`, rule.TransitionDays, bucketTransitionClass("tencent", bucket.StorageClass)))
+		}
+		if rule.ExpirationDays > 0 {
+			bucketConfig.WriteString(fmt.Sprintf(`    expiration {
+      days = %d
+    }
+`, rule.ExpirationDays))
+		}
+		bucketConfig.WriteString(`  }
+`)
+	}
+
+	bucketConfig.WriteString(fmt.Sprintf(`
+  tags = merge(local.common_tags, {
+    Name = %s
+  })
+}
+`, hclString(bucket.BucketName)))
+
+	if bucket.PolicyType == "custom" {
+		bucketConfig.WriteString(fmt.Sprintf(`resource "tencentcloud_cos_bucket_policy" "%s_policy" {
+  bucket = tencentcloud_cos_bucket.%s.id
+  policy = <<POLICY
+%s
+POLICY
+}
+`, resourceName, resourceName, bucketPolicyHeredoc(bucket.CustomPolicy)))
+	}
+
+	return bucketConfig.String()
+}
+
+// generateVolcengineBucket 生成火山引擎TOS存储桶配置
+func generateVolcengineBucket(config renderContext, resourceName string, bucket models.StorageBucket) string {
+	var bucketConfig strings.Builder
+
+	acl := "private"
+	if bucket.PolicyType == "public-read" {
+		acl = "public-read"
+	}
+
+	bucketConfig.WriteString(fmt.Sprintf(`resource "volcengine_tos_bucket" "%s" {
+  bucket_name    = %s
+  storage_class  = "%s"
+  public_acl     = "%s"
+  enable_version = %t
+
+  tags {
+    key   = "Name"
+    value = %s
+  }
+}
+`, resourceName, hclString(bucket.BucketName), storageClassMapping["volcengine"][bucket.StorageClass], acl, bucket.EnableVersioning, hclString(bucket.BucketName)))
+
+	if bucket.PolicyType == "custom" {
+		bucketConfig.WriteString(fmt.Sprintf(`resource "volcengine_tos_bucket_policy" "%s_policy" {
+  bucket_name = volcengine_tos_bucket.%s.id
+  policy      = <<POLICY
+%s
+POLICY
+}
+`, resourceName, resourceName, bucketPolicyHeredoc(bucket.CustomPolicy)))
+	}
+
+	if bucket.Encryption != "" {
+		sseAlgorithm := "AES256"
+		kmsKey := ""
+		if bucket.Encryption == "KMS" {
+			sseAlgorithm = "kms"
+			if bucket.KmsKeyId != "" {
+				kmsKey = fmt.Sprintf("\n      kms_master_key_id = %s", hclString(bucket.KmsKeyId))
+			}
+		}
+		bucketConfig.WriteString(fmt.Sprintf(`resource "volcengine_tos_bucket_encryption" "%s_encryption" {
+  bucket_name = volcengine_tos_bucket.%s.id
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm = "%s"%s
+    }
+  }
+}
+`, resourceName, resourceName, sseAlgorithm, kmsKey))
+	}
+
+	if bucket.EnableLifecycleRules {
+		reportWarn(config, fmt.Sprintf("火山引擎TOS暂不支持通过Terraform配置生命周期规则，存储桶 %s 的生命周期规则将被忽略", bucket.BucketName))
+	}
+
+	return bucketConfig.String()
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+func TestGenerateObjectStorageConfig(t *testing.T) {
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::logs/${aws:username}/*"}]}`
+	tests := []struct {
+		name     string
+		provider string
+		buckets  []models.StorageBucket
+		want     []string
+		notWant  []string
+		wantErr  bool
+	}{
+		{
+			name:     "labels do not collide with the s3 component",
+			provider: "aws",
+			buckets:  []models.StorageBucket{{BucketName: "logs"}},
+			want:     []string{`resource "aws_s3_bucket" "object_storage"`},
+			notWant:  []string{`"storage"`},
+		},
+		{
+			name:     "aws infrequent access waits 30 days",
+			provider: "aws",
+			buckets:  []models.StorageBucket{{BucketName: "logs", StorageClass: "IA"}},
+			want:     []string{"days          = 30\n      storage_class = \"STANDARD_IA\""},
+		},
+		{
+			name:     "aws archive transitions immediately",
+			provider: "aws",
+			buckets:  []models.StorageBucket{{BucketName: "logs", StorageClass: "Archive"}},
+			want:     []string{"days          = 0\n      storage_class = \"GLACIER\""},
+		},
+		{
+			name:     "lifecycle transition to infrequent access is raised",
+			provider: "tencent",
+			buckets:  []models.StorageBucket{{BucketName: "logs", EnableLifecycleRules: true, LifecycleRule: models.BucketLifecycleRule{TransitionDays: 7}}},
+			want:     []string{"days          = 30\n      storage_class = \"STANDARD_IA\""},
+		},
+		{
+			name:     "custom policy interpolation is escaped",
+			provider: "aws",
+			buckets:  []models.StorageBucket{{BucketName: "logs", PolicyType: "custom", CustomPolicy: policy}},
+			want:     []string{"logs/$${aws:username}/*"},
+		},
+		{
+			name:     "bucket name breaking out of the string is rejected",
+			provider: "aws",
+			buckets:  []models.StorageBucket{{BucketName: `x" } resource "null_resource" "pwn" { x = "${file("/etc/passwd")}`}},
+			notWant:  []string{"null_resource", "aws_s3_bucket"},
+			wantErr:  true,
+		},
+		{
+			name:     "tencent bucket name too long for the appid suffix",
+			provider: "tencent",
+			buckets:  []models.StorageBucket{{BucketName: strings.Repeat("a", 41)}},
+			notWant:  []string{"tencentcloud_cos_bucket"},
+			wantErr:  true,
+		},
+		{
+			name:     "lifecycle rule names are escaped",
+			provider: "alicloud",
+			buckets:  []models.StorageBucket{{BucketName: "logs", EnableLifecycleRules: true, LifecycleRule: models.BucketLifecycleRule{Name: `rule "${x}"`, ExpirationDays: 30}}},
+			want:     []string{`id      = "rule \"$${x}\""`, `bucket        = "logs"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(models.DeploymentConfig{CloudProvider: tt.provider, Region: "us-east-1"})
+			config.ComponentConfig.StorageBuckets = tt.buckets
+			body := generateObjectStorageConfig(config, map[string]interface{}{})
+			if got := HasErrorFindings(config.findings.Findings()); got != tt.wantErr {
+				t.Fatalf("error findings = %v, want %v: %v", got, tt.wantErr, config.findings.Findings())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("config should not contain %q:\n%s", notWant, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,694 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// organizationProviders 支持组织结构和账号开通的云提供商
+var organizationProviders = map[string]bool{
+	"aws":      true,
+	"azure":    true,
+	"alicloud": true,
+}
+
+// defaultAccessRoles 账号开通时在成员账号中创建的默认访问角色，部署通过扮演该角色访问成员账号
+var defaultAccessRoles = map[string]string{
+	"aws":        "OrganizationAccountAccessRole",
+	"alicloud":   "ResourceDirectoryAccountAccessRole",
+	"tencent":    "OrganizationAccessControlRole",
+	"huawei":     "OrganizationAccessAgency",
+	"volcengine": "OrganizationAccessRole",
+}
+
+// azureAllowedLocationsPolicy Azure内置策略“允许的位置”的定义ID
+const azureAllowedLocationsPolicy = "/providers/Microsoft.Authorization/policyDefinitions/e56962a6-4747-49cd-b67b-bf8b01975c4c"
+
+// orgLabelRegexp 用于将组织单元和账号名称转换为Terraform资源名称
+var orgLabelRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// awsAccountIdRegexp 和 azureSubscriptionIdRegexp 用于校验目标账号ID
+var (
+	awsAccountIdRegexp        = regexp.MustCompile(`^\d{12}$`)
+	azureSubscriptionIdRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// organizationUnit 表示校验后的组织单元，ParentRef为父节点ID的Terraform表达式，GuardrailPolicies为校验后的防护策略
+type organizationUnit struct {
+	models.OrganizationUnit
+	Label             string
+	ParentRef         string
+	GuardrailPolicies []guardrail
+}
+
+// guardrail 表示渲染前的防护策略，Document为策略文档，Azure内置策略使用PolicyDefinitionId和Parameters（HCL表达式）
+type guardrail struct {
+	Name               string
+	Label              string
+	Document           map[string]interface{}
+	PolicyDefinitionId string
+	Parameters         string
+}
+
+// orgLabel 将名称转换为Terraform资源名称
+func orgLabel(name string) string {
+	label := strings.Trim(orgLabelRegexp.ReplaceAllString(strings.ToLower(strings.ReplaceAll(name, "-", "_")), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "ou_" + label
+	}
+	return label
+}
+
+// hasNetworkConfig 判断部署是否定义了VPC，只开通账号的组织部署不包含网络配置
+func hasNetworkConfig(config renderContext) bool {
+	return len(config.AllVpcs) > 0 || config.VPC.Name != ""
+}
+
+// generateOrganizationConfig 生成组织单元、成员账号（订阅）和防护策略
+func generateOrganizationConfig(config renderContext) string {
+	provider := config.CloudProvider
+	if !organizationProviders[provider] {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持组织结构和账号开通", provider))
+		return ""
+	}
+
+	units, ok := resolveOrganizationUnits(config)
+	if !ok {
+		return ""
+	}
+	for i := range units {
+		guardrails, err := resolveGuardrails(config, units[i])
+		if err != nil {
+			reportError(config, fmt.Sprintf("%v，未生成组织结构配置", err))
+			return ""
+		}
+		units[i].GuardrailPolicies = guardrails
+	}
+
+	var orgConfig string
+	switch provider {
+	case "aws":
+		orgConfig = generateAwsOrganization(config, units)
+	case "azure":
+		orgConfig = generateAzureManagementGroups(config, units)
+	case "alicloud":
+		orgConfig = generateAlicloudResourceDirectory(config, units)
+	}
+
+	// 指定目标账号时默认provider扮演目标账号中的角色，组织资源只能在管理账号中创建，使用不扮演角色的管理账号provider
+	if config.TargetAccount.AccountId != "" {
+		alias := managementProviderAlias(config)
+		management := generateProviderBlock(provider, config.Region, alias, providerDefaultTags(provider, resolveTags(config)))
+		orgConfig = management + "\n" + scopeProviderAlias(orgConfig, map[string]string{providerLocalName(provider): alias})
+	}
+	LogInfo(fmt.Sprintf("已生成组织结构配置: 组织单元=%d, 成员账号=%d", len(units), len(config.Organization.Accounts)))
+	return orgConfig
+}
+
+// managementProviderAlias 返回组织资源使用的管理账号provider别名，多云部署中加上分段别名避免冲突
+func managementProviderAlias(config renderContext) string {
+	if config.ProviderAlias != "" {
+		return config.ProviderAlias + "_management"
+	}
+	return "management"
+}
+
+// resolveOrganizationUnits 校验组织单元名称唯一、父节点存在且没有循环引用，并按层级排序
+// 父节点在子节点之前，便于阅读生成的配置
+func resolveOrganizationUnits(config renderContext) ([]organizationUnit, bool) {
+	byName := make(map[string]models.OrganizationUnit)
+	labels := make(map[string]string)
+	for _, unit := range config.Organization.Units {
+		if unit.Name == "" {
+			reportError(config, "组织单元名称不能为空，未生成组织结构配置")
+			return nil, false
+		}
+		if _, exists := byName[unit.Name]; exists {
+			reportError(config, fmt.Sprintf("组织单元 %s 重复定义，未生成组织结构配置", unit.Name))
+			return nil, false
+		}
+		label := orgLabel(unit.Name)
+		if other, exists := labels[label]; exists {
+			reportError(config, fmt.Sprintf("组织单元 %s 和 %s 的资源名称冲突，未生成组织结构配置", unit.Name, other))
+			return nil, false
+		}
+		byName[unit.Name] = unit
+		labels[label] = unit.Name
+	}
+
+	depths := make(map[string]int)
+	for _, unit := range config.Organization.Units {
+		depth := 0
+		for parent := unit.Parent; parent != ""; parent = byName[parent].Parent {
+			if _, exists := byName[parent]; !exists {
+				reportError(config, fmt.Sprintf("组织单元 %s 的父组织单元 %s 不存在，未生成组织结构配置", unit.Name, parent))
+				return nil, false
+			}
+			if depth++; depth > len(byName) {
+				reportError(config, fmt.Sprintf("组织单元 %s 存在循环的父子关系，未生成组织结构配置", unit.Name))
+				return nil, false
+			}
+		}
+		if config.CloudProvider == "aws" && depth >= 5 {
+			reportError(config, fmt.Sprintf("组织单元 %s 的层级超过AWS Organizations支持的5级", unit.Name))
+			return nil, false
+		}
+		depths[unit.Name] = depth
+	}
+
+	accounts := make(map[string]string)
+	for _, account := range config.Organization.Accounts {
+		if account.Name == "" {
+			reportError(config, "成员账号名称不能为空，未生成组织结构配置")
+			return nil, false
+		}
+		label := orgLabel(account.Name)
+		if other, exists := accounts[label]; exists {
+			reportError(config, fmt.Sprintf("成员账号 %s 和 %s 重复或资源名称冲突，未生成组织结构配置", account.Name, other))
+			return nil, false
+		}
+		accounts[label] = account.Name
+		if account.Unit != "" {
+			if _, exists := byName[account.Unit]; !exists {
+				reportError(config, fmt.Sprintf("成员账号 %s 所属的组织单元 %s 不存在，未生成组织结构配置", account.Name, account.Unit))
+				return nil, false
+			}
+		}
+	}
+
+	units := make([]organizationUnit, 0, len(config.Organization.Units))
+	for _, unit := range config.Organization.Units {
+		units = append(units, organizationUnit{OrganizationUnit: unit, Label: orgLabel(unit.Name)})
+	}
+	sort.SliceStable(units, func(i, j int) bool {
+		return depths[units[i].Name] < depths[units[j].Name]
+	})
+	return units, true
+}
+
+// resolveGuardrails 解析组织单元的内置防护策略和自定义策略，自定义策略校验失败或名称重复时返回错误
+// 云提供商不支持的内置防护策略只记录警告
+func resolveGuardrails(config renderContext, unit organizationUnit) ([]guardrail, error) {
+	provider := config.CloudProvider
+	regions := config.Organization.AllowedRegions
+	if len(regions) == 0 {
+		regions = []string{config.Region}
+	}
+
+	var guardrails []guardrail
+	labels := make(map[string]bool)
+	for _, name := range unit.Guardrails {
+		name = strings.ToLower(strings.TrimSpace(name))
+		item, ok := builtinGuardrail(provider, name, regions)
+		if !ok {
+			reportWarn(config, fmt.Sprintf("云提供商 %s 不支持内置防护策略 %s，组织单元 %s 已忽略该策略", provider, name, unit.Name))
+			continue
+		}
+		item.Label = unit.Label + "_" + orgLabel(name)
+		if labels[item.Label] {
+			continue
+		}
+		labels[item.Label] = true
+		guardrails = append(guardrails, item)
+	}
+
+	for _, policy := range unit.Policies {
+		if policy.Name == "" {
+			return nil, fmt.Errorf("组织单元 %s 的自定义防护策略名称不能为空", unit.Name)
+		}
+		document, err := validateGuardrailDocument(provider, policy.Document)
+		if err != nil {
+			return nil, fmt.Errorf("组织单元 %s 的自定义防护策略 %s 校验失败: %v", unit.Name, policy.Name, err)
+		}
+		label := unit.Label + "_" + orgLabel(policy.Name)
+		if labels[label] {
+			return nil, fmt.Errorf("组织单元 %s 的防护策略 %s 重复或资源名称冲突", unit.Name, policy.Name)
+		}
+		labels[label] = true
+		guardrails = append(guardrails, guardrail{
+			Name:     policy.Name,
+			Label:    label,
+			Document: document,
+		})
+	}
+	return guardrails, nil
+}
+
+// builtinGuardrail 返回内置防护策略的策略文档
+// deny-leave-organization 禁止成员账号退出组织，deny-root-user 禁止使用根用户，
+// protect-audit 禁止关闭操作审计和配置记录，allowed-regions 禁止在允许的区域之外创建资源
+func builtinGuardrail(provider, name string, regions []string) (guardrail, bool) {
+	regionList := make([]interface{}, len(regions))
+	for i, region := range regions {
+		regionList[i] = region
+	}
+	statement := func(sid string, body map[string]interface{}) map[string]interface{} {
+		body["Sid"] = sid
+		body["Effect"] = "Deny"
+		return body
+	}
+
+	switch provider {
+	case "aws":
+		var body map[string]interface{}
+		switch name {
+		case "deny-leave-organization":
+			body = statement("DenyLeaveOrganization", map[string]interface{}{"Action": "organizations:LeaveOrganization", "Resource": "*"})
+		case "deny-root-user":
+			body = statement("DenyRootUser", map[string]interface{}{
+				"Action":    "*",
+				"Resource":  "*",
+				"Condition": map[string]interface{}{"StringLike": map[string]interface{}{"aws:PrincipalArn": "arn:aws:iam::*:root"}},
+			})
+		case "protect-audit":
+			body = statement("ProtectAuditTrail", map[string]interface{}{
+				"Action": []interface{}{
+					"cloudtrail:StopLogging", "cloudtrail:DeleteTrail", "cloudtrail:UpdateTrail",
+					"config:StopConfigurationRecorder", "config:DeleteConfigurationRecorder", "config:DeleteDeliveryChannel",
+				},
+				"Resource": "*",
+			})
+		case "allowed-regions":
+			// 全局服务的请求区域固定为us-east-1，需要排除在外
+			body = statement("DenyOutsideAllowedRegions", map[string]interface{}{
+				"NotAction": []interface{}{
+					"iam:*", "organizations:*", "sts:*", "support:*", "route53:*", "cloudfront:*", "budgets:*", "waf:*", "globalaccelerator:*",
+				},
+				"Resource":  "*",
+				"Condition": map[string]interface{}{"StringNotEquals": map[string]interface{}{"aws:RequestedRegion": regionList}},
+			})
+		default:
+			return guardrail{}, false
+		}
+		return guardrail{Name: name, Document: map[string]interface{}{"Version": "2012-10-17", "Statement": []interface{}{body}}}, true
+
+	case "alicloud":
+		var body map[string]interface{}
+		switch name {
+		case "deny-leave-organization":
+			body = map[string]interface{}{"Action": "resourcemanager:RemoveAccount", "Resource": "*"}
+		case "deny-root-user":
+			body = map[string]interface{}{
+				"Action":    "*",
+				"Resource":  "*",
+				"Condition": map[string]interface{}{"StringLike": map[string]interface{}{"acs:PrincipalARN": "acs:ram::*:root"}},
+			}
+		case "protect-audit":
+			body = map[string]interface{}{
+				"Action":   []interface{}{"actiontrail:StopLogging", "actiontrail:DeleteTrail", "actiontrail:UpdateTrail", "config:StopConfigurationRecorder"},
+				"Resource": "*",
+			}
+		case "allowed-regions":
+			body = map[string]interface{}{
+				"NotAction": []interface{}{"ram:*", "sts:*", "resourcemanager:*", "actiontrail:*", "bss:*", "cdn:*"},
+				"Resource":  "*",
+				"Condition": map[string]interface{}{"StringNotEquals": map[string]interface{}{"acs:RequestedRegion": regionList}},
+			}
+		default:
+			return guardrail{}, false
+		}
+		body["Effect"] = "Deny"
+		return guardrail{Name: name, Document: map[string]interface{}{"Version": "1", "Statement": []interface{}{body}}}, true
+
+	case "azure":
+		// 订阅不能退出管理组层级，也没有根用户，Azure只支持区域限制
+		if name == "allowed-regions" {
+			return guardrail{
+				Name:               name,
+				PolicyDefinitionId: azureAllowedLocationsPolicy,
+				Parameters:         fmt.Sprintf("jsonencode({\n    listOfAllowedLocations = { value = %s }\n  })", hclStringList(regions)),
+			}, true
+		}
+	}
+	return guardrail{}, false
+}
+
+// validateGuardrailDocument 校验自定义防护策略
+// AWS的SCP和阿里云的管控策略使用与IAM策略相同的语法，Azure Policy的策略规则必须包含if和then.effect
+func validateGuardrailDocument(provider, document string) (map[string]interface{}, error) {
+	if provider != "azure" {
+		return validateIamPolicyDocument(provider, document)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(document), &parsed); err != nil {
+		return nil, fmt.Errorf("策略规则不是有效的JSON对象: %v", err)
+	}
+	if _, ok := parsed["if"].(map[string]interface{}); !ok {
+		return nil, errors.New("策略规则缺少if条件")
+	}
+	then, _ := parsed["then"].(map[string]interface{})
+	if effect, _ := then["effect"].(string); effect == "" {
+		return nil, errors.New("策略规则缺少then.effect")
+	}
+	return parsed, nil
+}
+
+// generateAwsOrganization 生成AWS Organizations组织单元、成员账号和SCP
+func generateAwsOrganization(config renderContext, units []organizationUnit) string {
+	var org strings.Builder
+
+	rootRef := "data.aws_organizations_organization.org.roots[0].id"
+	if config.Organization.CreateOrganization {
+		org.WriteString(`resource "aws_organizations_organization" "org" {
+  feature_set          = "ALL"
+  enabled_policy_types = ["SERVICE_CONTROL_POLICY"]
+
+  aws_service_access_principals = [
+    "cloudtrail.amazonaws.com",
+    "config.amazonaws.com",
+  ]
+}
+
+`)
+		rootRef = "aws_organizations_organization.org.roots[0].id"
+	} else {
+		org.WriteString(`data "aws_organizations_organization" "org" {}
+
+`)
+	}
+
+	unitRefs := make(map[string]string)
+	for _, unit := range units {
+		parentRef := rootRef
+		if unit.Parent != "" {
+			parentRef = unitRefs[unit.Parent]
+		}
+		unitRefs[unit.Name] = fmt.Sprintf("aws_organizations_organizational_unit.%s.id", unit.Label)
+		org.WriteString(fmt.Sprintf(`resource "aws_organizations_organizational_unit" "%s" {
+  name      = "%s"
+  parent_id = %s
+}
+
+`, unit.Label, hclStringContent(unit.Name), parentRef))
+
+		for _, item := range unit.GuardrailPolicies {
+			org.WriteString(fmt.Sprintf(`resource "aws_organizations_policy" "%s" {
+  name    = "%s-%s"
+  type    = "SERVICE_CONTROL_POLICY"
+  content = %s
+}
+
+resource "aws_organizations_policy_attachment" "%s" {
+  policy_id = aws_organizations_policy.%s.id
+  target_id = aws_organizations_organizational_unit.%s.id
+}
+
+`, item.Label, hclStringContent(unit.Name), hclStringContent(item.Name), iamPolicyHeredoc(item.Document), item.Label, item.Label, unit.Label))
+		}
+	}
+
+	outputs := make(map[string]string)
+	for _, account := range config.Organization.Accounts {
+		if account.Email == "" {
+			reportError(config, fmt.Sprintf("AWS成员账号 %s 必须指定根用户邮箱，已忽略", account.Name))
+			continue
+		}
+		parentRef := rootRef
+		if account.Unit != "" {
+			parentRef = unitRefs[account.Unit]
+		}
+		label := orgLabel(account.Name)
+		org.WriteString(fmt.Sprintf(`resource "aws_organizations_account" "%s" {
+  name              = "%s"
+  email             = "%s"
+  parent_id         = %s
+  role_name         = "%s"
+  close_on_deletion = false
+
+  # 账号创建后不能修改访问角色名称
+  lifecycle {
+    ignore_changes = [role_name]
+  }
+}
+
+`, label, hclStringContent(account.Name), hclStringContent(account.Email), parentRef, defaultAccessRoles["aws"]))
+		outputs[account.Name] = fmt.Sprintf("aws_organizations_account.%s.id", label)
+	}
+
+	org.WriteString(generateVendedAccountsOutput(outputs))
+	return org.String()
+}
+
+// generateAzureManagementGroups 生成Azure管理组、订阅以及管理组上的Azure Policy分配
+func generateAzureManagementGroups(config renderContext, units []organizationUnit) string {
+	var org strings.Builder
+
+	unitRefs := make(map[string]string)
+	for _, unit := range units {
+		unitRefs[unit.Name] = fmt.Sprintf("azurerm_management_group.%s.id", unit.Label)
+		if unit.Parent == "" {
+			org.WriteString(fmt.Sprintf(`resource "azurerm_management_group" "%s" {
+  name         = "%s"
+  display_name = "%s"
+}
+
+`, unit.Label, strings.ReplaceAll(unit.Label, "_", "-"), hclStringContent(unit.Name)))
+		} else {
+			org.WriteString(fmt.Sprintf(`resource "azurerm_management_group" "%s" {
+  name                       = "%s"
+  display_name               = "%s"
+  parent_management_group_id = %s
+}
+
+`, unit.Label, strings.ReplaceAll(unit.Label, "_", "-"), hclStringContent(unit.Name), unitRefs[unit.Parent]))
+		}
+
+		for _, item := range unit.GuardrailPolicies {
+			// 管理组范围的策略分配名称最多24个字符
+			assignmentName := strings.ReplaceAll(item.Label, "_", "-")
+			if len(assignmentName) > 24 {
+				assignmentName = strings.Trim(assignmentName[:24], "-")
+			}
+
+			definitionRef := fmt.Sprintf(`"%s"`, item.PolicyDefinitionId)
+			if item.Document != nil {
+				org.WriteString(fmt.Sprintf(`resource "azurerm_policy_definition" "%s" {
+  name                = "%s"
+  policy_type         = "Custom"
+  mode                = "All"
+  display_name        = "%s"
+  management_group_id = azurerm_management_group.%s.id
+  policy_rule         = %s
+}
+
+`, item.Label, strings.ReplaceAll(item.Label, "_", "-"), hclStringContent(item.Name), unit.Label, iamPolicyHeredoc(item.Document)))
+				definitionRef = fmt.Sprintf("azurerm_policy_definition.%s.id", item.Label)
+			}
+
+			parameters := ""
+			if item.Parameters != "" {
+				parameters = "\n  parameters           = " + item.Parameters
+			}
+			org.WriteString(fmt.Sprintf(`resource "azurerm_management_group_policy_assignment" "%s" {
+  name                 = "%s"
+  display_name         = "%s"
+  policy_definition_id = %s
+  management_group_id  = azurerm_management_group.%s.id%s
+}
+
+`, item.Label, assignmentName, hclStringContent(item.Name), definitionRef, unit.Label, parameters))
+		}
+	}
+
+	outputs := make(map[string]string)
+	for _, account := range config.Organization.Accounts {
+		if config.Organization.BillingScope == "" {
+			reportError(config, fmt.Sprintf("创建Azure订阅 %s 需要指定计费范围billingScope，已忽略", account.Name))
+			continue
+		}
+		label := orgLabel(account.Name)
+		org.WriteString(fmt.Sprintf(`resource "azurerm_subscription" "%s" {
+  subscription_name = "%s"
+  alias             = "%s"
+  billing_scope_id  = "%s"
+
+  tags = local.common_tags
+}
+
+`, label, hclStringContent(account.Name), strings.ReplaceAll(label, "_", "-"), hclStringContent(config.Organization.BillingScope)))
+
+		if account.Unit != "" {
+			org.WriteString(fmt.Sprintf(`resource "azurerm_management_group_subscription_association" "%s" {
+  management_group_id = %s
+  subscription_id     = "/subscriptions/${azurerm_subscription.%s.subscription_id}"
+}
+
+`, label, unitRefs[account.Unit], label))
+		}
+		outputs[account.Name] = fmt.Sprintf("azurerm_subscription.%s.subscription_id", label)
+	}
+
+	org.WriteString(generateVendedAccountsOutput(outputs))
+	return org.String()
+}
+
+// generateAlicloudResourceDirectory 生成阿里云资源目录文件夹、成员账号和管控策略
+func generateAlicloudResourceDirectory(config renderContext, units []organizationUnit) string {
+	var org strings.Builder
+
+	rootRef := "data.alicloud_resource_manager_resource_directories.rd.directories[0].root_folder_id"
+	if config.Organization.CreateOrganization {
+		org.WriteString(`resource "alicloud_resource_manager_resource_directory" "rd" {
+  status = "Enabled"
+}
+
+`)
+		rootRef = "alicloud_resource_manager_resource_directory.rd.root_folder_id"
+	} else {
+		org.WriteString(`data "alicloud_resource_manager_resource_directories" "rd" {}
+
+`)
+	}
+
+	unitRefs := make(map[string]string)
+	for _, unit := range units {
+		parentRef := rootRef
+		if unit.Parent != "" {
+			parentRef = unitRefs[unit.Parent]
+		}
+		unitRefs[unit.Name] = fmt.Sprintf("alicloud_resource_manager_folder.%s.id", unit.Label)
+		org.WriteString(fmt.Sprintf(`resource "alicloud_resource_manager_folder" "%s" {
+  folder_name      = "%s"
+  parent_folder_id = %s
+}
+
+`, unit.Label, hclStringContent(unit.Name), parentRef))
+
+		for _, item := range unit.GuardrailPolicies {
+			org.WriteString(fmt.Sprintf(`resource "alicloud_resource_manager_control_policy" "%s" {
+  control_policy_name = "%s-%s"
+  description         = "Guardrail created by multi-cloud landing zone platform"
+  effect_scope        = "RAM"
+  policy_document     = %s
+}
+
+resource "alicloud_resource_manager_control_policy_attachment" "%s" {
+  policy_id = alicloud_resource_manager_control_policy.%s.id
+  target_id = alicloud_resource_manager_folder.%s.id
+}
+
+`, item.Label, hclStringContent(unit.Name), hclStringContent(item.Name), iamPolicyHeredoc(item.Document), item.Label, item.Label, unit.Label))
+		}
+	}
+
+	outputs := make(map[string]string)
+	for _, account := range config.Organization.Accounts {
+		parentRef := rootRef
+		if account.Unit != "" {
+			parentRef = unitRefs[account.Unit]
+		}
+		label := orgLabel(account.Name)
+		org.WriteString(fmt.Sprintf(`resource "alicloud_resource_manager_account" "%s" {
+  display_name = "%s"
+  folder_id    = %s
+}
+
+`, label, hclStringContent(account.Name), parentRef))
+		outputs[account.Name] = fmt.Sprintf("alicloud_resource_manager_account.%s.id", label)
+	}
+
+	org.WriteString(generateVendedAccountsOutput(outputs))
+	return org.String()
+}
+
+// generateVendedAccountsOutput 输出开通的成员账号ID，网络部署通过targetAccount引用这些账号
+func generateVendedAccountsOutput(outputs map[string]string) string {
+	if len(outputs) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var output strings.Builder
+	output.WriteString("output \"vended_accounts\" {\n  value = {\n")
+	for _, name := range names {
+		output.WriteString(fmt.Sprintf("    %s = %s\n", hclString(name), outputs[name]))
+	}
+	output.WriteString("  }\n}\n\n")
+	return output.String()
+}
+
+// providerAssumeRole 返回provider中访问目标账号的配置，未指定目标账号时返回空字符串
+func providerAssumeRole(config renderContext) string {
+	target := config.TargetAccount
+	if target.AccountId == "" {
+		return ""
+	}
+
+	provider := config.CloudProvider
+	roleName := target.RoleName
+	if roleName == "" {
+		roleName = defaultAccessRoles[provider]
+	}
+	sessionName := target.SessionName
+	if sessionName == "" {
+		sessionName = "landing-zone"
+	}
+
+	switch provider {
+	case "aws":
+		if !awsAccountIdRegexp.MatchString(target.AccountId) {
+			reportError(config, fmt.Sprintf("目标账号ID %q 不是12位数字的AWS账号ID", target.AccountId))
+			return ""
+		}
+		return fmt.Sprintf(`
+
+  assume_role {
+    role_arn     = "arn:aws:iam::%s:role/%s"
+    session_name = "%s"
+  }`, hclStringContent(target.AccountId), hclStringContent(roleName), hclStringContent(sessionName))
+	case "azure":
+		if !azureSubscriptionIdRegexp.MatchString(target.AccountId) {
+			reportError(config, fmt.Sprintf("目标订阅ID %q 不是有效的GUID", target.AccountId))
+			return ""
+		}
+		return fmt.Sprintf("\n  subscription_id = \"%s\"", target.AccountId)
+	case "alicloud":
+		return fmt.Sprintf(`
+
+  assume_role {
+    role_arn     = "acs:ram::%s:role/%s"
+    session_name = "%s"
+  }`, hclStringContent(target.AccountId), hclStringContent(roleName), hclStringContent(sessionName))
+	case "tencent":
+		return fmt.Sprintf(`
+
+  assume_role {
+    role_arn         = "qcs::cam::uin/%s:roleName/%s"
+    session_name     = "%s"
+    session_duration = 3600
+  }`, hclStringContent(target.AccountId), hclStringContent(roleName), hclStringContent(sessionName))
+	case "huawei":
+		// 华为云的委托通过账号名（domain_name）指定委托所在的账号，账号ID不能作为账号名使用
+		if target.DomainName == "" {
+			reportError(config, fmt.Sprintf("华为云目标账号 %s 必须指定账号名domainName", target.AccountId))
+			return ""
+		}
+		return fmt.Sprintf(`
+
+  assume_role {
+    agency_name = "%s"
+    domain_name = "%s"
+  }`, hclStringContent(roleName), hclStringContent(target.DomainName))
+	case "volcengine":
+		return fmt.Sprintf(`
+
+  assume_role {
+    assume_role_trn          = "trn:iam::%s:role/%s"
+    assume_role_session_name = "%s"
+    duration_seconds         = 3600
+  }`, hclStringContent(target.AccountId), hclStringContent(roleName), hclStringContent(sessionName))
+	}
+	reportWarn(config, fmt.Sprintf("云提供商 %s 不支持通过扮演角色访问目标账号，将使用当前凭证部署", provider))
+	return ""
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+func TestGenerateOrganizationConfig(t *testing.T) {
+	denyAll := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":"ec2:*","Resource":"*"}]}`
+	tests := []struct {
+		name      string
+		target    models.TargetAccount
+		units     []models.OrganizationUnit
+		accounts  []models.VendedAccount
+		want      []string
+		wantEmpty bool
+	}{
+		{
+			name:   "management provider for target account",
+			target: models.TargetAccount{AccountId: "123456789012"},
+			units:  []models.OrganizationUnit{{Name: "Workloads"}},
+			want: []string{
+				"alias  = \"management\"",
+				"provider = aws.management",
+			},
+		},
+		{
+			name:  "custom guardrail",
+			units: []models.OrganizationUnit{{Name: "Workloads", Policies: []models.GuardrailPolicy{{Name: "deny-ec2", Document: denyAll}}}},
+			want:  []string{`resource "aws_organizations_policy" "workloads_deny_ec2"`},
+		},
+		{
+			name:      "invalid custom guardrail",
+			units:     []models.OrganizationUnit{{Name: "Workloads", Policies: []models.GuardrailPolicy{{Name: "broken", Document: "{"}}}},
+			wantEmpty: true,
+		},
+		{
+			name:      "duplicate accounts",
+			units:     []models.OrganizationUnit{{Name: "Workloads"}},
+			accounts:  []models.VendedAccount{{Name: "prod", Email: "a@example.com"}, {Name: "prod", Email: "b@example.com"}},
+			wantEmpty: true,
+		},
+		{
+			name:      "colliding account labels",
+			units:     []models.OrganizationUnit{{Name: "Workloads"}},
+			accounts:  []models.VendedAccount{{Name: "prod-app", Email: "a@example.com"}, {Name: "prod_app", Email: "b@example.com"}},
+			wantEmpty: true,
+		},
+		{
+			name:     "account name and email escaped",
+			accounts: []models.VendedAccount{{Name: "prod", Email: `a@example.com" } resource "x" "y" { a = "${file("/etc/passwd")}`}},
+			want:     []string{`email             = "a@example.com\" } resource \"x\" \"y\" { a = \"$${file(\"/etc/passwd\")}"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(models.DeploymentConfig{
+				CloudProvider: "aws",
+				Region:        "us-east-1",
+				TargetAccount: tt.target,
+				Organization:  models.OrganizationConfig{Enabled: true, Units: tt.units, Accounts: tt.accounts},
+			})
+			body := generateOrganizationConfig(config)
+			if tt.wantEmpty {
+				if body != "" {
+					t.Errorf("expected no config, got:\n%s", body)
+				}
+				if !HasErrorFindings(config.findings.Findings()) {
+					t.Errorf("expected an error finding")
+				}
+				return
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestProviderAssumeRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		target   models.TargetAccount
+		want     string
+		wantErr  bool
+	}{
+		{"aws account", "aws", models.TargetAccount{AccountId: "123456789012"}, `role_arn     = "arn:aws:iam::123456789012:role/OrganizationAccountAccessRole"`, false},
+		{"aws account not 12 digits", "aws", models.TargetAccount{AccountId: "1234"}, "", true},
+		{"azure subscription not a guid", "azure", models.TargetAccount{AccountId: `sub" }`}, "", true},
+		{"role and session escaped", "alicloud", models.TargetAccount{AccountId: "1234567890123456", RoleName: `r" }`, SessionName: "${x}"}, `role_arn     = "acs:ram::1234567890123456:role/r\" }"
+    session_name = "$${x}"`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(models.DeploymentConfig{CloudProvider: tt.provider, TargetAccount: tt.target})
+			body := providerAssumeRole(config)
+			if got := HasErrorFindings(config.findings.Findings()); got != tt.wantErr {
+				t.Fatalf("error findings = %v, want %v", got, tt.wantErr)
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("assume role does not contain %q:\n%s", tt.want, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// peeringProviders 支持VPC对等连接组件的云提供商
+var peeringProviders = map[string]bool{
+	"aws":     true,
+	"azure":   true,
+	"huawei":  true,
+	"tencent": true,
+}
+
+// peeringRouteTableProviders 启用子网路由表时，对等连接路由写入子网路由表的云提供商
+// Azure对等连接的路由由系统路由自动下发，不需要额外的路由条目
+var peeringRouteTableProviders = map[string]bool{
+	"aws":     true,
+	"huawei":  true,
+	"tencent": true,
+}
+
+// vpcPeering 表示解析后的VPC对等连接
+type vpcPeering struct {
+	Name      string
+	Label     string
+	Requester models.VPC
+	Accepter  models.VPC
+}
+
+// peeringRoute 表示VPC中指向对等连接的路由
+type peeringRoute struct {
+	DestinationCidr string
+	PeeringLabel    string
+}
+
+// generateVpcPeeringConfig 生成同一部署中VPC之间的对等连接、接受操作和双向路由
+func generateVpcPeeringConfig(config renderContext, propsMap map[string]interface{}) string {
+	if !peeringProviders[config.CloudProvider] {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持VPC对等连接组件", config.CloudProvider))
+		return ""
+	}
+
+	peerings := resolveVpcPeerings(config, propsMap)
+	if len(peerings) == 0 {
+		reportWarn(config, "VPC对等连接组件没有有效的VPC对，未生成对等连接")
+		return ""
+	}
+
+	// 启用子网路由表时，路由由路由表生成器写入各子网路由表，这里只在VPC默认路由表中添加路由
+	withRoutes := !(config.ComponentConfig.EnableRouteTables && peeringRouteTableProviders[config.CloudProvider])
+
+	var peeringConfig strings.Builder
+	if config.CloudProvider == "tencent" {
+		peeringConfig.WriteString(`data "tencentcloud_user_info" "peering" {}
+
+`)
+	}
+
+	for _, peering := range peerings {
+		switch config.CloudProvider {
+		case "aws":
+			peeringConfig.WriteString(generateAwsVpcPeering(peering, withRoutes))
+		case "azure":
+			peeringConfig.WriteString(generateAzureVnetPeering(peering))
+		case "huawei":
+			peeringConfig.WriteString(generateHuaweiVpcPeering(peering, withRoutes))
+		case "tencent":
+			peeringConfig.WriteString(generateTencentVpcPeering(config, peering, withRoutes))
+		}
+		LogInfo(fmt.Sprintf("已生成VPC对等连接配置: 名称=%s, %s <-> %s", peering.Name, peering.Requester.Name, peering.Accepter.Name))
+	}
+	return peeringConfig.String()
+}
+
+// resolveVpcPeerings 解析对等连接的VPC对
+// 优先使用组件属性peerings，其次使用vpc_pairs（如 "0:1,0:2"），最后使用ComponentConfig.VpcPeerings
+func resolveVpcPeerings(config renderContext, propsMap map[string]interface{}) []vpcPeering {
+	var definitions []models.VpcPeering
+	if !decodeListProp(propsMap, "peerings", &definitions) {
+		for _, pair := range getStringListProp(propsMap, "vpc_pairs") {
+			definition, ok := parseVpcPair(pair)
+			if !ok {
+				reportWarn(config, fmt.Sprintf("VPC对 %s 格式无效，应为 请求方索引:接受方索引", pair))
+				continue
+			}
+			definitions = append(definitions, definition)
+		}
+	}
+	if len(definitions) == 0 {
+		definitions = config.ComponentConfig.VpcPeerings
+	}
+
+	vpcs := resolveVpcs(config)
+	var peerings []vpcPeering
+	seen := make(map[string]bool)
+	for _, definition := range definitions {
+		requester, accepter := definition.RequesterVpcIndex, definition.AccepterVpcIndex
+		if requester < 0 || requester >= len(vpcs) || accepter < 0 || accepter >= len(vpcs) {
+			reportWarn(config, fmt.Sprintf("对等连接引用的VPC索引 %d:%d 不存在，已忽略", requester, accepter))
+			continue
+		}
+		if requester == accepter {
+			reportWarn(config, fmt.Sprintf("对等连接的两端不能是同一个VPC %s，已忽略", vpcs[requester].Name))
+			continue
+		}
+
+		pairKey := fmt.Sprintf("%d:%d", requester, accepter)
+		if requester > accepter {
+			pairKey = fmt.Sprintf("%d:%d", accepter, requester)
+		}
+		if seen[pairKey] {
+			reportWarn(config, fmt.Sprintf("VPC %s 和 %s 之间的对等连接重复定义，已忽略", vpcs[requester].Name, vpcs[accepter].Name))
+			continue
+		}
+		if cidrsOverlap(vpcs[requester].CIDR, vpcs[accepter].CIDR) {
+			reportWarn(config, fmt.Sprintf("VPC %s (%s) 和 %s (%s) 的网段重叠，无法建立对等连接", vpcs[requester].Name, vpcs[requester].CIDR, vpcs[accepter].Name, vpcs[accepter].CIDR))
+			continue
+		}
+		seen[pairKey] = true
+
+		peering := vpcPeering{
+			Name:      definition.Name,
+			Label:     fmt.Sprintf("%s_to_%s", vpcs[requester].Name, vpcs[accepter].Name),
+			Requester: vpcs[requester],
+			Accepter:  vpcs[accepter],
+		}
+		if peering.Name == "" {
+			peering.Name = fmt.Sprintf("%s-to-%s", vpcs[requester].Name, vpcs[accepter].Name)
+		}
+		peerings = append(peerings, peering)
+	}
+	return peerings
+}
+
+// parseVpcPair 解析 "请求方索引:接受方索引" 形式的VPC对
+func parseVpcPair(pair string) (models.VpcPeering, bool) {
+	parts := strings.Split(pair, ":")
+	if len(parts) != 2 {
+		return models.VpcPeering{}, false
+	}
+	requester, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return models.VpcPeering{}, false
+	}
+	accepter, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return models.VpcPeering{}, false
+	}
+	return models.VpcPeering{RequesterVpcIndex: requester, AccepterVpcIndex: accepter}, true
+}
+
+// cidrsOverlap 判断两个网段是否重叠，无法解析时视为不重叠
+func cidrsOverlap(a, b string) bool {
+	_, netA, errA := net.ParseCIDR(a)
+	_, netB, errB := net.ParseCIDR(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return netA.Contains(netB.IP) || netB.Contains(netA.IP)
+}
+
+// vpcPeeringRoutes 返回每个VPC中指向对等连接的路由，供子网路由表生成器使用
+// 未启用对等连接组件时返回nil
+func vpcPeeringRoutes(config renderContext) map[string][]peeringRoute {
+	propsMap := componentPropsMap(config, "vpc-peering")
+	if propsMap == nil || !peeringRouteTableProviders[config.CloudProvider] {
+		return nil
+	}
+
+	routes := make(map[string][]peeringRoute)
+	for _, peering := range resolveVpcPeerings(config, propsMap) {
+		routes[peering.Requester.Name] = append(routes[peering.Requester.Name], peeringRoute{DestinationCidr: peering.Accepter.CIDR, PeeringLabel: peering.Label})
+		routes[peering.Accepter.Name] = append(routes[peering.Accepter.Name], peeringRoute{DestinationCidr: peering.Requester.CIDR, PeeringLabel: peering.Label})
+	}
+	return routes
+}
+
+// generateAwsVpcPeering 生成AWS VPC对等连接，同账号同区域的对等连接由请求方自动接受
+func generateAwsVpcPeering(peering vpcPeering, withRoutes bool) string {
+	var peeringConfig strings.Builder
+	peeringConfig.WriteString(fmt.Sprintf(`resource "aws_vpc_peering_connection" "%s" {
+  vpc_id      = %s.id
+  peer_vpc_id = %s.id
+  auto_accept = true
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+`, peering.Label, vpcAddress("aws", peering.Requester.Name), vpcAddress("aws", peering.Accepter.Name), peering.Name))
+
+	if withRoutes {
+		for _, side := range [][2]models.VPC{{peering.Requester, peering.Accepter}, {peering.Accepter, peering.Requester}} {
+			peeringConfig.WriteString(fmt.Sprintf(`resource "aws_route" "%s_%s" {
+  route_table_id            = %s.main_route_table_id
+  destination_cidr_block    = "%s"
+  vpc_peering_connection_id = aws_vpc_peering_connection.%s.id
+}
+
+`, peering.Label, side[0].Name, vpcAddress("aws", side[0].Name), side[1].CIDR, peering.Label))
+		}
+	}
+	return peeringConfig.String()
+}
+
+// generateAzureVnetPeering 生成Azure VNet双向对等连接，对等VNet之间的路由由系统路由自动下发
+func generateAzureVnetPeering(peering vpcPeering) string {
+	var peeringConfig strings.Builder
+	for _, side := range [][2]models.VPC{{peering.Requester, peering.Accepter}, {peering.Accepter, peering.Requester}} {
+		peeringConfig.WriteString(fmt.Sprintf(`resource "azurerm_virtual_network_peering" "%s_%s" {
+  name                         = "%s-%s"
+  resource_group_name          = azurerm_resource_group.rg.name
+  virtual_network_name         = %s.name
+  remote_virtual_network_id    = %s.id
+  allow_virtual_network_access = true
+  allow_forwarded_traffic      = true
+}
+
+`, peering.Label, side[0].Name, peering.Name, side[0].Name, vpcAddress("azure", side[0].Name), vpcAddress("azure", side[1].Name)))
+	}
+	return peeringConfig.String()
+}
+
+// generateHuaweiVpcPeering 生成华为云VPC对等连接，同租户的对等连接创建后自动接受
+func generateHuaweiVpcPeering(peering vpcPeering, withRoutes bool) string {
+	var peeringConfig strings.Builder
+	peeringConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_vpc_peering_connection" "%s" {
+  name        = "%s"
+  vpc_id      = %s.id
+  peer_vpc_id = %s.id
+}
+
+`, peering.Label, peering.Name, vpcAddress("huawei", peering.Requester.Name), vpcAddress("huawei", peering.Accepter.Name)))
+
+	if withRoutes {
+		for _, side := range [][2]models.VPC{{peering.Requester, peering.Accepter}, {peering.Accepter, peering.Requester}} {
+			peeringConfig.WriteString(fmt.Sprintf(`resource "huaweicloud_vpc_route" "%s_%s" {
+  vpc_id      = %s.id
+  destination = "%s"
+  type        = "peering"
+  nexthop     = huaweicloud_vpc_peering_connection.%s.id
+}
+
+`, peering.Label, side[0].Name, vpcAddress("huawei", side[0].Name), side[1].CIDR, peering.Label))
+		}
+	}
+	return peeringConfig.String()
+}
+
+// generateTencentVpcPeering 生成腾讯云对等连接及接受操作，接受方为同一账号下的VPC
+func generateTencentVpcPeering(config renderContext, peering vpcPeering, withRoutes bool) string {
+	var peeringConfig strings.Builder
+	peeringConfig.WriteString(fmt.Sprintf(`resource "tencentcloud_vpc_peer_connect_manager" "%s" {
+  peering_connection_name = "%s"
+  source_vpc_id           = %s.id
+  destination_vpc_id      = %s.id
+  destination_uin         = data.tencentcloud_user_info.peering.owner_uin
+  destination_region      = "%s"
+}
+
+resource "tencentcloud_vpc_peer_connect_accept_operation" "%s" {
+  peering_connection_id = tencentcloud_vpc_peer_connect_manager.%s.id
+}
+
+`, peering.Label, peering.Name, vpcAddress("tencent", peering.Requester.Name), vpcAddress("tencent", peering.Accepter.Name), config.Region,
+		peering.Label, peering.Label))
+
+	if withRoutes {
+		for _, side := range [][2]models.VPC{{peering.Requester, peering.Accepter}, {peering.Accepter, peering.Requester}} {
+			peeringConfig.WriteString(fmt.Sprintf(`resource "tencentcloud_route_table_entry" "%s_%s" {
+  route_table_id         = %s.default_route_table_id
+  destination_cidr_block = "%s"
+  next_type              = "PEERCONNECTION"
+  next_hub               = tencentcloud_vpc_peer_connect_manager.%s.id
+  description            = "Route to peer VPC %s"
+
+  depends_on = [tencentcloud_vpc_peer_connect_accept_operation.%s]
+}
+
+`, peering.Label, side[0].Name, vpcAddress("tencent", side[0].Name), side[1].CIDR, peering.Label, side[1].Name, peering.Label))
+		}
+	}
+	return peeringConfig.String()
+}
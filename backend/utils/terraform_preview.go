@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"sync"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// RenderResult 渲染部署的结果，包含模块文件、预测的拓扑图和生成过程中发现的问题
+type RenderResult struct {
+	Files    map[string]string          `json:"files"`
+	Topology map[string]interface{}     `json:"topology"`
+	Findings []models.ValidationFinding `json:"findings"`
+}
+
+// RenderDeployment 渲染部署的Terraform模块和拓扑图，不写入磁盘
+// 每次渲染创建自己的问题收集器，生成器通过reportWarn和reportError记录的问题随结果返回，部署和预览都通过这里渲染
+func RenderDeployment(config models.DeploymentConfig) RenderResult {
+	ctx := renderContext{DeploymentConfig: config, findings: &findingCollector{}}
+	findings := []models.ValidationFinding{}
+	if err := validateDeploymentTags(ctx); err != nil {
+		findings = append(findings, models.ValidationFinding{Severity: "error", Source: "tags", Message: err.Error()})
+	}
+
+	files := renderTerraformModule(ctx)
+	topology := generateTopology(ctx)
+	findings = append(findings, ctx.findings.Findings()...)
+	return RenderResult{Files: files, Topology: topology, Findings: findings}
+}
+
+// HasErrorFindings 判断问题中是否包含错误，包含错误的渲染结果不能部署
+func HasErrorFindings(findings []models.ValidationFinding) bool {
+	for _, finding := range findings {
+		if finding.Severity == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// renderContext 一次渲染请求中生成器使用的配置，携带请求的问题收集器
+// 多云部署的分段和跨云引用的对端通过withConfig共享同一个收集器
+type renderContext struct {
+	models.DeploymentConfig
+	findings *findingCollector // 为空时只记录日志
+}
+
+// withConfig 返回使用另一个配置、共享同一问题收集器的渲染上下文
+func (config renderContext) withConfig(other models.DeploymentConfig) renderContext {
+	return renderContext{DeploymentConfig: other, findings: config.findings}
+}
+
+// findingCollector 收集一次渲染请求中发现的问题，由RenderDeployment创建
+type findingCollector struct {
+	mutex    sync.Mutex
+	findings []models.ValidationFinding
+}
+
+// Add 记录一个问题，收集器为空或已记录相同的问题时忽略，拓扑图和模块渲染会重复解析同一配置
+func (c *findingCollector) Add(finding models.ValidationFinding) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, existing := range c.findings {
+		if existing == finding {
+			return
+		}
+	}
+	c.findings = append(c.findings, finding)
+}
+
+// Findings 返回已记录的问题
+func (c *findingCollector) Findings() []models.ValidationFinding {
+	if c == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]models.ValidationFinding{}, c.findings...)
+}
+
+// reportWarn 记录警告日志，并作为问题记录到配置所属的渲染请求中
+func reportWarn(config renderContext, message string) {
+	LogWarn(message)
+	config.findings.Add(models.ValidationFinding{Severity: "warning", Source: "generator", Message: message})
+}
+
+// reportError 记录错误日志，并作为问题记录到配置所属的渲染请求中，包含错误的渲染结果不能部署
+func reportError(config renderContext, message string) {
+	LogError(message)
+	config.findings.Add(models.ValidationFinding{Severity: "error", Source: "generator", Message: message})
+}
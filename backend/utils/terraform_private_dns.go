@@ -0,0 +1,364 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// privateDnsProviders 支持私有DNS组件的云提供商
+var privateDnsProviders = map[string]bool{
+	"aws":      true,
+	"azure":    true,
+	"alicloud": true,
+	"tencent":  true,
+}
+
+// privateDnsRecordTypes 私有DNS组件支持的记录类型
+var privateDnsRecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+	"TXT":   true,
+}
+
+// dnsZoneNameRegexp 校验私有DNS区域名称，例如 corp.internal
+var dnsZoneNameRegexp = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// dnsRecordNameRegexp 校验相对于区域的主机记录，支持通配符和多级主机记录
+var dnsRecordNameRegexp = regexp.MustCompile(`^(@|(\*\.)?[a-z0-9_]([a-z0-9_-]*[a-z0-9_])?(\.[a-z0-9_]([a-z0-9_-]*[a-z0-9_])?)*|\*)$`)
+
+// privateDnsZone 表示解析后的私有DNS区域
+type privateDnsZone struct {
+	Name    string
+	Label   string
+	Vpcs    []models.VPC
+	Records []privateDnsRecord
+}
+
+// privateDnsRecord 表示校验后的解析记录，Label在区域内唯一
+type privateDnsRecord struct {
+	models.DnsRecord
+	Label string
+}
+
+// generatePrivateDnsConfig 生成私有DNS区域、区域与VPC的关联以及解析记录
+func generatePrivateDnsConfig(config renderContext, propsMap map[string]interface{}) string {
+	if !privateDnsProviders[config.CloudProvider] {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持私有DNS组件", config.CloudProvider))
+		return ""
+	}
+
+	zone, ok := resolvePrivateDnsZone(config, propsMap)
+	if !ok {
+		return ""
+	}
+
+	var dnsConfig string
+	switch config.CloudProvider {
+	case "aws":
+		dnsConfig = generateAwsPrivateDns(zone)
+	case "azure":
+		dnsConfig = generateAzurePrivateDns(zone)
+	case "alicloud":
+		dnsConfig = generateAlicloudPrivateZone(zone)
+	case "tencent":
+		dnsConfig = generateTencentPrivateDns(config, zone)
+	}
+	LogInfo(fmt.Sprintf("已生成私有DNS配置: 区域=%s, 关联VPC=%d, 记录=%d", zone.Name, len(zone.Vpcs), len(zone.Records)))
+	return dnsConfig
+}
+
+// resolvePrivateDnsZone 解析区域名称、关联的VPC和解析记录
+// 未指定vpcs时关联部署中的所有VPC，vpcs可以是VPC名称或索引
+func resolvePrivateDnsZone(config renderContext, propsMap map[string]interface{}) (privateDnsZone, bool) {
+	zone := privateDnsZone{
+		Name: strings.TrimSuffix(strings.ToLower(strings.TrimSpace(getStringProp(propsMap, "zone_name", "landing-zone.internal"))), "."),
+	}
+	if !dnsZoneNameRegexp.MatchString(zone.Name) {
+		reportError(config, fmt.Sprintf("私有DNS区域名称 %s 无效，未生成私有DNS配置", zone.Name))
+		return zone, false
+	}
+	zone.Label = strings.NewReplacer(".", "_", "-", "_").Replace(zone.Name)
+	if !terraformIdentifierRegexp.MatchString(zone.Label) {
+		zone.Label = "zone_" + zone.Label
+	}
+
+	vpcs := resolveVpcs(config)
+	refs := getStringListProp(propsMap, "vpcs")
+	if len(refs) == 0 {
+		zone.Vpcs = vpcs
+	}
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		index, ok := resolveVpcReference(config, ref)
+		if !ok {
+			reportWarn(config, fmt.Sprintf("私有DNS关联的VPC %s 不存在，已忽略", ref))
+			continue
+		}
+		if !seen[vpcs[index].Name] {
+			seen[vpcs[index].Name] = true
+			zone.Vpcs = append(zone.Vpcs, vpcs[index])
+		}
+	}
+	if len(zone.Vpcs) == 0 {
+		reportError(config, fmt.Sprintf("私有DNS区域 %s 没有可关联的VPC，未生成私有DNS配置", zone.Name))
+		return zone, false
+	}
+
+	var records []models.DnsRecord
+	decodeListProp(propsMap, "records", &records)
+	defaultTTL := getIntProp(propsMap, "ttl", 300)
+	labels := make(map[string]bool)
+	for _, record := range records {
+		record.Name = strings.ToLower(strings.TrimSpace(record.Name))
+		record.Type = strings.ToUpper(strings.TrimSpace(record.Type))
+		if record.Name == "" {
+			record.Name = "@"
+		}
+		if !dnsRecordNameRegexp.MatchString(record.Name) {
+			reportWarn(config, fmt.Sprintf("解析记录名称 %s 无效，已忽略", record.Name))
+			continue
+		}
+		if !privateDnsRecordTypes[record.Type] {
+			reportWarn(config, fmt.Sprintf("解析记录 %s 的类型 %s 不受支持，仅支持A、AAAA、CNAME和TXT", record.Name, record.Type))
+			continue
+		}
+		var values []string
+		for _, value := range record.Values {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		if len(values) == 0 {
+			reportWarn(config, fmt.Sprintf("解析记录 %s %s 没有记录值，已忽略", record.Name, record.Type))
+			continue
+		}
+		if record.Type == "CNAME" && len(values) > 1 {
+			reportWarn(config, fmt.Sprintf("CNAME记录 %s 只能有一个记录值，仅保留 %s", record.Name, values[0]))
+			values = values[:1]
+		}
+		if err := validateDnsRecordValues(record.Type, values); err != nil {
+			reportError(config, fmt.Sprintf("解析记录 %s %s 无效，已忽略: %v", record.Name, record.Type, err))
+			continue
+		}
+		record.Values = values
+		if record.TTL <= 0 {
+			record.TTL = defaultTTL
+		}
+
+		host := strings.NewReplacer("@", "apex", "*", "wildcard", ".", "_", "-", "_").Replace(record.Name)
+		label := host + "_" + strings.ToLower(record.Type)
+		// 主机记录可以以数字开头，Terraform资源名称必须以字母或下划线开头
+		if !terraformIdentifierRegexp.MatchString(label) {
+			label = "record_" + label
+		}
+		if labels[label] {
+			reportWarn(config, fmt.Sprintf("解析记录 %s %s 重复定义，已忽略", record.Name, record.Type))
+			continue
+		}
+		labels[label] = true
+		zone.Records = append(zone.Records, privateDnsRecord{DnsRecord: record, Label: label})
+	}
+	return zone, true
+}
+
+// validateDnsRecordValues 校验A记录的值为IPv4地址，AAAA记录的值为IPv6地址
+func validateDnsRecordValues(recordType string, values []string) error {
+	for _, value := range values {
+		ip := net.ParseIP(value)
+		switch recordType {
+		case "A":
+			if ip == nil || ip.To4() == nil {
+				return fmt.Errorf("记录值 %s 不是有效的IPv4地址", value)
+			}
+		case "AAAA":
+			if ip == nil || ip.To4() != nil {
+				return fmt.Errorf("记录值 %s 不是有效的IPv6地址", value)
+			}
+		}
+	}
+	return nil
+}
+
+// recordFqdn 返回解析记录的完整域名
+func recordFqdn(zone privateDnsZone, record privateDnsRecord) string {
+	if record.Name == "@" {
+		return zone.Name
+	}
+	return record.Name + "." + zone.Name
+}
+
+// generateAwsPrivateDns 生成Route 53私有托管区域，区域通过vpc块关联VPC
+func generateAwsPrivateDns(zone privateDnsZone) string {
+	var dns strings.Builder
+
+	vpcBlocks := ""
+	for _, vpc := range zone.Vpcs {
+		vpcBlocks += fmt.Sprintf(`
+
+  vpc {
+    vpc_id = %s.id
+  }`, vpcAddress("aws", vpc.Name))
+	}
+	dns.WriteString(fmt.Sprintf(`resource "aws_route53_zone" "%s" {
+  name    = "%s"
+  comment = "Private hosted zone created by multi-cloud landing zone platform"%s
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+`, zone.Label, zone.Name, vpcBlocks, zone.Name))
+
+	for _, record := range zone.Records {
+		dns.WriteString(fmt.Sprintf(`resource "aws_route53_record" "%s_%s" {
+  zone_id = aws_route53_zone.%s.zone_id
+  name    = "%s"
+  type    = "%s"
+  ttl     = %d
+  records = %s
+}
+
+`, zone.Label, record.Label, zone.Label, recordFqdn(zone, record), record.Type, record.TTL, hclStringList(record.Values)))
+	}
+	return dns.String()
+}
+
+// generateAzurePrivateDns 生成Azure专用DNS区域、VNet链接和解析记录
+// 每种记录类型对应不同的资源类型，CNAME和TXT的记录值写法与A记录不同
+func generateAzurePrivateDns(zone privateDnsZone) string {
+	var dns strings.Builder
+	dns.WriteString(fmt.Sprintf(`resource "azurerm_private_dns_zone" "%s" {
+  name                = "%s"
+  resource_group_name = azurerm_resource_group.rg.name
+
+  tags = local.common_tags
+}
+
+`, zone.Label, zone.Name))
+
+	for _, vpc := range zone.Vpcs {
+		dns.WriteString(fmt.Sprintf(`resource "azurerm_private_dns_zone_virtual_network_link" "%s_%s" {
+  name                  = "%s-link"
+  resource_group_name   = azurerm_resource_group.rg.name
+  private_dns_zone_name = azurerm_private_dns_zone.%s.name
+  virtual_network_id    = %s.id
+  registration_enabled  = false
+
+  tags = local.common_tags
+}
+
+`, zone.Label, vpc.Name, vpc.Name, zone.Label, vpcAddress("azure", vpc.Name)))
+	}
+
+	for _, record := range zone.Records {
+		var value string
+		switch record.Type {
+		case "CNAME":
+			value = fmt.Sprintf("\n  record              = %s", hclString(record.Values[0]))
+		case "TXT":
+			for _, item := range record.Values {
+				value += fmt.Sprintf("\n\n  record {\n    value = %s\n  }", hclString(item))
+			}
+		default:
+			value = fmt.Sprintf("\n  records             = %s", hclStringList(record.Values))
+		}
+		dns.WriteString(fmt.Sprintf(`resource "azurerm_private_dns_%s_record" "%s_%s" {
+  name                = "%s"
+  zone_name           = azurerm_private_dns_zone.%s.name
+  resource_group_name = azurerm_resource_group.rg.name
+  ttl                 = %d%s
+}
+
+`, strings.ToLower(record.Type), zone.Label, record.Label, record.Name, zone.Label, record.TTL, value))
+	}
+	return dns.String()
+}
+
+// generateAlicloudPrivateZone 生成阿里云PrivateZone区域、VPC关联和解析记录
+// PrivateZone的每条记录只有一个记录值，多个记录值拆分为多条记录
+func generateAlicloudPrivateZone(zone privateDnsZone) string {
+	var dns strings.Builder
+
+	vpcIds := make([]string, 0, len(zone.Vpcs))
+	for _, vpc := range zone.Vpcs {
+		vpcIds = append(vpcIds, vpcAddress("alicloud", vpc.Name)+".id")
+	}
+	dns.WriteString(fmt.Sprintf(`resource "alicloud_pvtz_zone" "%s" {
+  zone_name = "%s"
+  remark    = "Private zone created by multi-cloud landing zone platform"
+}
+
+resource "alicloud_pvtz_zone_attachment" "%s" {
+  zone_id = alicloud_pvtz_zone.%s.id
+  vpc_ids = %s
+}
+
+`, zone.Label, zone.Name, zone.Label, zone.Label, hclList(vpcIds)))
+
+	for _, record := range zone.Records {
+		for i, value := range record.Values {
+			label := record.Label
+			if len(record.Values) > 1 {
+				label = fmt.Sprintf("%s_%d", record.Label, i+1)
+			}
+			dns.WriteString(fmt.Sprintf(`resource "alicloud_pvtz_zone_record" "%s_%s" {
+  zone_id = alicloud_pvtz_zone.%s.id
+  rr      = "%s"
+  type    = "%s"
+  value   = %s
+  ttl     = %d
+}
+
+`, zone.Label, label, zone.Label, record.Name, record.Type, hclString(value), record.TTL))
+		}
+	}
+	return dns.String()
+}
+
+// generateTencentPrivateDns 生成腾讯云私有域、VPC关联和解析记录
+// 腾讯云私有域的每条记录只有一个记录值，多个记录值拆分为多条记录
+func generateTencentPrivateDns(config renderContext, zone privateDnsZone) string {
+	var dns strings.Builder
+
+	vpcSets := ""
+	for _, vpc := range zone.Vpcs {
+		vpcSets += fmt.Sprintf(`
+
+  vpc_set {
+    region      = "%s"
+    uniq_vpc_id = %s.id
+  }`, config.Region, vpcAddress("tencent", vpc.Name))
+	}
+	dns.WriteString(fmt.Sprintf(`resource "tencentcloud_private_dns_zone" "%s" {
+  domain             = "%s"
+  remark             = "Private zone created by multi-cloud landing zone platform"
+  dns_forward_status = "DISABLED"%s
+}
+
+`, zone.Label, zone.Name, vpcSets))
+
+	for _, record := range zone.Records {
+		for i, value := range record.Values {
+			label := record.Label
+			if len(record.Values) > 1 {
+				label = fmt.Sprintf("%s_%d", record.Label, i+1)
+			}
+			dns.WriteString(fmt.Sprintf(`resource "tencentcloud_private_dns_record" "%s_%s" {
+  zone_id      = tencentcloud_private_dns_zone.%s.id
+  sub_domain   = "%s"
+  record_type  = "%s"
+  record_value = %s
+  ttl          = %d
+}
+
+`, zone.Label, label, zone.Label, record.Name, record.Type, hclString(value), record.TTL))
+		}
+	}
+	return dns.String()
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePrivateDnsConfigRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		zone    string
+		records string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "valid records",
+			zone:    "corp.internal",
+			records: `[{"name":"app","type":"A","values":["10.0.1.10"]},{"name":"app","type":"AAAA","values":["fd00::10"]}]`,
+			want:    []string{`resource "aws_route53_record" "corp_internal_app_a"`, `resource "aws_route53_record" "corp_internal_app_aaaa"`},
+		},
+		{
+			name:    "labels start with a letter",
+			zone:    "1corp.internal",
+			records: `[{"name":"1app","type":"A","values":["10.0.1.10"]}]`,
+			want:    []string{`resource "aws_route53_zone" "zone_1corp_internal"`, `resource "aws_route53_record" "zone_1corp_internal_record_1app_a"`},
+		},
+		{
+			name:    "a record with hostname",
+			zone:    "corp.internal",
+			records: `[{"name":"app","type":"A","values":["app.example.com"]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "aaaa record with ipv4 address",
+			zone:    "corp.internal",
+			records: `[{"name":"app","type":"AAAA","values":["10.0.1.10"]}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig("aws", "us-east-1"))
+			props := map[string]interface{}{"zone_name": tt.zone, "records": tt.records}
+			body := generatePrivateDnsConfig(config, props)
+			if got := HasErrorFindings(config.findings.Findings()); got != tt.wantErr {
+				t.Fatalf("error findings = %v, want %v: %v", got, tt.wantErr, config.findings.Findings())
+			}
+			if tt.wantErr && strings.Contains(body, "aws_route53_record") {
+				t.Errorf("invalid record should not be generated:\n%s", body)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratePrivateDnsConfigTxtEscaping(t *testing.T) {
+	records := `[{"name":"verify","type":"TXT","values":["v=1 \"quoted\" ${var.secret}"]}]`
+	tests := []struct {
+		provider string
+		region   string
+		want     string
+	}{
+		{"azure", "eastus", `value = "v=1 \"quoted\" $${var.secret}"`},
+		{"alicloud", "cn-hangzhou", `value   = "v=1 \"quoted\" $${var.secret}"`},
+		{"tencent", "ap-guangzhou", `record_value = "v=1 \"quoted\" $${var.secret}"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig(tt.provider, tt.region))
+			props := map[string]interface{}{"zone_name": "corp.internal", "records": records}
+			body := generatePrivateDnsConfig(config, props)
+			if HasErrorFindings(config.findings.Findings()) {
+				t.Fatalf("unexpected error findings: %v", config.findings.Findings())
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("config does not contain %q:\n%s", tt.want, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// vpcResourceTypes 各云提供商VPC对应的Terraform资源类型
+var vpcResourceTypes = map[string]string{
+	"aws":        "aws_vpc",
+	"azure":      "azurerm_virtual_network",
+	"alicloud":   "alicloud_vpc",
+	"baidu":      "baiducloud_vpc",
+	"huawei":     "huaweicloud_vpc",
+	"tencent":    "tencentcloud_vpc",
+	"volcengine": "volcengine_vpc",
+}
+
+// subnetResourceTypes 各云提供商子网对应的Terraform资源类型
+var subnetResourceTypes = map[string]string{
+	"aws":        "aws_subnet",
+	"azure":      "azurerm_subnet",
+	"alicloud":   "alicloud_vswitch",
+	"baidu":      "baiducloud_subnet",
+	"huawei":     "huaweicloud_vpc_subnet",
+	"tencent":    "tencentcloud_subnet",
+	"volcengine": "volcengine_subnet",
+}
+
+// propertyTokenRegexp 实例规格、版本号等标识类组件属性允许的字符
+var propertyTokenRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// securityGroupResourceTypes 各云提供商安全组对应的Terraform资源类型
+var securityGroupResourceTypes = map[string]string{
+	"aws":        "aws_security_group",
+	"azure":      "azurerm_network_security_group",
+	"alicloud":   "alicloud_security_group",
+	"baidu":      "baiducloud_security_group",
+	"huawei":     "huaweicloud_networking_secgroup",
+	"tencent":    "tencentcloud_security_group",
+	"volcengine": "volcengine_security_group",
+}
+
+// resolveVpcs 返回当前配置实际生成的VPC列表
+func resolveVpcs(config renderContext) []models.VPC {
+	if len(config.AllVpcs) > 0 {
+		return config.AllVpcs
+	}
+	return []models.VPC{config.VPC}
+}
+
+// resolveSubnets 返回当前配置实际生成的子网列表
+func resolveSubnets(config renderContext) []models.Subnet {
+	if len(config.AllSubnets) > 0 {
+		return config.AllSubnets
+	}
+	return []models.Subnet{config.Subnet}
+}
+
+// selectSubnets 按名称从已生成的子网中筛选，未指定名称时返回全部子网
+func selectSubnets(config renderContext, names []string) []models.Subnet {
+	subnets := resolveSubnets(config)
+	if len(names) == 0 {
+		return subnets
+	}
+
+	var selected []models.Subnet
+	for _, name := range names {
+		found := false
+		for _, subnet := range subnets {
+			if matchesLogicalName(name, subnet.Name, subnet.LogicalID) {
+				selected = append(selected, subnet)
+				found = true
+				break
+			}
+		}
+		if !found {
+			reportWarn(config, fmt.Sprintf("未找到名称为 %s 的子网，已忽略", name))
+		}
+	}
+
+	if len(selected) == 0 {
+		return subnets
+	}
+	return selected
+}
+
+// selectPrivateSubnets 按名称筛选私有子网，未指定名称时返回全部私有子网
+// 公有子网和不属于第一个子网所在VPC的子网会被忽略，没有可用的私有子网时返回nil
+func selectPrivateSubnets(config renderContext, names []string) []models.Subnet {
+	var candidates []models.Subnet
+	if len(names) > 0 {
+		candidates = selectSubnets(config, names)
+	} else {
+		candidates = resolveSubnets(config)
+	}
+
+	var selected []models.Subnet
+	for _, subnet := range candidates {
+		if subnetTier(subnet) != "private" {
+			if len(names) > 0 {
+				reportWarn(config, fmt.Sprintf("子网 %s 不是私有子网，已忽略", subnet.Name))
+			}
+			continue
+		}
+		if len(selected) > 0 && subnetVpc(config, subnet).Name != subnetVpc(config, selected[0]).Name {
+			if len(names) > 0 {
+				reportWarn(config, fmt.Sprintf("子网 %s 不属于VPC %s，已忽略", subnet.Name, subnetVpc(config, selected[0]).Name))
+			}
+			continue
+		}
+		selected = append(selected, subnet)
+	}
+	return selected
+}
+
+// subnetVpc 返回子网所属的VPC
+func subnetVpc(config renderContext, subnet models.Subnet) models.VPC {
+	vpcs := resolveVpcs(config)
+	if subnet.VpcIndex >= 0 && subnet.VpcIndex < len(vpcs) {
+		return vpcs[subnet.VpcIndex]
+	}
+	return vpcs[0]
+}
+
+// subnetZone 返回子网所在的可用区
+func subnetZone(config renderContext, subnet models.Subnet) string {
+	if config.CloudProvider == "aws" {
+		actualAZ := getActualAwsAZ(config.Region, subnet.AZ)
+		if subnet.AZ == "" {
+			actualAZ = getActualAwsAZ(config.Region, config.AZ)
+		}
+		return actualAZ
+	}
+	if subnet.AZ != "" {
+		return subnet.AZ
+	}
+	return config.AZ
+}
+
+// vpcAddress 返回VPC资源的Terraform地址，例如 aws_vpc.main
+func vpcAddress(provider, vpcName string) string {
+	resourceType, ok := vpcResourceTypes[provider]
+	if !ok {
+		resourceType = provider + "_vpc"
+	}
+	return fmt.Sprintf("%s.%s", resourceType, vpcName)
+}
+
+// subnetAddress 返回子网资源的Terraform地址，例如 alicloud_vswitch.app
+func subnetAddress(provider, subnetName string) string {
+	resourceType, ok := subnetResourceTypes[provider]
+	if !ok {
+		resourceType = provider + "_subnet"
+	}
+	return fmt.Sprintf("%s.%s", resourceType, subnetName)
+}
+
+// securityGroupAddress 返回安全组资源的Terraform地址，例如 aws_security_group.web
+func securityGroupAddress(provider, groupName string) string {
+	return fmt.Sprintf("%s.%s", securityGroupResourceTypes[provider], groupName)
+}
+
+// hclList 将表达式列表渲染为HCL列表
+func hclList(items []string) string {
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+// hclStringList 将字符串列表渲染为带引号的HCL列表
+func hclStringList(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, item := range items {
+		quoted = append(quoted, hclString(item))
+	}
+	return hclList(quoted)
+}
+
+// hclString 将字符串渲染为带引号的HCL字符串
+// 按HCL的转义规则处理引号、反斜杠和控制字符，并将 ${ 和 %{ 转义为 $${ 和 %%{，避免用户输入被当作模板执行
+// 不能使用Go的%q：Go会输出HCL不支持的\x转义
+func hclString(value string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for i, r := range value {
+		switch {
+		case r == '"':
+			quoted.WriteString(`\"`)
+		case r == '\\':
+			quoted.WriteString(`\\`)
+		case r == '\n':
+			quoted.WriteString(`\n`)
+		case r == '\r':
+			quoted.WriteString(`\r`)
+		case r == '\t':
+			quoted.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(value[i+1:], "{"):
+			quoted.WriteRune(r)
+			quoted.WriteRune(r)
+		case r == utf8.RuneError || unicode.IsControl(r):
+			quoted.WriteString(fmt.Sprintf(`\u%04X`, r))
+		default:
+			quoted.WriteRune(r)
+		}
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
+
+// hclStringContent 返回hclString转义后不带引号的内容，用于拼接到包含插值的HCL字符串中，例如 "%s-${count.index + 1}"
+func hclStringContent(value string) string {
+	quoted := hclString(value)
+	return quoted[1 : len(quoted)-1]
+}
+
+// checkPropertyToken 检查实例规格、版本号等标识类组件属性只包含字母、数字、点、下划线和连字符，
+// 这类属性不需要其他字符，不符合时返回错误而不是渲染到配置中
+func checkPropertyToken(key, value string) error {
+	if !propertyTokenRegexp.MatchString(value) {
+		return fmt.Errorf("属性 %s 的值 %q 无效，只能包含字母、数字、点、下划线和连字符", key, value)
+	}
+	return nil
+}
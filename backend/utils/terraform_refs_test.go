@@ -0,0 +1,26 @@
+package utils
+
+import "testing"
+
+func TestHclString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "landing-zone", `"landing-zone"`},
+		{"quote and backslash", `say "hi" \ bye`, `"say \"hi\" \\ bye"`},
+		{"template interpolation", "${var.secret}", `"$${var.secret}"`},
+		{"template directive", "%{if true}x%{endif}", `"%%{if true}x%%{endif}"`},
+		{"dollar without brace", "cost $5", `"cost $5"`},
+		{"control characters", "a\nb\tc\x01", `"a\nb\tc\u0001"`},
+		{"non-ascii", "生产环境", `"生产环境"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hclString(tt.value); got != tt.want {
+				t.Errorf("hclString(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,691 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// bucketNameRegexp 用于去除日志存储桶名称中的非法字符
+var bucketNameRegexp = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// securityBaseline 表示规范化后的安全基线配置
+type securityBaseline struct {
+	BucketName    string
+	NamePrefix    string // 操作审计跟踪、配置记录器和服务角色的名称前缀，同一账号中多次部署时避免名称冲突
+	RetentionDays int
+}
+
+// baselineNamePrefixMaxLength 名称前缀的最大长度，阿里云操作审计跟踪名称最长36个字符
+const baselineNamePrefixMaxLength = 28
+
+// huaweiLtsMaxTTL 华为云日志组最长保留365天
+const huaweiLtsMaxTTL = 365
+
+// generateSecurityBaselineConfig 生成安全基线：加密日志存储桶、操作审计、配置记录和每个VPC的流日志
+func generateSecurityBaselineConfig(config renderContext) string {
+	baseline := resolveSecurityBaseline(config)
+
+	var baselineConfig string
+	switch config.CloudProvider {
+	case "aws":
+		baselineConfig = generateAwsSecurityBaseline(config, baseline)
+	case "azure":
+		baselineConfig = generateAzureSecurityBaseline(config, baseline)
+	case "alicloud":
+		baselineConfig = generateAlicloudSecurityBaseline(config, baseline)
+	case "huawei":
+		baselineConfig = generateHuaweiSecurityBaseline(config, baseline)
+	case "tencent":
+		baselineConfig = generateTencentSecurityBaseline(config, baseline)
+	default:
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持安全基线，未生成审计日志配置", config.CloudProvider))
+		return ""
+	}
+
+	LogInfo(fmt.Sprintf("已生成安全基线配置: 日志存储桶=%s, 保留天数=%d, VPC流日志=%d", baseline.BucketName, baseline.RetentionDays, len(resolveVpcs(config))))
+	return baselineConfig
+}
+
+// resolveSecurityBaseline 规范化日志存储桶名称和保留天数
+func resolveSecurityBaseline(config renderContext) securityBaseline {
+	baseline := securityBaseline{
+		BucketName:    config.SecurityBaseline.LogBucketName,
+		RetentionDays: config.SecurityBaseline.LogRetentionDays,
+	}
+	vpcs := resolveVpcs(config)
+	prefix := "landing-zone"
+	if len(vpcs) > 0 && vpcs[0].Name != "" {
+		prefix = vpcs[0].Name
+	}
+	if baseline.BucketName == "" {
+		baseline.BucketName = fmt.Sprintf("%s-audit-logs-%s", prefix, config.Region)
+	}
+	baseline.NamePrefix = baselineNamePrefix(config, prefix)
+	baseline.BucketName = strings.Trim(bucketNameRegexp.ReplaceAllString(strings.ToLower(strings.ReplaceAll(baseline.BucketName, "_", "-")), "-"), "-")
+	if len(baseline.BucketName) > 63 {
+		baseline.BucketName = strings.Trim(baseline.BucketName[:63], "-")
+	}
+	if baseline.RetentionDays <= 0 {
+		baseline.RetentionDays = 365
+	}
+	return baseline
+}
+
+// baselineNamePrefix 按命名规则生成审计资源的名称前缀，并追加部署ID保证同一账号中多次部署时名称唯一
+// 部署ID保持完整，超长时截断前面的名称部分
+func baselineNamePrefix(config renderContext, name string) string {
+	prefix := strings.Trim(bucketNameRegexp.ReplaceAllString(strings.ToLower(strings.ReplaceAll(renderDisplayName(config, "audit", name, ""), "_", "-")), "-"), "-")
+	suffix := strings.Trim(bucketNameRegexp.ReplaceAllString(strings.ToLower(config.DeploymentID), "-"), "-")
+	maxLength := baselineNamePrefixMaxLength
+	if suffix != "" {
+		if len(suffix) > maxLength/2 {
+			suffix = suffix[len(suffix)-maxLength/2:]
+		}
+		maxLength -= len(suffix) + 1
+	}
+	if len(prefix) > maxLength {
+		prefix = strings.Trim(prefix[:maxLength], "-")
+	}
+	if prefix == "" || prefix[0] < 'a' || prefix[0] > 'z' {
+		prefix = strings.Trim("lz-"+prefix, "-")
+		if len(prefix) > maxLength {
+			prefix = strings.Trim(prefix[:maxLength], "-")
+		}
+	}
+	if suffix != "" {
+		prefix += "-" + suffix
+	}
+	return prefix
+}
+
+// generateAwsSecurityBaseline 生成CloudTrail、AWS Config和VPC流日志，日志写入KMS加密的S3存储桶
+func generateAwsSecurityBaseline(config renderContext, baseline securityBaseline) string {
+	var aws strings.Builder
+
+	aws.WriteString(fmt.Sprintf(`data "aws_caller_identity" "audit" {}
+
+resource "aws_kms_key" "audit_logs" {
+  description         = "Encrypts landing zone audit logs"
+  enable_key_rotation = true
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "AccountAdministration"
+        Effect    = "Allow"
+        Principal = { AWS = "arn:aws:iam::${data.aws_caller_identity.audit.account_id}:root" }
+        Action    = "kms:*"
+        Resource  = "*"
+      },
+      {
+        Sid       = "AllowLogDelivery"
+        Effect    = "Allow"
+        Principal = { Service = ["cloudtrail.amazonaws.com", "config.amazonaws.com", "delivery.logs.amazonaws.com"] }
+        Action    = ["kms:GenerateDataKey*", "kms:Decrypt", "kms:DescribeKey"]
+        Resource  = "*"
+      }
+    ]
+  })
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+resource "aws_s3_bucket" "audit_logs" {
+  bucket = "%s"
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+resource "aws_s3_bucket_public_access_block" "audit_logs" {
+  bucket                  = aws_s3_bucket.audit_logs.id
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+resource "aws_s3_bucket_versioning" "audit_logs" {
+  bucket = aws_s3_bucket.audit_logs.id
+
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "audit_logs" {
+  bucket = aws_s3_bucket.audit_logs.id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm     = "aws:kms"
+      kms_master_key_id = aws_kms_key.audit_logs.arn
+    }
+    bucket_key_enabled = true
+  }
+}
+
+resource "aws_s3_bucket_lifecycle_configuration" "audit_logs" {
+  bucket = aws_s3_bucket.audit_logs.id
+
+  rule {
+    id     = "expire-audit-logs"
+    status = "Enabled"
+
+    filter {}
+
+    expiration {
+      days = %d
+    }
+  }
+}
+
+resource "aws_s3_bucket_policy" "audit_logs" {
+  bucket = aws_s3_bucket.audit_logs.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "AllowLogDeliveryAclCheck"
+        Effect    = "Allow"
+        Principal = { Service = ["cloudtrail.amazonaws.com", "config.amazonaws.com", "delivery.logs.amazonaws.com"] }
+        Action    = ["s3:GetBucketAcl", "s3:ListBucket"]
+        Resource  = aws_s3_bucket.audit_logs.arn
+      },
+      {
+        Sid       = "AllowLogDeliveryWrite"
+        Effect    = "Allow"
+        Principal = { Service = ["cloudtrail.amazonaws.com", "config.amazonaws.com", "delivery.logs.amazonaws.com"] }
+        Action    = "s3:PutObject"
+        Resource  = "${aws_s3_bucket.audit_logs.arn}/*"
+        Condition = { StringEquals = { "s3:x-amz-acl" = "bucket-owner-full-control" } }
+      },
+      {
+        Sid       = "DenyInsecureTransport"
+        Effect    = "Deny"
+        Principal = "*"
+        Action    = "s3:*"
+        Resource  = [aws_s3_bucket.audit_logs.arn, "${aws_s3_bucket.audit_logs.arn}/*"]
+        Condition = { Bool = { "aws:SecureTransport" = "false" } }
+      }
+    ]
+  })
+
+  depends_on = [aws_s3_bucket_public_access_block.audit_logs]
+}
+
+resource "aws_cloudtrail" "audit" {
+  name                          = "%s-audit"
+  s3_bucket_name                = aws_s3_bucket.audit_logs.id
+  s3_key_prefix                 = "cloudtrail"
+  kms_key_id                    = aws_kms_key.audit_logs.arn
+  is_multi_region_trail         = true
+  include_global_service_events = true
+  enable_log_file_validation    = true
+
+  depends_on = [aws_s3_bucket_policy.audit_logs]
+}
+
+resource "aws_iam_role" "config_recorder" {
+  name = "%s-config-recorder"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Principal = { Service = "config.amazonaws.com" }
+      Action    = "sts:AssumeRole"
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "config_recorder" {
+  role       = aws_iam_role.config_recorder.name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AWS_ConfigRole"
+}
+
+resource "aws_config_configuration_recorder" "audit" {
+  name     = "%s-recorder"
+  role_arn = aws_iam_role.config_recorder.arn
+
+  recording_group {
+    all_supported                 = true
+    include_global_resource_types = true
+  }
+}
+
+resource "aws_config_delivery_channel" "audit" {
+  name           = "%s-delivery"
+  s3_bucket_name = aws_s3_bucket.audit_logs.id
+  s3_key_prefix  = "config"
+  s3_kms_key_arn = aws_kms_key.audit_logs.arn
+
+  depends_on = [aws_config_configuration_recorder.audit, aws_s3_bucket_policy.audit_logs]
+}
+
+resource "aws_config_configuration_recorder_status" "audit" {
+  name       = aws_config_configuration_recorder.audit.name
+  is_enabled = true
+
+  depends_on = [aws_config_delivery_channel.audit]
+}
+
+`, baseline.BucketName, baseline.BucketName, baseline.BucketName, baseline.RetentionDays, baseline.NamePrefix, baseline.NamePrefix, baseline.NamePrefix, baseline.NamePrefix))
+
+	for _, vpc := range resolveVpcs(config) {
+		aws.WriteString(fmt.Sprintf(`resource "aws_flow_log" "%s" {
+  vpc_id               = %s.id
+  traffic_type         = "ALL"
+  log_destination_type = "s3"
+  log_destination      = "${aws_s3_bucket.audit_logs.arn}/vpc-flow-logs/"
+
+  tags = {
+    Name = "%s-flow-log"
+  }
+
+  depends_on = [aws_s3_bucket_policy.audit_logs]
+}
+
+`, vpc.Name, vpcAddress("aws", vpc.Name), vpc.Name))
+	}
+
+	aws.WriteString(`output "audit_log_bucket" {
+  value = aws_s3_bucket.audit_logs.id
+}
+
+`)
+	return aws.String()
+}
+
+// generateAzureSecurityBaseline 生成订阅活动日志诊断设置和VNet流日志，日志写入启用基础结构加密的存储账户
+// Azure的资源配置变更由活动日志的Administrative和Policy类别记录，不需要单独的配置记录器
+func generateAzureSecurityBaseline(config renderContext, baseline securityBaseline) string {
+	var azure strings.Builder
+
+	accountName := azureStorageAccountNameRegexp.ReplaceAllString(baseline.BucketName, "")
+	if len(accountName) > 24 {
+		accountName = accountName[:24]
+	}
+	if len(accountName) < 3 {
+		accountName = "auditlogs"
+	}
+
+	azure.WriteString(fmt.Sprintf(`data "azurerm_subscription" "audit" {}
+
+resource "azurerm_storage_account" "audit_logs" {
+  name                              = "%s"
+  resource_group_name               = azurerm_resource_group.rg.name
+  location                          = azurerm_resource_group.rg.location
+  account_tier                      = "Standard"
+  account_replication_type          = "GRS"
+  min_tls_version                   = "TLS1_2"
+  allow_nested_items_to_be_public   = false
+  infrastructure_encryption_enabled = true
+
+  blob_properties {
+    versioning_enabled = true
+  }
+
+  tags = local.common_tags
+}
+
+resource "azurerm_storage_management_policy" "audit_logs" {
+  storage_account_id = azurerm_storage_account.audit_logs.id
+
+  rule {
+    name    = "expire-audit-logs"
+    enabled = true
+
+    filters {
+      blob_types = ["blockBlob", "appendBlob"]
+    }
+
+    actions {
+      base_blob {
+        delete_after_days_since_modification_greater_than = %d
+      }
+    }
+  }
+}
+
+resource "azurerm_monitor_diagnostic_setting" "activity_log" {
+  name               = "%s-activity-log"
+  target_resource_id = data.azurerm_subscription.audit.id
+  storage_account_id = azurerm_storage_account.audit_logs.id
+
+  enabled_log {
+    category = "Administrative"
+  }
+
+  enabled_log {
+    category = "Security"
+  }
+
+  enabled_log {
+    category = "Policy"
+  }
+
+  enabled_log {
+    category = "Alert"
+  }
+}
+
+`, accountName, baseline.RetentionDays, baseline.NamePrefix))
+
+	// Azure在区域中创建第一个虚拟网络时自动创建网络观察程序，每个区域只能有一个，直接引用而不是重复创建
+	// 读取依赖虚拟网络，保证首次部署时网络观察程序已经存在
+	vpcs := resolveVpcs(config)
+	if len(vpcs) > 0 {
+		var vnets []string
+		for _, vpc := range vpcs {
+			vnets = append(vnets, vpcAddress("azure", vpc.Name))
+		}
+		azure.WriteString(fmt.Sprintf(`data "azurerm_network_watcher" "audit" {
+  name                = "NetworkWatcher_%s"
+  resource_group_name = "NetworkWatcherRG"
+
+  depends_on = [%s]
+}
+
+`, config.Region, strings.Join(vnets, ", ")))
+	}
+
+	for _, vpc := range vpcs {
+		azure.WriteString(fmt.Sprintf(`resource "azurerm_network_watcher_flow_log" "%s" {
+  name                 = "%s-flow-log"
+  network_watcher_name = data.azurerm_network_watcher.audit.name
+  resource_group_name  = data.azurerm_network_watcher.audit.resource_group_name
+  target_resource_id   = %s.id
+  storage_account_id   = azurerm_storage_account.audit_logs.id
+  enabled              = true
+  version              = 2
+
+  retention_policy {
+    enabled = true
+    days    = %d
+  }
+
+  tags = local.common_tags
+}
+
+`, vpc.Name, vpc.Name, vpcAddress("azure", vpc.Name), baseline.RetentionDays))
+	}
+
+	azure.WriteString(`output "audit_log_bucket" {
+  value = azurerm_storage_account.audit_logs.name
+}
+
+`)
+	return azure.String()
+}
+
+// generateAlicloudSecurityBaseline 生成操作审计跟踪、配置审计投递和VPC流日志
+// 操作审计和配置审计投递到加密的OSS存储桶，阿里云VPC流日志只能投递到日志服务，使用加密的Logstore保存
+func generateAlicloudSecurityBaseline(config renderContext, baseline securityBaseline) string {
+	var alicloud strings.Builder
+
+	alicloud.WriteString(fmt.Sprintf(`data "alicloud_account" "audit" {}
+
+resource "alicloud_oss_bucket" "audit_logs" {
+  bucket = "%s"
+
+  versioning {
+    status = "Enabled"
+  }
+
+  server_side_encryption_rule {
+    sse_algorithm = "KMS"
+  }
+
+  lifecycle_rule {
+    id      = "expire-audit-logs"
+    enabled = true
+
+    expiration {
+      days = %d
+    }
+  }
+
+  tags = local.common_tags
+}
+
+resource "alicloud_oss_bucket_acl" "audit_logs" {
+  bucket = alicloud_oss_bucket.audit_logs.bucket
+  acl    = "private"
+}
+
+resource "alicloud_actiontrail_trail" "audit" {
+  trail_name      = "%s-audit"
+  oss_bucket_name = alicloud_oss_bucket.audit_logs.bucket
+  oss_key_prefix  = "actiontrail"
+  event_rw        = "All"
+  trail_region    = "All"
+}
+
+resource "alicloud_config_configuration_recorder" "audit" {
+  enterprise_edition = false
+}
+
+resource "alicloud_config_delivery" "audit" {
+  delivery_channel_name                  = "%s-delivery"
+  delivery_channel_type                  = "OSS"
+  delivery_channel_target_arn            = "acs:oss:%s:${data.alicloud_account.audit.id}:${alicloud_oss_bucket.audit_logs.bucket}"
+  configuration_item_change_notification = true
+
+  depends_on = [alicloud_config_configuration_recorder.audit]
+}
+
+resource "alicloud_log_project" "flow_logs" {
+  project_name = "%s-flow-logs"
+  description  = "VPC flow logs created by multi-cloud landing zone platform"
+
+  tags = local.common_tags
+}
+
+resource "alicloud_log_store" "flow_logs" {
+  project_name     = alicloud_log_project.flow_logs.project_name
+  logstore_name    = "vpc-flow-logs"
+  retention_period = %d
+
+  encrypt_conf {
+    enable       = true
+    encrypt_type = "default"
+  }
+}
+
+`, baseline.BucketName, baseline.RetentionDays, baseline.NamePrefix, baseline.NamePrefix, config.Region, baseline.BucketName, baseline.RetentionDays))
+
+	for _, vpc := range resolveVpcs(config) {
+		alicloud.WriteString(fmt.Sprintf(`resource "alicloud_vpc_flow_log" "%s" {
+  flow_log_name  = "%s-flow-log"
+  resource_type  = "VPC"
+  resource_id    = %s.id
+  traffic_type   = "All"
+  project_name   = alicloud_log_project.flow_logs.project_name
+  log_store_name = alicloud_log_store.flow_logs.logstore_name
+  status         = "Active"
+
+  tags = local.common_tags
+}
+
+`, vpc.Name, vpc.Name, vpcAddress("alicloud", vpc.Name)))
+	}
+
+	alicloud.WriteString(`output "audit_log_bucket" {
+  value = alicloud_oss_bucket.audit_logs.bucket
+}
+
+`)
+	return alicloud.String()
+}
+
+// generateHuaweiSecurityBaseline 生成云审计服务追踪器、资源记录器和VPC流日志
+// 审计日志和资源快照投递到加密的OBS桶，华为云VPC流日志只能投递到云日志服务
+func generateHuaweiSecurityBaseline(config renderContext, baseline securityBaseline) string {
+	var huawei strings.Builder
+
+	ttl := baseline.RetentionDays
+	if ttl > huaweiLtsMaxTTL {
+		reportWarn(config, fmt.Sprintf("华为云日志组最长保留%d天，VPC流日志保留天数已从%d天调整为%d天", huaweiLtsMaxTTL, ttl, huaweiLtsMaxTTL))
+		ttl = huaweiLtsMaxTTL
+	}
+
+	huawei.WriteString(fmt.Sprintf(`resource "huaweicloud_obs_bucket" "audit_logs" {
+  bucket        = "%s"
+  acl           = "private"
+  versioning    = true
+  encryption    = true
+  sse_algorithm = "kms"
+
+  lifecycle_rule {
+    name    = "expire-audit-logs"
+    enabled = true
+
+    expiration {
+      days = %d
+    }
+  }
+
+  tags = local.common_tags
+}
+
+resource "huaweicloud_cts_tracker" "audit" {
+  bucket_name   = huaweicloud_obs_bucket.audit_logs.bucket
+  file_prefix   = "cts"
+  validate_file = true
+  lts_enabled   = false
+}
+
+resource "huaweicloud_identity_agency" "rms" {
+  name                   = "%s-rms-agency"
+  description            = "Allows Config to deliver resource snapshots to OBS"
+  delegated_service_name = "op_svc_rms"
+  all_resources_roles    = ["OBS OperateAccess", "RMS ReadOnlyAccess"]
+}
+
+resource "huaweicloud_rms_resource_recorder" "audit" {
+  agency_name = huaweicloud_identity_agency.rms.name
+
+  obs_channel {
+    bucket = huaweicloud_obs_bucket.audit_logs.bucket
+    region = "%s"
+  }
+}
+
+resource "huaweicloud_lts_group" "flow_logs" {
+  group_name  = "%s-flow-logs"
+  ttl_in_days = %d
+}
+
+resource "huaweicloud_lts_stream" "flow_logs" {
+  group_id    = huaweicloud_lts_group.flow_logs.id
+  stream_name = "vpc-flow-logs"
+}
+
+`, baseline.BucketName, baseline.RetentionDays, baseline.NamePrefix, config.Region, baseline.BucketName, ttl))
+
+	for _, vpc := range resolveVpcs(config) {
+		huawei.WriteString(fmt.Sprintf(`resource "huaweicloud_vpc_flow_log" "%s" {
+  name          = "%s-flow-log"
+  resource_type = "vpc"
+  resource_id   = %s.id
+  traffic_type  = "all"
+  log_group_id  = huaweicloud_lts_group.flow_logs.id
+  log_stream_id = huaweicloud_lts_stream.flow_logs.id
+}
+
+`, vpc.Name, vpc.Name, vpcAddress("huawei", vpc.Name)))
+	}
+
+	huawei.WriteString(`output "audit_log_bucket" {
+  value = huaweicloud_obs_bucket.audit_logs.bucket
+}
+
+`)
+	return huawei.String()
+}
+
+// generateTencentSecurityBaseline 生成云审计跟踪集和VPC流日志
+// 云审计投递到加密的COS存储桶，腾讯云VPC流日志只能投递到日志服务CLS
+func generateTencentSecurityBaseline(config renderContext, baseline securityBaseline) string {
+	var tencent strings.Builder
+	reportWarn(config, "腾讯云配置审计暂不支持通过Terraform开启，安全基线仅包含云审计和VPC流日志")
+
+	tencent.WriteString(fmt.Sprintf(`data "tencentcloud_user_info" "audit" {}
+
+resource "tencentcloud_cos_bucket" "audit_logs" {
+  bucket               = "%s-${data.tencentcloud_user_info.audit.app_id}"
+  acl                  = "private"
+  versioning_enable    = true
+  encryption_algorithm = "AES256"
+
+  lifecycle_rules {
+    filter_prefix = ""
+
+    expiration {
+      days = %d
+    }
+  }
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_audit_track" "audit" {
+  name                  = "%s-audit"
+  action_type           = "*"
+  event_names           = ["*"]
+  resource_type         = "*"
+  status                = 1
+  track_for_all_members = 0
+
+  storage {
+    storage_name   = tencentcloud_cos_bucket.audit_logs.bucket
+    storage_prefix = "cloudaudit"
+    storage_region = "%s"
+    storage_type   = "cos"
+  }
+}
+
+resource "tencentcloud_cls_logset" "flow_logs" {
+  logset_name = "%s-flow-logs"
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_cls_topic" "flow_logs" {
+  topic_name = "vpc-flow-logs"
+  logset_id  = tencentcloud_cls_logset.flow_logs.id
+  period     = %d
+
+  tags = local.common_tags
+}
+
+`, baseline.BucketName, baseline.RetentionDays, baseline.NamePrefix, config.Region, baseline.BucketName, baseline.RetentionDays))
+
+	for _, vpc := range resolveVpcs(config) {
+		tencent.WriteString(fmt.Sprintf(`resource "tencentcloud_vpc_flow_log" "%s" {
+  flow_log_name = "%s-flow-log"
+  resource_type = "VPC"
+  resource_id   = %s.id
+  traffic_type  = "ALL"
+  vpc_id        = %s.id
+  storage_type  = "cls"
+  cloud_log_id  = tencentcloud_cls_topic.flow_logs.id
+}
+
+`, vpc.Name, vpc.Name, vpcAddress("tencent", vpc.Name), vpcAddress("tencent", vpc.Name)))
+	}
+
+	tencent.WriteString(`output "audit_log_bucket" {
+  value = tencentcloud_cos_bucket.audit_logs.bucket
+}
+
+`)
+	return tencent.String()
+}
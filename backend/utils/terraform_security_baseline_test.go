@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+func TestGenerateSecurityBaselineConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		provider     string
+		region       string
+		deploymentID string
+		pattern      string
+		retention    int
+		want         []string
+		notWant      []string
+	}{
+		{
+			name:     "aws names follow first vpc",
+			provider: "aws",
+			region:   "us-east-1",
+			want:     []string{`name                          = "main-audit"`, `name = "main-config-recorder"`, `name     = "main-recorder"`},
+			notWant:  []string{"landing-zone-"},
+		},
+		{
+			name:         "deployment id keeps names unique",
+			provider:     "alicloud",
+			region:       "cn-hangzhou",
+			deploymentID: "3f9a1c7e",
+			pattern:      "{env}-{name}",
+			want:         []string{`trail_name      = "prod-main-3f9a1c7e-audit"`, `delivery_channel_name                  = "prod-main-3f9a1c7e-delivery"`},
+		},
+		{
+			name:      "huawei lts ttl is clamped",
+			provider:  "huawei",
+			region:    "cn-north-4",
+			retention: 730,
+			want:      []string{"ttl_in_days = 365", "days = 730"},
+		},
+		{
+			name:     "azure reads the existing network watcher",
+			provider: "azure",
+			region:   "eastus",
+			want:     []string{`data "azurerm_network_watcher" "audit"`, `name                = "NetworkWatcher_eastus"`, "network_watcher_name = data.azurerm_network_watcher.audit.name"},
+			notWant:  []string{`resource "azurerm_network_watcher" `},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig(tt.provider, tt.region))
+			config.DeploymentID = tt.deploymentID
+			config.Naming = models.NamingConvention{Pattern: tt.pattern, Environment: "prod"}
+			config.SecurityBaseline = models.SecurityBaseline{Enabled: true, LogRetentionDays: tt.retention}
+			body := generateSecurityBaselineConfig(config)
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("config should not contain %q:\n%s", notWant, body)
+				}
+			}
+		})
+	}
+}
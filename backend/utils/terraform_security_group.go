@@ -0,0 +1,600 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// securityGroupProtocols 安全组规则支持的协议及其别名
+var securityGroupProtocols = map[string]string{
+	"tcp":  "tcp",
+	"udp":  "udp",
+	"icmp": "icmp",
+	"all":  "all",
+	"any":  "all",
+	"-1":   "all",
+	"*":    "all",
+}
+
+// azureSecurityRuleProtocols Azure网络安全规则使用的协议名称
+var azureSecurityRuleProtocols = map[string]string{
+	"tcp":  "Tcp",
+	"udp":  "Udp",
+	"icmp": "Icmp",
+	"all":  "*",
+}
+
+// generateSecurityGroupConfig 生成安全组组件的Terraform配置
+// 每个安全组的规则单独渲染为规则资源，便于安全组之间相互引用而不产生循环依赖
+func generateSecurityGroupConfig(config renderContext, propsMap map[string]interface{}) string {
+	provider := config.CloudProvider
+	if _, ok := securityGroupResourceTypes[provider]; !ok {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持安全组组件", provider))
+		return ""
+	}
+
+	groups := resolveSecurityGroups(config, propsMap)
+	if len(groups) == 0 {
+		reportWarn(config, "安全组组件未定义任何安全组，跳过生成")
+		return ""
+	}
+
+	vpcs := resolveVpcs(config)
+	var sgConfig strings.Builder
+	for _, group := range groups {
+		vpc := vpcs[0]
+		if group.VpcIndex >= 0 && group.VpcIndex < len(vpcs) {
+			vpc = vpcs[group.VpcIndex]
+		}
+
+		switch provider {
+		case "aws":
+			sgConfig.WriteString(generateAwsSecurityGroup(group, vpc))
+		case "azure":
+			sgConfig.WriteString(generateAzureSecurityGroup(group))
+		case "alicloud":
+			sgConfig.WriteString(generateAlicloudSecurityGroup(group, vpc))
+		case "baidu":
+			sgConfig.WriteString(generateBaiduSecurityGroup(group, vpc))
+		case "huawei":
+			sgConfig.WriteString(generateHuaweiSecurityGroup(group))
+		case "tencent":
+			sgConfig.WriteString(generateTencentSecurityGroup(group))
+		case "volcengine":
+			sgConfig.WriteString(generateVolcengineSecurityGroup(group, vpc))
+		}
+
+		LogInfo(fmt.Sprintf("已生成安全组配置: 名称=%s, 规则数=%d", group.DisplayName, len(group.Rules)))
+	}
+
+	return sgConfig.String()
+}
+
+// resolveSecurityGroups 解析安全组列表，优先使用组件属性中的securityGroups，其次使用ComponentConfig
+// 安全组名称按命名规则生成显示名称，规则会被校验和规范化，无效规则报告为错误
+func resolveSecurityGroups(config renderContext, propsMap map[string]interface{}) []models.SecurityGroup {
+	groups := decodeSecurityGroups(config, propsMap)
+
+	var result []models.SecurityGroup
+	for _, group := range groups {
+		if group.Name != group.LogicalID {
+			reportWarn(config, fmt.Sprintf("安全组名称 %s 不是合法或唯一的Terraform资源名称，已转换为 %s", group.LogicalID, group.Name))
+		}
+		if group.DisplayName == "" {
+			group.DisplayName = renderDisplayName(config, "sg", group.LogicalID, "")
+		}
+		group.DisplayName = checkDisplayName(config, config.CloudProvider, "sg", group.DisplayName)
+		if group.Description == "" {
+			group.Description = fmt.Sprintf("Security group %s", group.DisplayName)
+		}
+
+		var rules []models.SecurityGroupRule
+		hasEgress := false
+		for i, rule := range group.Rules {
+			normalized, err := normalizeSecurityGroupRule(rule, groups)
+			if err != nil {
+				reportError(config, fmt.Sprintf("安全组 %s 的第 %d 条规则无效: %v", group.LogicalID, i+1, err))
+				continue
+			}
+			hasEgress = hasEgress || normalized.Direction == "egress"
+			rules = append(rules, normalized)
+		}
+
+		// 未定义出站规则时默认允许全部出站，与各云默认安全组的行为保持一致
+		if !hasEgress && config.CloudProvider != "azure" {
+			rules = append(rules, models.SecurityGroupRule{
+				Direction:   "egress",
+				Protocol:    "all",
+				CidrBlock:   "0.0.0.0/0",
+				Description: "Allow all outbound traffic",
+			})
+		}
+		group.Rules = rules
+		result = append(result, group)
+	}
+
+	return result
+}
+
+// decodeSecurityGroups 从组件属性或ComponentConfig中读取安全组定义并分配Terraform资源名称，不校验规则
+// 原始名称保存在LogicalID中，规则的对端安全组和其他组件的security_groups属性通过原始名称引用
+func decodeSecurityGroups(config renderContext, propsMap map[string]interface{}) []models.SecurityGroup {
+	var groups []models.SecurityGroup
+	if !decodeListProp(propsMap, "securityGroups", &groups) {
+		groups = config.ComponentConfig.SecurityGroups
+	}
+
+	if len(groups) == 0 {
+		group := models.SecurityGroup{
+			Name:        getStringProp(propsMap, "group_name", ""),
+			Description: getStringProp(propsMap, "description", ""),
+		}
+		decodeListProp(propsMap, "rules", &group.Rules)
+		if group.Name != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	logicalIDs := make([]string, len(groups))
+	for i := range groups {
+		if groups[i].Name == "" {
+			groups[i].Name = fmt.Sprintf("sg-%d", i+1)
+		}
+		groups[i].LogicalID = groups[i].Name
+		logicalIDs[i] = groups[i].Name
+	}
+	for i, identifier := range assignIdentifiers(logicalIDs, "sg") {
+		groups[i].Name = identifier
+	}
+	return groups
+}
+
+// findSecurityGroup 按名称查找安全组，既可以填写原始名称也可以填写转换后的资源名称
+func findSecurityGroup(groups []models.SecurityGroup, name string) (models.SecurityGroup, bool) {
+	for _, group := range groups {
+		if matchesLogicalName(name, group.Name, group.LogicalID) {
+			return group, true
+		}
+	}
+	return models.SecurityGroup{}, false
+}
+
+// normalizeSecurityGroupRule 校验并规范化安全组规则的方向、协议、端口和来源
+func normalizeSecurityGroupRule(rule models.SecurityGroupRule, groups []models.SecurityGroup) (models.SecurityGroupRule, error) {
+	switch strings.ToLower(strings.TrimSpace(rule.Direction)) {
+	case "", "ingress", "inbound":
+		rule.Direction = "ingress"
+	case "egress", "outbound":
+		rule.Direction = "egress"
+	default:
+		return rule, fmt.Errorf("不支持的方向 %s", rule.Direction)
+	}
+
+	protocol, ok := securityGroupProtocols[strings.ToLower(strings.TrimSpace(rule.Protocol))]
+	if !ok {
+		return rule, fmt.Errorf("不支持的协议 %s", rule.Protocol)
+	}
+	rule.Protocol = protocol
+
+	if protocol == "tcp" || protocol == "udp" {
+		if rule.ToPort == 0 {
+			rule.ToPort = rule.FromPort
+		}
+		if rule.FromPort < 1 || rule.ToPort > 65535 || rule.FromPort > rule.ToPort {
+			return rule, fmt.Errorf("端口范围 %d-%d 无效", rule.FromPort, rule.ToPort)
+		}
+	} else {
+		rule.FromPort, rule.ToPort = 0, 0
+	}
+
+	rule.CidrBlock = strings.TrimSpace(rule.CidrBlock)
+	rule.PeerGroup = strings.TrimSpace(rule.PeerGroup)
+	if (rule.CidrBlock == "") == (rule.PeerGroup == "") {
+		return rule, fmt.Errorf("必须且只能指定CIDR或对端安全组之一")
+	}
+	if rule.CidrBlock != "" {
+		if _, _, err := net.ParseCIDR(rule.CidrBlock); err != nil {
+			return rule, fmt.Errorf("CIDR %s 无效", rule.CidrBlock)
+		}
+	}
+	if rule.PeerGroup != "" {
+		peer, ok := findSecurityGroup(groups, rule.PeerGroup)
+		if !ok {
+			return rule, fmt.Errorf("对端安全组 %s 不存在", rule.PeerGroup)
+		}
+		rule.PeerGroup = peer.Name
+	}
+
+	return rule, nil
+}
+
+// isIpv6Cidr 判断CIDR是否为IPv6网段
+func isIpv6Cidr(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
+
+// securityGroupPropsMap 返回安全组组件的属性，未启用安全组组件时返回nil
+func securityGroupPropsMap(config renderContext) map[string]interface{} {
+	return componentPropsMap(config, "security-group")
+}
+
+// resolveSecurityGroupRefs 将组件属性security_groups中的安全组名称解析为安全组ID引用
+// 只能引用安全组组件中定义的安全组，未找到的名称会被忽略
+func resolveSecurityGroupRefs(config renderContext, propsMap map[string]interface{}) []string {
+	names := getStringListProp(propsMap, "security_groups")
+	if len(names) == 0 {
+		return nil
+	}
+
+	var groups []models.SecurityGroup
+	if sgProps := securityGroupPropsMap(config); sgProps != nil {
+		groups = decodeSecurityGroups(config, sgProps)
+	}
+
+	var refs []string
+	for _, name := range names {
+		group, ok := findSecurityGroup(groups, name)
+		if !ok {
+			reportWarn(config, fmt.Sprintf("未在安全组组件中找到名称为 %s 的安全组，已忽略", name))
+			continue
+		}
+		refs = append(refs, securityGroupAddress(config.CloudProvider, group.Name)+".id")
+	}
+	return refs
+}
+
+// securityRuleLabel 返回安全组规则资源的标签，例如 web_ingress_1
+func securityRuleLabel(groupName, direction string, index int) string {
+	return fmt.Sprintf("%s_%s_%d", groupName, direction, index)
+}
+
+// securityRulePortRange 按分隔符渲染端口范围，单端口时只返回一个端口
+func securityRulePortRange(rule models.SecurityGroupRule, separator string) string {
+	if rule.FromPort == rule.ToPort {
+		return fmt.Sprintf("%d", rule.FromPort)
+	}
+	return fmt.Sprintf("%d%s%d", rule.FromPort, separator, rule.ToPort)
+}
+
+// generateAwsSecurityGroup 生成AWS安全组及规则
+func generateAwsSecurityGroup(group models.SecurityGroup, vpc models.VPC) string {
+	var sg strings.Builder
+	sg.WriteString(fmt.Sprintf(`resource "aws_security_group" "%s" {
+  name        = %s
+  description = %s
+  vpc_id      = %s.id
+
+  tags = {
+    Name = %s
+  }
+}
+
+`, group.Name, hclString(group.DisplayName), hclString(group.Description), vpcAddress("aws", vpc.Name), hclString(group.DisplayName)))
+
+	for i, rule := range group.Rules {
+		protocol, fromPort, toPort := rule.Protocol, rule.FromPort, rule.ToPort
+		switch rule.Protocol {
+		case "all":
+			protocol = "-1"
+		case "icmp":
+			fromPort, toPort = -1, -1
+		}
+
+		peer := fmt.Sprintf(`cidr_blocks              = ["%s"]`, rule.CidrBlock)
+		if rule.PeerGroup != "" {
+			peer = fmt.Sprintf(`source_security_group_id = %s.id`, securityGroupAddress("aws", rule.PeerGroup))
+		} else if isIpv6Cidr(rule.CidrBlock) {
+			peer = fmt.Sprintf(`ipv6_cidr_blocks         = ["%s"]`, rule.CidrBlock)
+		}
+
+		sg.WriteString(fmt.Sprintf(`resource "aws_security_group_rule" "%s" {
+  type                     = "%s"
+  security_group_id        = aws_security_group.%s.id
+  protocol                 = "%s"
+  from_port                = %d
+  to_port                  = %d
+  %s
+  description              = %s
+}
+
+`, securityRuleLabel(group.Name, rule.Direction, i+1), rule.Direction, group.Name, protocol, fromPort, toPort, peer, hclString(rule.Description)))
+	}
+
+	return sg.String()
+}
+
+// generateAzureSecurityGroup 生成Azure网络安全组及规则
+// 同时为每个安全组创建应用程序安全组，用于在规则中引用对端安全组
+func generateAzureSecurityGroup(group models.SecurityGroup) string {
+	var sg strings.Builder
+	sg.WriteString(fmt.Sprintf(`resource "azurerm_network_security_group" "%s" {
+  name                = %s
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+
+  tags = local.common_tags
+}
+
+resource "azurerm_application_security_group" "%s" {
+  name                = %s
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+
+  tags = local.common_tags
+}
+
+`, group.Name, hclString(group.DisplayName), group.Name, hclString(group.DisplayName+"-asg")))
+
+	// 入站和出站规则分别按顺序分配优先级
+	priorities := map[string]int{"ingress": 100, "egress": 100}
+	for i, rule := range group.Rules {
+		direction := "Inbound"
+		if rule.Direction == "egress" {
+			direction = "Outbound"
+		}
+
+		portRange := "*"
+		if rule.Protocol == "tcp" || rule.Protocol == "udp" {
+			portRange = securityRulePortRange(rule, "-")
+		}
+
+		// 入站规则的对端为来源，出站规则的对端为目标
+		peerSide, otherSide := "source", "destination"
+		if rule.Direction == "egress" {
+			peerSide, otherSide = "destination", "source"
+		}
+		peer := fmt.Sprintf(`%-27s = "%s"`, peerSide+"_address_prefix", rule.CidrBlock)
+		if rule.PeerGroup != "" {
+			peer = fmt.Sprintf(`%s_application_security_group_ids = [azurerm_application_security_group.%s.id]`, peerSide, rule.PeerGroup)
+		}
+		other := fmt.Sprintf(`%-27s = "*"`, otherSide+"_address_prefix")
+
+		sg.WriteString(fmt.Sprintf(`resource "azurerm_network_security_rule" "%s" {
+  name                        = %s
+  priority                    = %d
+  direction                   = "%s"
+  access                      = "Allow"
+  protocol                    = "%s"
+  source_port_range           = "*"
+  destination_port_range      = "%s"
+  %s
+  %s
+  description                 = %s
+  resource_group_name         = azurerm_resource_group.rg.name
+  network_security_group_name = azurerm_network_security_group.%s.name
+}
+
+`, securityRuleLabel(group.Name, rule.Direction, i+1), hclString(fmt.Sprintf("%s-%s-%d", group.DisplayName, rule.Direction, i+1)), priorities[rule.Direction], direction,
+			azureSecurityRuleProtocols[rule.Protocol], portRange, peer, other, hclString(rule.Description), group.Name))
+		priorities[rule.Direction] += 10
+	}
+
+	return sg.String()
+}
+
+// generateAlicloudSecurityGroup 生成阿里云安全组及规则
+func generateAlicloudSecurityGroup(group models.SecurityGroup, vpc models.VPC) string {
+	var sg strings.Builder
+	sg.WriteString(fmt.Sprintf(`resource "alicloud_security_group" "%s" {
+  name        = %s
+  description = %s
+  vpc_id      = %s.id
+
+  tags = local.common_tags
+}
+
+`, group.Name, hclString(group.DisplayName), hclString(group.Description), vpcAddress("alicloud", vpc.Name)))
+
+	for i, rule := range group.Rules {
+		portRange := "-1/-1"
+		if rule.Protocol == "tcp" || rule.Protocol == "udp" {
+			portRange = fmt.Sprintf("%d/%d", rule.FromPort, rule.ToPort)
+		}
+
+		peer := fmt.Sprintf(`cidr_ip                  = "%s"`, rule.CidrBlock)
+		if rule.PeerGroup != "" {
+			peer = fmt.Sprintf(`source_security_group_id = %s.id`, securityGroupAddress("alicloud", rule.PeerGroup))
+		} else if isIpv6Cidr(rule.CidrBlock) {
+			peer = fmt.Sprintf(`ipv6_cidr_ip             = "%s"`, rule.CidrBlock)
+		}
+
+		sg.WriteString(fmt.Sprintf(`resource "alicloud_security_group_rule" "%s" {
+  type                     = "%s"
+  security_group_id        = alicloud_security_group.%s.id
+  ip_protocol              = "%s"
+  port_range               = "%s"
+  nic_type                 = "intranet"
+  policy                   = "accept"
+  priority                 = 1
+  %s
+  description              = %s
+}
+
+`, securityRuleLabel(group.Name, rule.Direction, i+1), rule.Direction, group.Name, rule.Protocol, portRange, peer, hclString(rule.Description)))
+	}
+
+	return sg.String()
+}
+
+// generateBaiduSecurityGroup 生成百度云安全组及规则
+func generateBaiduSecurityGroup(group models.SecurityGroup, vpc models.VPC) string {
+	var sg strings.Builder
+	sg.WriteString(fmt.Sprintf(`resource "baiducloud_security_group" "%s" {
+  name        = %s
+  description = %s
+  vpc_id      = %s.id
+
+  tags = local.common_tags
+}
+
+`, group.Name, hclString(group.DisplayName), hclString(group.Description), vpcAddress("baidu", vpc.Name)))
+
+	for i, rule := range group.Rules {
+		portRange := ""
+		if rule.Protocol == "tcp" || rule.Protocol == "udp" {
+			portRange = securityRulePortRange(rule, "-")
+		}
+
+		// 入站规则使用source_*字段，出站规则使用dest_*字段
+		prefix := "source"
+		if rule.Direction == "egress" {
+			prefix = "dest"
+		}
+		etherType := "IPv4"
+		peer := fmt.Sprintf(`%s_ip = "%s"`, prefix, rule.CidrBlock)
+		if rule.PeerGroup != "" {
+			peer = fmt.Sprintf(`%s_group_id = %s.id`, prefix, securityGroupAddress("baidu", rule.PeerGroup))
+		} else if isIpv6Cidr(rule.CidrBlock) {
+			etherType = "IPv6"
+		}
+
+		sg.WriteString(fmt.Sprintf(`resource "baiducloud_security_group_rule" "%s" {
+  security_group_id = baiducloud_security_group.%s.id
+  direction         = "%s"
+  ether_type        = "%s"
+  protocol          = "%s"
+  port_range        = "%s"
+  remark            = %s
+  %s
+}
+
+`, securityRuleLabel(group.Name, rule.Direction, i+1), group.Name, rule.Direction, etherType, rule.Protocol, portRange, hclString(rule.Description), peer))
+	}
+
+	return sg.String()
+}
+
+// generateHuaweiSecurityGroup 生成华为云安全组及规则，删除默认规则以保证规则完全由配置决定
+func generateHuaweiSecurityGroup(group models.SecurityGroup) string {
+	var sg strings.Builder
+	sg.WriteString(fmt.Sprintf(`resource "huaweicloud_networking_secgroup" "%s" {
+  name                 = %s
+  description          = %s
+  delete_default_rules = true
+}
+
+`, group.Name, hclString(group.DisplayName), hclString(group.Description)))
+
+	for i, rule := range group.Rules {
+		etherType := "IPv4"
+		if isIpv6Cidr(rule.CidrBlock) {
+			etherType = "IPv6"
+		}
+		var ruleBody strings.Builder
+		if rule.Protocol != "all" {
+			ruleBody.WriteString(fmt.Sprintf("\n  protocol          = \"%s\"", rule.Protocol))
+		}
+		if rule.Protocol == "tcp" || rule.Protocol == "udp" {
+			ruleBody.WriteString(fmt.Sprintf("\n  port_range_min    = %d\n  port_range_max    = %d", rule.FromPort, rule.ToPort))
+		}
+		if rule.PeerGroup != "" {
+			ruleBody.WriteString(fmt.Sprintf("\n  remote_group_id   = %s.id", securityGroupAddress("huawei", rule.PeerGroup)))
+		} else {
+			ruleBody.WriteString(fmt.Sprintf("\n  remote_ip_prefix  = \"%s\"", rule.CidrBlock))
+		}
+
+		sg.WriteString(fmt.Sprintf(`resource "huaweicloud_networking_secgroup_rule" "%s" {
+  security_group_id = huaweicloud_networking_secgroup.%s.id
+  direction         = "%s"
+  ethertype         = "%s"%s
+  description       = %s
+}
+
+`, securityRuleLabel(group.Name, rule.Direction, i+1), group.Name, rule.Direction, etherType, ruleBody.String(), hclString(rule.Description)))
+	}
+
+	return sg.String()
+}
+
+// generateTencentSecurityGroup 生成腾讯云安全组，规则通过规则集资源统一管理
+func generateTencentSecurityGroup(group models.SecurityGroup) string {
+	var ruleBlocks strings.Builder
+	for _, rule := range group.Rules {
+		port := "ALL"
+		if rule.Protocol == "tcp" || rule.Protocol == "udp" {
+			port = securityRulePortRange(rule, "-")
+		}
+
+		peer := fmt.Sprintf(`cidr_block         = "%s"`, rule.CidrBlock)
+		if rule.PeerGroup != "" {
+			peer = fmt.Sprintf(`source_security_id = %s.id`, securityGroupAddress("tencent", rule.PeerGroup))
+		} else if isIpv6Cidr(rule.CidrBlock) {
+			peer = fmt.Sprintf(`ipv6_cidr_block    = "%s"`, rule.CidrBlock)
+		}
+
+		ruleBlocks.WriteString(fmt.Sprintf(`
+
+  %s {
+    action             = "ACCEPT"
+    protocol           = "%s"
+    port               = "%s"
+    %s
+    description        = %s
+  }`, rule.Direction, strings.ToUpper(rule.Protocol), port, peer, hclString(rule.Description)))
+	}
+
+	return fmt.Sprintf(`resource "tencentcloud_security_group" "%s" {
+  name        = %s
+  description = %s
+
+  tags = local.common_tags
+}
+
+resource "tencentcloud_security_group_rule_set" "%s" {
+  security_group_id = tencentcloud_security_group.%s.id%s
+}
+
+`, group.Name, hclString(group.DisplayName), hclString(group.Description), group.Name, group.Name, ruleBlocks.String())
+}
+
+// generateVolcengineSecurityGroup 生成火山引擎安全组及规则
+func generateVolcengineSecurityGroup(group models.SecurityGroup, vpc models.VPC) string {
+	var sg strings.Builder
+	sg.WriteString(fmt.Sprintf(`resource "volcengine_security_group" "%s" {
+  security_group_name = %s
+  description         = %s
+  vpc_id              = %s.id
+
+  dynamic "tags" {
+    for_each = local.common_tags
+    content {
+      key   = tags.key
+      value = tags.value
+    }
+  }
+}
+
+`, group.Name, hclString(group.DisplayName), hclString(group.Description), vpcAddress("volcengine", vpc.Name)))
+
+	for i, rule := range group.Rules {
+		portStart, portEnd := -1, -1
+		if rule.Protocol == "tcp" || rule.Protocol == "udp" {
+			portStart, portEnd = rule.FromPort, rule.ToPort
+		}
+
+		peer := fmt.Sprintf(`cidr_ip           = "%s"`, rule.CidrBlock)
+		if rule.PeerGroup != "" {
+			peer = fmt.Sprintf(`source_group_id   = %s.id`, securityGroupAddress("volcengine", rule.PeerGroup))
+		}
+
+		sg.WriteString(fmt.Sprintf(`resource "volcengine_security_group_rule" "%s" {
+  security_group_id = volcengine_security_group.%s.id
+  direction         = "%s"
+  protocol          = "%s"
+  port_start        = %d
+  port_end          = %d
+  policy            = "accept"
+  priority          = 1
+  %s
+  description       = %s
+}
+
+`, securityRuleLabel(group.Name, rule.Direction, i+1), group.Name, rule.Direction, rule.Protocol, portStart, portEnd, peer, hclString(rule.Description)))
+	}
+
+	return sg.String()
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+func TestGenerateSecurityGroupConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		groups   []models.SecurityGroup
+		want     []string
+		notWant  []string
+		wantErr  bool
+	}{
+		{
+			name:     "names go through the naming engine",
+			provider: "aws",
+			groups: []models.SecurityGroup{
+				{Name: "web tier", Rules: []models.SecurityGroupRule{{Protocol: "tcp", FromPort: 443, CidrBlock: "0.0.0.0/0"}}},
+				{Name: "app", Rules: []models.SecurityGroupRule{{Protocol: "tcp", FromPort: 8080, PeerGroup: "web tier"}}},
+			},
+			want: []string{
+				`resource "aws_security_group" "web_tier"`,
+				`name        = "prod-sg-web tier"`,
+				"source_security_group_id = aws_security_group.web_tier.id",
+			},
+		},
+		{
+			name:     "descriptions are escaped",
+			provider: "alicloud",
+			groups: []models.SecurityGroup{
+				{Name: "web", Description: `Web "tier"`, Rules: []models.SecurityGroupRule{{Protocol: "tcp", FromPort: 443, CidrBlock: "0.0.0.0/0", Description: "https ${var.x}"}}},
+			},
+			want: []string{`description = "Web \"tier\""`, `description              = "https $${var.x}"`},
+		},
+		{
+			name:     "aws ipv6 cidr",
+			provider: "aws",
+			groups:   []models.SecurityGroup{{Name: "web", Rules: []models.SecurityGroupRule{{Protocol: "tcp", FromPort: 443, CidrBlock: "::/0"}}}},
+			want:     []string{`ipv6_cidr_blocks         = ["::/0"]`},
+			notWant:  []string{`cidr_blocks              = ["::/0"]`},
+		},
+		{
+			name:     "tencent ipv6 cidr",
+			provider: "tencent",
+			groups:   []models.SecurityGroup{{Name: "web", Rules: []models.SecurityGroupRule{{Protocol: "tcp", FromPort: 443, CidrBlock: "2001:db8::/32"}}}},
+			want:     []string{`ipv6_cidr_block    = "2001:db8::/32"`},
+		},
+		{
+			name:     "huawei ipv6 ethertype",
+			provider: "huawei",
+			groups:   []models.SecurityGroup{{Name: "web", Rules: []models.SecurityGroupRule{{Protocol: "tcp", FromPort: 443, CidrBlock: "::/0"}}}},
+			want:     []string{"ethertype         = \"IPv6\"\n  protocol          = \"tcp\""},
+		},
+		{
+			name:     "invalid rule is an error",
+			provider: "aws",
+			groups:   []models.SecurityGroup{{Name: "web", Rules: []models.SecurityGroupRule{{Protocol: "tcp", FromPort: 70000, CidrBlock: "0.0.0.0/0"}}}},
+			notWant:  []string{"from_port                = 70000"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig(tt.provider, "us-east-1"))
+			config.Naming = models.NamingConvention{Pattern: "{env}-{type}-{name}", Environment: "prod"}
+			props := map[string]interface{}{"securityGroups": tt.groups}
+			body := generateSecurityGroupConfig(config, props)
+			if got := HasErrorFindings(config.findings.Findings()); got != tt.wantErr {
+				t.Fatalf("error findings = %v, want %v: %v", got, tt.wantErr, config.findings.Findings())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("config should not contain %q:\n%s", notWant, body)
+				}
+			}
+		})
+	}
+}
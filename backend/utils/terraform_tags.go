@@ -0,0 +1,363 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// managedByTagValue 平台写入managed-by标签的值
+const managedByTagValue = "multi-cloud-landing-zone"
+
+// commonTagsLocal 模板中支持标签的资源引用统一标签的局部变量
+const commonTagsLocal = "local.common_tags"
+
+// requiredTagKeys 部署时必须由用户提供的标签，默认不要求，通过SetRequiredTagKeys配置
+// deployment-id和managed-by由服务端写入
+var requiredTagKeys []string
+
+// SetRequiredTagKeys 设置部署时必须提供的标签键，忽略空白的键
+func SetRequiredTagKeys(keys []string) {
+	requiredTagKeys = nil
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			requiredTagKeys = append(requiredTagKeys, key)
+		}
+	}
+}
+
+// tagLimit 云提供商对标签的限制
+type tagLimit struct {
+	MaxKeyLength     int
+	MaxValueLength   int
+	MaxTags          int
+	ReservedPrefixes []string // 标签键不能使用的前缀（不区分大小写）
+	InvalidChars     string   // 标签键不能包含的字符
+}
+
+// tagLimits 各云提供商的标签限制，MaxTags包含资源自身的Name标签
+var tagLimits = map[string]tagLimit{
+	"aws":        {MaxKeyLength: 128, MaxValueLength: 256, MaxTags: 50, ReservedPrefixes: []string{"aws:"}},
+	"azure":      {MaxKeyLength: 512, MaxValueLength: 256, MaxTags: 50, InvalidChars: `<>%&\?/`},
+	"alicloud":   {MaxKeyLength: 128, MaxValueLength: 128, MaxTags: 20, ReservedPrefixes: []string{"aliyun", "acs:", "http://", "https://"}},
+	"baidu":      {MaxKeyLength: 65, MaxValueLength: 65, MaxTags: 10},
+	"huawei":     {MaxKeyLength: 128, MaxValueLength: 255, MaxTags: 20, ReservedPrefixes: []string{"_sys_"}},
+	"tencent":    {MaxKeyLength: 127, MaxValueLength: 255, MaxTags: 50, ReservedPrefixes: []string{"qcloud", "tencent", "project"}},
+	"volcengine": {MaxKeyLength: 128, MaxValueLength: 256, MaxTags: 50, ReservedPrefixes: []string{"volc:", "sys:"}},
+}
+
+// taggableResources 模板中通过local.common_tags引用统一标签的资源类型，AWS资源通过provider的default_tags统一打标签，不在此列出
+// 火山引擎的标签是dynamic tags块，其他云提供商是tags映射
+var taggableResources = map[string]bool{
+	"azurerm_resource_group":                        true,
+	"azurerm_virtual_network":                       true,
+	"azurerm_network_security_group":                true,
+	"azurerm_route_table":                           true,
+	"azurerm_public_ip":                             true,
+	"azurerm_nat_gateway":                           true,
+	"azurerm_network_interface":                     true,
+	"azurerm_application_security_group":            true,
+	"azurerm_linux_virtual_machine":                 true,
+	"azurerm_storage_account":                       true,
+	"azurerm_log_analytics_workspace":               true,
+	"azurerm_application_insights":                  true,
+	"azurerm_service_plan":                          true,
+	"azurerm_linux_function_app":                    true,
+	"azurerm_user_assigned_identity":                true,
+	"azurerm_mysql_flexible_server":                 true,
+	"azurerm_postgresql_flexible_server":            true,
+	"azurerm_private_dns_zone":                      true,
+	"azurerm_private_dns_zone_virtual_network_link": true,
+	"azurerm_virtual_wan":                           true,
+	"azurerm_virtual_hub":                           true,
+	"azurerm_virtual_network_gateway":               true,
+	"azurerm_local_network_gateway":                 true,
+	"azurerm_virtual_network_gateway_connection":    true,
+	"azurerm_network_watcher":                       true,
+	"azurerm_network_watcher_flow_log":              true,
+	"azurerm_subscription":                          true,
+	"azurerm_kubernetes_cluster":                    true,
+	"azurerm_kubernetes_cluster_node_pool":          true,
+	"azurerm_bastion_host":                          true,
+	"azurerm_monitor_action_group":                  true,
+	"azurerm_monitor_metric_alert":                  true,
+	"azurerm_redis_cache":                           true,
+	"azurerm_private_endpoint":                      true,
+	"azurerm_eventhub_namespace":                    true,
+
+	"alicloud_vpc":                     true,
+	"alicloud_vswitch":                 true,
+	"alicloud_security_group":          true,
+	"alicloud_instance":                true,
+	"alicloud_nat_gateway":             true,
+	"alicloud_eip_address":             true,
+	"alicloud_route_table":             true,
+	"alicloud_oss_bucket":              true,
+	"alicloud_db_instance":             true,
+	"alicloud_vpn_gateway":             true,
+	"alicloud_cen_instance":            true,
+	"alicloud_cen_transit_router":      true,
+	"alicloud_log_project":             true,
+	"alicloud_vpc_flow_log":            true,
+	"alicloud_cs_managed_kubernetes":   true,
+	"alicloud_cs_kubernetes_node_pool": true,
+	"alicloud_bastionhost_instance":    true,
+	"alicloud_cms_alarm":               true,
+	"alicloud_kvstore_instance":        true,
+	"alicloud_alikafka_instance":       true,
+	"alicloud_rocketmq_instance":       true,
+
+	"baiducloud_vpc":            true,
+	"baiducloud_subnet":         true,
+	"baiducloud_instance":       true,
+	"baiducloud_security_group": true,
+
+	"huaweicloud_vpc":                   true,
+	"huaweicloud_vpc_subnet":            true,
+	"huaweicloud_compute_instance":      true,
+	"huaweicloud_nat_gateway":           true,
+	"huaweicloud_vpc_eip":               true,
+	"huaweicloud_obs_bucket":            true,
+	"huaweicloud_rds_instance":          true,
+	"huaweicloud_dcs_instance":          true,
+	"huaweicloud_dms_kafka_instance":    true,
+	"huaweicloud_dms_rocketmq_instance": true,
+	"huaweicloud_er_instance":           true,
+	"huaweicloud_fgs_function":          true,
+	"huaweicloud_cce_cluster":           true,
+	"huaweicloud_cce_node_pool":         true,
+
+	"tencentcloud_vpc":                       true,
+	"tencentcloud_subnet":                    true,
+	"tencentcloud_security_group":            true,
+	"tencentcloud_instance":                  true,
+	"tencentcloud_nat_gateway":               true,
+	"tencentcloud_eip":                       true,
+	"tencentcloud_route_table":               true,
+	"tencentcloud_cos_bucket":                true,
+	"tencentcloud_mysql_instance":            true,
+	"tencentcloud_redis_instance":            true,
+	"tencentcloud_ckafka_instance":           true,
+	"tencentcloud_trocket_rocketmq_instance": true,
+	"tencentcloud_vpn_gateway":               true,
+	"tencentcloud_ccn":                       true,
+	"tencentcloud_cls_logset":                true,
+	"tencentcloud_cls_topic":                 true,
+	"tencentcloud_kubernetes_cluster":        true,
+	"tencentcloud_kubernetes_node_pool":      true,
+
+	"volcengine_vpc":                     true,
+	"volcengine_subnet":                  true,
+	"volcengine_security_group":          true,
+	"volcengine_ecs_instance":            true,
+	"volcengine_nat_gateway":             true,
+	"volcengine_eip_address":             true,
+	"volcengine_rds_mysql_instance":      true,
+	"volcengine_rds_postgresql_instance": true,
+	"volcengine_vke_cluster":             true,
+	"volcengine_vke_node_pool":           true,
+}
+
+// hclResourceTypeRegexp 匹配顶层resource块的资源类型
+var hclResourceTypeRegexp = regexp.MustCompile(`(?m)^resource "([a-z0-9_]+)" "[^"]+" \{`)
+
+// hclHeredocRegexp 匹配heredoc的开始标记，例如 <<POLICY 或 <<-EOT
+var hclHeredocRegexp = regexp.MustCompile(`<<-?([A-Z_]+)\s*$`)
+
+// hclIdentifierRegexp 匹配不需要加引号的标签键
+var hclIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateDeploymentTags 校验部署的标签，缺少必需标签或标签不符合云提供商限制时返回错误
+func validateDeploymentTags(config renderContext) error {
+	configs := []models.DeploymentConfig{config.DeploymentConfig}
+	if len(config.Sections) > 0 {
+		configs = nil
+		for _, section := range resolveDeploymentSections(config) {
+			configs = append(configs, section.Config.DeploymentConfig)
+		}
+	}
+
+	var problems []string
+	for _, item := range configs {
+		for _, key := range requiredTagKeys {
+			if strings.TrimSpace(item.Tags[key]) == "" {
+				problems = append(problems, fmt.Sprintf("%s 缺少必需标签 %s", item.CloudProvider, key))
+			}
+		}
+		for _, key := range sortedKeys(item.Tags) {
+			if err := validateTag(item.CloudProvider, key, item.Tags[key]); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("标签校验失败: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateTag 按云提供商的限制校验单个标签
+func validateTag(provider, key, value string) error {
+	limit, ok := tagLimits[provider]
+	if !ok {
+		return nil
+	}
+	if key == "" {
+		return fmt.Errorf("%s 的标签键不能为空", provider)
+	}
+	if len([]rune(key)) > limit.MaxKeyLength {
+		return fmt.Errorf("%s 的标签键 %s 超过%d个字符", provider, key, limit.MaxKeyLength)
+	}
+	if len([]rune(value)) > limit.MaxValueLength {
+		return fmt.Errorf("%s 的标签 %s 的值超过%d个字符", provider, key, limit.MaxValueLength)
+	}
+	for _, prefix := range limit.ReservedPrefixes {
+		if strings.HasPrefix(strings.ToLower(key), prefix) {
+			return fmt.Errorf("%s 的标签键 %s 不能以 %s 开头", provider, key, prefix)
+		}
+	}
+	if limit.InvalidChars != "" && strings.ContainsAny(key, limit.InvalidChars) {
+		return fmt.Errorf("%s 的标签键 %s 不能包含字符 %s", provider, key, limit.InvalidChars)
+	}
+	if strings.ContainsAny(key+value, "\"\n") || strings.Contains(key+value, "${") || strings.Contains(key+value, "%{") {
+		return fmt.Errorf("%s 的标签 %s 不能包含双引号、换行或模板表达式", provider, key)
+	}
+	return nil
+}
+
+// resolveTags 返回合并到资源中的标签：用户标签加上服务端写入的deployment-id和managed-by
+func resolveTags(config renderContext) map[string]string {
+	tags := make(map[string]string, len(config.Tags)+2)
+	for key, value := range config.Tags {
+		tags[key] = value
+	}
+	tags["managed-by"] = managedByTagValue
+	if config.DeploymentID != "" {
+		tags["deployment-id"] = config.DeploymentID
+	}
+	return tags
+}
+
+// filterTags 去掉不符合云提供商限制的标签
+func filterTags(provider string, tags map[string]string) map[string]string {
+	filtered := make(map[string]string, len(tags))
+	for _, key := range sortedKeys(tags) {
+		if err := validateTag(provider, key, tags[key]); err != nil {
+			LogError(fmt.Sprintf("%v，已忽略该标签", err))
+			continue
+		}
+		filtered[key] = tags[key]
+	}
+	if limit, ok := tagLimits[provider]; ok && len(filtered)+1 > limit.MaxTags {
+		LogWarn(fmt.Sprintf("%s 的资源最多支持%d个标签，当前标签数量为%d", provider, limit.MaxTags, len(filtered)+1))
+	}
+	return filtered
+}
+
+// sortedKeys 返回排序后的键，保证生成的配置稳定
+func sortedKeys(items map[string]string) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hclTagKey 返回标签键在HCL映射中的写法，包含非标识符字符的键需要加引号
+func hclTagKey(key string) string {
+	if hclIdentifierRegexp.MatchString(key) {
+		return key
+	}
+	return hclString(key)
+}
+
+// providerDefaultTags 返回provider配置块中的default_tags，目前只有AWS支持
+func providerDefaultTags(provider string, tags map[string]string) string {
+	if provider != "aws" || len(tags) == 0 {
+		return ""
+	}
+	return "\n\n  default_tags {\n    tags = " + hclTagMap(filterTags(provider, tags), "    ") + "\n  }"
+}
+
+// hclTagMap 生成对齐的标签映射
+func hclTagMap(tags map[string]string, indent string) string {
+	keys := sortedKeys(tags)
+	width := 0
+	for _, key := range keys {
+		if len(hclTagKey(key)) > width {
+			width = len(hclTagKey(key))
+		}
+	}
+	var tagMap strings.Builder
+	tagMap.WriteString("{\n")
+	for _, key := range keys {
+		tagMap.WriteString(fmt.Sprintf("%s  %-*s = %s\n", indent, width, hclTagKey(key), hclString(tags[key])))
+	}
+	tagMap.WriteString(indent + "}")
+	return tagMap.String()
+}
+
+// commonTags 返回配置引用的统一标签，标签需要符合配置中所有支持标签的资源所属云提供商的限制，
+// 例如AWS分段中的跨云VPN会创建阿里云的资源
+func commonTags(body string, tags map[string]string) map[string]string {
+	providers := make(map[string]bool)
+	for _, match := range hclResourceTypeRegexp.FindAllStringSubmatch(body, -1) {
+		if taggableResources[match[1]] {
+			providers[providerForResourceType(match[1])] = true
+		}
+	}
+	names := make([]string, 0, len(providers))
+	for provider := range providers {
+		names = append(names, provider)
+	}
+	sort.Strings(names)
+	for _, provider := range names {
+		tags = filterTags(provider, tags)
+	}
+	return tags
+}
+
+// generateCommonTagsLocals 生成资源引用的统一标签局部变量，配置中没有资源引用local.<name>时返回空字符串
+func generateCommonTagsLocals(body, name string, tags map[string]string) string {
+	if !strings.Contains(body, "local."+name) {
+		return ""
+	}
+	return fmt.Sprintf("locals {\n  %s = %s\n}\n\n", name, hclTagMap(commonTags(body, tags), "  "))
+}
+
+// resourceBlockEnd 返回从start开始的顶层块的结束行，跳过heredoc中的内容
+func resourceBlockEnd(lines []string, start int) int {
+	heredoc := ""
+	for i := start + 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\n")
+		if heredoc != "" {
+			if strings.TrimSpace(line) == heredoc {
+				heredoc = ""
+			}
+			continue
+		}
+		if match := hclHeredocRegexp.FindStringSubmatch(line); match != nil {
+			heredoc = match[1]
+			continue
+		}
+		if strings.HasPrefix(line, "}") {
+			return i
+		}
+	}
+	return -1
+}
+
+// providerForResourceType 根据资源类型前缀返回云提供商
+func providerForResourceType(resourceType string) string {
+	prefix := strings.SplitN(resourceType, "_", 2)[0]
+	for provider, name := range providerLocalNames {
+		if name == prefix {
+			return provider
+		}
+	}
+	return prefix
+}
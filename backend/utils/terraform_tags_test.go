@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+func TestValidateDeploymentTagsRequiredKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		required []string
+		tags     map[string]string
+		wantErr  bool
+	}{
+		{name: "no required tags by default", tags: nil},
+		{name: "configured tag missing", required: []string{"owner", " "}, tags: map[string]string{"cost-center": "rd"}, wantErr: true},
+		{name: "configured tag present", required: []string{"owner"}, tags: map[string]string{"owner": "net-team"}},
+	}
+	t.Cleanup(func() { SetRequiredTagKeys(nil) })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRequiredTagKeys(tt.required)
+			config := models.DeploymentConfig{CloudProvider: "alicloud", Tags: tt.tags}
+			if err := validateDeploymentTags(testRenderContext(config)); (err != nil) != tt.wantErr {
+				t.Errorf("validateDeploymentTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateTerraformConfigCommonTags(t *testing.T) {
+	tests := []struct {
+		provider string
+		region   string
+		want     []string
+		notWant  []string
+	}{
+		{
+			provider: "aws",
+			region:   "us-east-1",
+			want:     []string{"default_tags {", `owner           = "net-team"`},
+			notWant:  []string{"common_tags"},
+		},
+		{
+			provider: "alicloud",
+			region:   "cn-hangzhou",
+			want:     []string{"locals {\n  common_tags = {", `owner           = "net-team"`, "  tags = local.common_tags\n"},
+		},
+		{
+			provider: "volcengine",
+			region:   "cn-beijing",
+			want:     []string{"locals {\n  common_tags = {", "    for_each = local.common_tags\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			config := testNetworkConfig(tt.provider, tt.region)
+			config.DeploymentID = "3f9a1c7e"
+			config.Tags = map[string]string{"owner": "net-team"}
+			body := GenerateTerraformConfig(config)
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("config should not contain %q:\n%s", notWant, body)
+				}
+			}
+		})
+	}
+}
+
+func TestHclTagMapEscaping(t *testing.T) {
+	got := hclTagMap(map[string]string{"cost center": "研发${var.x}", "team": "net\x7fops"}, "")
+	want := "{\n  \"cost center\" = \"研发$${var.x}\"\n  team          = \"net\\u007Fops\"\n}"
+	if got != want {
+		t.Errorf("hclTagMap() =\n%s\nwant\n%s", got, want)
+	}
+}
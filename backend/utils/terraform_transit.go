@@ -0,0 +1,799 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// legacyTransitAttachment 兼容旧版tgwAttachments中以vpcId和subnetIds字符串描述的挂载
+type legacyTransitAttachment struct {
+	models.TransitGatewayAttachment
+	VpcId     string `json:"vpcId"`
+	SubnetIds string `json:"subnetIds"`
+}
+
+// transitAttachment 表示解析后的中转网关挂载
+type transitAttachment struct {
+	Name       string
+	Label      string
+	Vpc        models.VPC
+	Subnets    []models.Subnet // 每个可用区最多一个子网
+	RouteTable string          // 关联的路由表名称，为空时关联默认路由表
+	Propagate  []string        // 传播到的路由表名称，为空时传播到默认路由表
+}
+
+// transitPlan 表示中转网关（及其他云的云企业网、云联网、企业路由器和虚拟WAN）的规划结果
+type transitPlan struct {
+	Name                 string
+	Description          string
+	AutoAccept           string
+	DnsSupport           string
+	VpnEcmpSupport       string
+	UseDefaultRouteTable bool
+	RouteTables          []models.TransitGatewayRouteTable
+	RouteTableLabels     map[string]string // 路由表名称 -> 资源标签
+	Attachments          []transitAttachment
+	AttachmentLabels     map[string]string // 挂载名称 -> 资源标签
+}
+
+// transitFlagDefaults 中转网关enable/disable开关属性的默认值
+var transitFlagDefaults = map[string]string{
+	"auto_accept_shared_attachments": "disable",
+	"dns_support":                    "enable",
+	"vpn_ecmp_support":               "disable",
+}
+
+// generateTransitGatewayConfig 生成中转网关及各云的Hub网络等价配置
+// AWS Transit Gateway、阿里云CEN转发路由器、腾讯云云联网、华为云企业路由器和Azure虚拟WAN
+func generateTransitGatewayConfig(config renderContext, propsMap map[string]interface{}) string {
+	plan := resolveTransitPlan(config, propsMap)
+
+	var transitConfig strings.Builder
+	switch config.CloudProvider {
+	case "aws":
+		transitConfig.WriteString(generateAwsTransitGateway(config, plan))
+	case "alicloud":
+		transitConfig.WriteString(generateAlicloudTransitRouter(plan))
+	case "tencent":
+		transitConfig.WriteString(generateTencentCcn(config, plan))
+	case "huawei":
+		transitConfig.WriteString(generateHuaweiEnterpriseRouter(config, plan, getIntProp(propsMap, "asn", 64512)))
+	case "azure":
+		hubAddressPrefix := getStringProp(propsMap, "hub_address_prefix", "10.255.0.0/23")
+		if _, _, err := net.ParseCIDR(hubAddressPrefix); err != nil {
+			reportError(config, fmt.Sprintf("虚拟Hub的地址前缀 %q 无效，跳过生成中转网关", hubAddressPrefix))
+			return ""
+		}
+		transitConfig.WriteString(generateAzureVirtualWan(config, plan, hubAddressPrefix))
+	default:
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持中转网关组件", config.CloudProvider))
+		return ""
+	}
+
+	LogInfo(fmt.Sprintf("已生成中转网关配置: 名称=%s, 路由表=%d, 挂载=%d", plan.Name, len(plan.RouteTables), len(plan.Attachments)))
+	return transitConfig.String()
+}
+
+// resolveTransitPlan 解析中转网关的路由表和挂载配置
+// 挂载优先使用组件属性tgwAttachments，其次使用TransitGatewayConfig.Attachments，启用VPC挂载时为每个VPC创建挂载
+func resolveTransitPlan(config renderContext, propsMap map[string]interface{}) transitPlan {
+	tgwConfig := config.ComponentConfig.TransitGatewayConfig
+	plan := transitPlan{
+		Name:             getStringProp(propsMap, "name", "transit-gateway"),
+		Description:      getStringProp(propsMap, "description", "Transit Gateway for multi-cloud connectivity"),
+		AutoAccept:       getStringProp(propsMap, "auto_accept_shared_attachments", transitFlagDefaults["auto_accept_shared_attachments"]),
+		DnsSupport:       getStringProp(propsMap, "dns_support", transitFlagDefaults["dns_support"]),
+		VpnEcmpSupport:   getStringProp(propsMap, "vpn_ecmp_support", transitFlagDefaults["vpn_ecmp_support"]),
+		RouteTableLabels: make(map[string]string),
+		AttachmentLabels: make(map[string]string),
+	}
+	// 使用ComponentConfig中的传输网关名称（如果存在）
+	if config.ComponentConfig.TransitGatewayName != "" {
+		plan.Name = config.ComponentConfig.TransitGatewayName
+	}
+	// enable/disable开关直接渲染到配置中，其他值按默认值处理
+	for key, value := range map[string]*string{"auto_accept_shared_attachments": &plan.AutoAccept, "dns_support": &plan.DnsSupport, "vpn_ecmp_support": &plan.VpnEcmpSupport} {
+		if *value != "enable" && *value != "disable" {
+			reportError(config, fmt.Sprintf("中转网关属性 %s 的值 %q 无效，只能是 enable 或 disable", key, *value))
+			*value = transitFlagDefaults[key]
+		}
+	}
+
+	// 路由表：未定义路由表列表时，兼容单个RouteTableName
+	routeTables := tgwConfig.RouteTables
+	if len(routeTables) == 0 && tgwConfig.RouteTableName != "" {
+		routeTables = []models.TransitGatewayRouteTable{{Name: tgwConfig.RouteTableName}}
+	}
+	for i, routeTable := range routeTables {
+		if routeTable.Name == "" {
+			routeTable.Name = fmt.Sprintf("tgw-route-table-%d", i+1)
+		}
+		if _, exists := plan.RouteTableLabels[routeTable.Name]; exists {
+			reportWarn(config, fmt.Sprintf("中转网关路由表 %s 重复定义，已忽略", routeTable.Name))
+			continue
+		}
+		plan.RouteTableLabels[routeTable.Name] = fmt.Sprintf("tgw_rt_%d", len(plan.RouteTables))
+		plan.RouteTables = append(plan.RouteTables, routeTable)
+	}
+	plan.UseDefaultRouteTable = len(plan.RouteTables) == 0 || tgwConfig.DefaultRouteTable
+
+	for _, attachment := range resolveTransitAttachments(config, propsMap) {
+		vpcs := resolveVpcs(config)
+		if attachment.VpcIndex < 0 || attachment.VpcIndex >= len(vpcs) {
+			reportWarn(config, fmt.Sprintf("中转网关挂载 %s 引用的VPC索引 %d 不存在，已忽略", attachment.Name, attachment.VpcIndex))
+			continue
+		}
+		if _, exists := plan.AttachmentLabels[attachment.Name]; exists {
+			reportWarn(config, fmt.Sprintf("中转网关挂载 %s 重复定义，已忽略", attachment.Name))
+			continue
+		}
+
+		resolved := transitAttachment{
+			Name:    attachment.Name,
+			Label:   fmt.Sprintf("tgw_attachment_%d", len(plan.Attachments)),
+			Vpc:     vpcs[attachment.VpcIndex],
+			Subnets: transitAttachmentSubnets(config, attachment),
+		}
+		if len(resolved.Subnets) == 0 && config.CloudProvider != "azure" && config.CloudProvider != "tencent" {
+			reportWarn(config, fmt.Sprintf("中转网关挂载 %s 所在的VPC %s 没有可用子网，已忽略", attachment.Name, resolved.Vpc.Name))
+			continue
+		}
+
+		if attachment.RouteTable != "" {
+			if _, ok := plan.RouteTableLabels[attachment.RouteTable]; ok {
+				resolved.RouteTable = attachment.RouteTable
+			} else {
+				reportWarn(config, fmt.Sprintf("中转网关挂载 %s 引用的路由表 %s 不存在，已忽略", attachment.Name, attachment.RouteTable))
+			}
+		}
+		for _, name := range attachment.Propagations {
+			if _, ok := plan.RouteTableLabels[name]; ok {
+				resolved.Propagate = append(resolved.Propagate, name)
+			} else {
+				reportWarn(config, fmt.Sprintf("中转网关挂载 %s 传播的路由表 %s 不存在，已忽略", attachment.Name, name))
+			}
+		}
+
+		// 不使用默认路由表时，未指定关联的挂载关联到第一个路由表，并传播到所有路由表
+		if !plan.UseDefaultRouteTable {
+			if resolved.RouteTable == "" {
+				resolved.RouteTable = plan.RouteTables[0].Name
+			}
+			if len(resolved.Propagate) == 0 {
+				for _, routeTable := range plan.RouteTables {
+					resolved.Propagate = append(resolved.Propagate, routeTable.Name)
+				}
+			}
+		}
+
+		plan.AttachmentLabels[resolved.Name] = resolved.Label
+		plan.Attachments = append(plan.Attachments, resolved)
+	}
+
+	// 静态路由的下一跳必须是已定义的挂载，黑洞路由除外
+	for i := range plan.RouteTables {
+		var routes []models.TransitGatewayRoute
+		for _, route := range plan.RouteTables[i].Routes {
+			if _, _, err := net.ParseCIDR(route.DestinationCidr); err != nil {
+				reportWarn(config, fmt.Sprintf("路由表 %s 中的目标网段 %s 无效，已忽略", plan.RouteTables[i].Name, route.DestinationCidr))
+				continue
+			}
+			if _, ok := plan.AttachmentLabels[route.Attachment]; !ok && !route.Blackhole {
+				reportWarn(config, fmt.Sprintf("路由表 %s 中到 %s 的路由引用的挂载 %s 不存在，已忽略", plan.RouteTables[i].Name, route.DestinationCidr, route.Attachment))
+				continue
+			}
+			routes = append(routes, route)
+		}
+		plan.RouteTables[i].Routes = routes
+	}
+
+	return plan
+}
+
+// resolveTransitAttachments 读取挂载定义，兼容旧版的tgwAttachments和EnableVpcAttachment
+func resolveTransitAttachments(config renderContext, propsMap map[string]interface{}) []models.TransitGatewayAttachment {
+	tgwConfig := config.ComponentConfig.TransitGatewayConfig
+
+	var legacyAttachments []legacyTransitAttachment
+	if decodeListProp(propsMap, "tgwAttachments", &legacyAttachments) {
+		var attachments []models.TransitGatewayAttachment
+		for i, legacy := range legacyAttachments {
+			attachment := legacy.TransitGatewayAttachment
+			if attachment.Name == "" {
+				attachment.Name = fmt.Sprintf("tgw-attachment-%d", i+1)
+			}
+			// 旧版vpcId只接受同一部署中的VPC名称、索引或Terraform地址，不再直接写入HCL
+			if legacy.VpcId != "" {
+				vpcIndex, ok := resolveVpcReference(config, legacy.VpcId)
+				if !ok {
+					reportWarn(config, fmt.Sprintf("中转网关挂载 %s 的vpcId %s 不是本部署中的VPC，已忽略", attachment.Name, legacy.VpcId))
+					continue
+				}
+				attachment.VpcIndex = vpcIndex
+			}
+			if legacy.SubnetIds != "" && len(attachment.SubnetNames) == 0 {
+				attachment.SubnetNames = parseResourceNames(legacy.SubnetIds, subnetResourceTypes[config.CloudProvider])
+			}
+			attachments = append(attachments, attachment)
+		}
+		return attachments
+	}
+
+	if len(tgwConfig.Attachments) > 0 {
+		attachments := make([]models.TransitGatewayAttachment, len(tgwConfig.Attachments))
+		copy(attachments, tgwConfig.Attachments)
+		for i := range attachments {
+			if attachments[i].Name == "" {
+				attachments[i].Name = fmt.Sprintf("tgw-attachment-%d", i+1)
+			}
+		}
+		return attachments
+	}
+
+	if !config.ComponentConfig.EnableVpcAttachment {
+		return nil
+	}
+
+	// 为每个VPC创建一个挂载
+	attachmentName := tgwConfig.AttachmentName
+	if attachmentName == "" {
+		attachmentName = "tgw-attachment"
+	}
+	vpcs := resolveVpcs(config)
+	var attachments []models.TransitGatewayAttachment
+	for i := range vpcs {
+		attachment := models.TransitGatewayAttachment{Name: fmt.Sprintf("%s-%d", attachmentName, i+1), VpcIndex: i}
+		if len(vpcs) == 1 {
+			attachment.Name = attachmentName
+			if tgwConfig.SubnetIds != "" {
+				attachment.SubnetNames = parseResourceNames(tgwConfig.SubnetIds, subnetResourceTypes[config.CloudProvider])
+			}
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments
+}
+
+// resolveVpcReference 将VPC名称、索引或Terraform地址（如 aws_vpc.main.id）解析为VPC索引
+func resolveVpcReference(config renderContext, ref string) (int, bool) {
+	names := parseResourceNames(ref, vpcResourceTypes[config.CloudProvider])
+	if len(names) != 1 {
+		return 0, false
+	}
+	vpcs := resolveVpcs(config)
+	for i, vpc := range vpcs {
+		if matchesLogicalName(names[0], vpc.Name, vpc.LogicalID) {
+			return i, true
+		}
+	}
+	if index, err := strconv.Atoi(names[0]); err == nil && index >= 0 && index < len(vpcs) {
+		return index, true
+	}
+	return 0, false
+}
+
+// parseResourceNames 解析逗号分隔的资源名称，去除Terraform地址中的资源类型前缀和.id后缀
+func parseResourceNames(value, resourceType string) []string {
+	var names []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		item = strings.TrimPrefix(item, resourceType+".")
+		item = strings.TrimSuffix(item, ".id")
+		if item != "" {
+			names = append(names, item)
+		}
+	}
+	return names
+}
+
+// transitAttachmentSubnets 返回挂载使用的子网，只保留属于该VPC的子网，并且每个可用区只取一个
+func transitAttachmentSubnets(config renderContext, attachment models.TransitGatewayAttachment) []models.Subnet {
+	vpc := resolveVpcs(config)[attachment.VpcIndex]
+	wanted := make(map[string]bool)
+	for _, name := range attachment.SubnetNames {
+		wanted[name] = true
+	}
+
+	var subnets []models.Subnet
+	zones := make(map[string]bool)
+	for _, subnet := range resolveSubnets(config) {
+		if subnetVpc(config, subnet).Name != vpc.Name {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[subnet.Name] {
+			continue
+		}
+		zone := subnetZone(config, subnet)
+		if zones[zone] {
+			continue
+		}
+		zones[zone] = true
+		subnets = append(subnets, subnet)
+	}
+	return subnets
+}
+
+// transitEnableFlag 将布尔值转换为AWS中转网关使用的enable/disable
+func transitEnableFlag(enabled bool) string {
+	if enabled {
+		return "enable"
+	}
+	return "disable"
+}
+
+// generateAwsTransitGateway 生成AWS Transit Gateway、路由表、挂载、关联、传播和静态路由
+func generateAwsTransitGateway(config renderContext, plan transitPlan) string {
+	tgwConfig := config.ComponentConfig.TransitGatewayConfig
+	var tgw strings.Builder
+
+	tgw.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway" "tgw" {
+  description                     = "%s"
+  auto_accept_shared_attachments  = "%s"
+  default_route_table_association = "%s"
+  default_route_table_propagation = "%s"
+  dns_support                     = "%s"
+  vpn_ecmp_support                = "%s"
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+`, hclStringContent(plan.Description), plan.AutoAccept, transitEnableFlag(plan.UseDefaultRouteTable), transitEnableFlag(plan.UseDefaultRouteTable),
+		plan.DnsSupport, plan.VpnEcmpSupport, hclStringContent(plan.Name)))
+
+	for _, routeTable := range plan.RouteTables {
+		tgw.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway_route_table" "%s" {
+  transit_gateway_id = aws_ec2_transit_gateway.tgw.id
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+`, plan.RouteTableLabels[routeTable.Name], hclStringContent(routeTable.Name)))
+	}
+
+	for _, attachment := range plan.Attachments {
+		subnetIds := make([]string, 0, len(attachment.Subnets))
+		for _, subnet := range attachment.Subnets {
+			subnetIds = append(subnetIds, subnetAddress("aws", subnet.Name)+".id")
+		}
+
+		tgw.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway_vpc_attachment" "%s" {
+  transit_gateway_id = aws_ec2_transit_gateway.tgw.id
+  vpc_id             = %s.id
+  subnet_ids         = %s
+
+  dns_support                                     = "%s"
+  ipv6_support                                    = "%s"
+  transit_gateway_default_route_table_association = %t
+  transit_gateway_default_route_table_propagation = %t
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+`, attachment.Label, vpcAddress("aws", attachment.Vpc.Name), hclList(subnetIds),
+			transitEnableFlag(tgwConfig.DnsSupport), transitEnableFlag(tgwConfig.Ipv6Support || attachment.Vpc.EnableIpv6),
+			attachment.RouteTable == "", len(attachment.Propagate) == 0, hclStringContent(attachment.Name)))
+
+		if attachment.RouteTable != "" {
+			tgw.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway_route_table_association" "%s" {
+  transit_gateway_attachment_id  = aws_ec2_transit_gateway_vpc_attachment.%s.id
+  transit_gateway_route_table_id = aws_ec2_transit_gateway_route_table.%s.id
+}
+
+`, attachment.Label, attachment.Label, plan.RouteTableLabels[attachment.RouteTable]))
+		}
+
+		for _, name := range attachment.Propagate {
+			routeTableLabel := plan.RouteTableLabels[name]
+			tgw.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway_route_table_propagation" "%s_%s" {
+  transit_gateway_attachment_id  = aws_ec2_transit_gateway_vpc_attachment.%s.id
+  transit_gateway_route_table_id = aws_ec2_transit_gateway_route_table.%s.id
+}
+
+`, attachment.Label, routeTableLabel, attachment.Label, routeTableLabel))
+		}
+
+		LogInfo(fmt.Sprintf("已生成中转网关挂载配置: 名称=%s, VPC=%s", attachment.Name, attachment.Vpc.Name))
+	}
+
+	for _, routeTable := range plan.RouteTables {
+		routeTableLabel := plan.RouteTableLabels[routeTable.Name]
+		for i, route := range routeTable.Routes {
+			target := "blackhole                      = true"
+			if !route.Blackhole {
+				target = fmt.Sprintf("transit_gateway_attachment_id  = aws_ec2_transit_gateway_vpc_attachment.%s.id", plan.AttachmentLabels[route.Attachment])
+			}
+			tgw.WriteString(fmt.Sprintf(`resource "aws_ec2_transit_gateway_route" "%s_route_%d" {
+  destination_cidr_block         = "%s"
+  %s
+  transit_gateway_route_table_id = aws_ec2_transit_gateway_route_table.%s.id
+}
+
+`, routeTableLabel, i+1, route.DestinationCidr, target, routeTableLabel))
+		}
+	}
+
+	return tgw.String()
+}
+
+// generateAlicloudTransitRouter 生成阿里云云企业网CEN及转发路由器配置
+// 使用默认路由表时关联到转发路由器的系统路由表
+func generateAlicloudTransitRouter(plan transitPlan) string {
+	var cen strings.Builder
+	cen.WriteString(fmt.Sprintf(`resource "alicloud_cen_instance" "tgw" {
+  cen_instance_name = "%s"
+  description       = "%s"
+
+  tags = local.common_tags
+}
+
+resource "alicloud_cen_transit_router" "tgw" {
+  cen_id              = alicloud_cen_instance.tgw.id
+  transit_router_name = "%s"
+
+  tags = local.common_tags
+}
+
+`, hclStringContent(plan.Name), hclStringContent(plan.Description), hclStringContent(plan.Name)))
+
+	routeTableRef := func(name string) string {
+		if name == "" {
+			return "data.alicloud_cen_transit_router_route_tables.tgw_system.tables[0].id"
+		}
+		return fmt.Sprintf("alicloud_cen_transit_router_route_table.%s.transit_router_route_table_id", plan.RouteTableLabels[name])
+	}
+
+	if plan.UseDefaultRouteTable {
+		cen.WriteString(`data "alicloud_cen_transit_router_route_tables" "tgw_system" {
+  transit_router_id               = alicloud_cen_transit_router.tgw.transit_router_id
+  transit_router_route_table_type = "System"
+}
+
+`)
+	}
+
+	for _, routeTable := range plan.RouteTables {
+		cen.WriteString(fmt.Sprintf(`resource "alicloud_cen_transit_router_route_table" "%s" {
+  transit_router_id               = alicloud_cen_transit_router.tgw.transit_router_id
+  transit_router_route_table_name = "%s"
+}
+
+`, plan.RouteTableLabels[routeTable.Name], hclStringContent(routeTable.Name)))
+	}
+
+	for _, attachment := range plan.Attachments {
+		var zoneMappings strings.Builder
+		for _, subnet := range attachment.Subnets {
+			zoneMappings.WriteString(fmt.Sprintf(`
+
+  zone_mappings {
+    zone_id    = %s.zone_id
+    vswitch_id = %s.id
+  }`, subnetAddress("alicloud", subnet.Name), subnetAddress("alicloud", subnet.Name)))
+		}
+
+		cen.WriteString(fmt.Sprintf(`resource "alicloud_cen_transit_router_vpc_attachment" "%s" {
+  cen_id                         = alicloud_cen_instance.tgw.id
+  transit_router_id              = alicloud_cen_transit_router.tgw.transit_router_id
+  vpc_id                         = %s.id
+  transit_router_attachment_name = "%s"%s
+}
+
+resource "alicloud_cen_transit_router_route_table_association" "%s" {
+  transit_router_route_table_id = %s
+  transit_router_attachment_id  = alicloud_cen_transit_router_vpc_attachment.%s.transit_router_attachment_id
+}
+
+`, attachment.Label, vpcAddress("alicloud", attachment.Vpc.Name), hclStringContent(attachment.Name), zoneMappings.String(),
+			attachment.Label, routeTableRef(attachment.RouteTable), attachment.Label))
+
+		propagations := attachment.Propagate
+		if len(propagations) == 0 {
+			propagations = []string{""}
+		}
+		for _, name := range propagations {
+			label := attachment.Label + "_system"
+			if name != "" {
+				label = attachment.Label + "_" + plan.RouteTableLabels[name]
+			}
+			cen.WriteString(fmt.Sprintf(`resource "alicloud_cen_transit_router_route_table_propagation" "%s" {
+  transit_router_route_table_id = %s
+  transit_router_attachment_id  = alicloud_cen_transit_router_vpc_attachment.%s.transit_router_attachment_id
+}
+
+`, label, routeTableRef(name), attachment.Label))
+		}
+	}
+
+	for _, routeTable := range plan.RouteTables {
+		routeTableLabel := plan.RouteTableLabels[routeTable.Name]
+		for i, route := range routeTable.Routes {
+			nextHop := `transit_router_route_entry_next_hop_type          = "BlackHole"`
+			if !route.Blackhole {
+				nextHop = fmt.Sprintf(`transit_router_route_entry_next_hop_type          = "Attachment"
+  transit_router_route_entry_next_hop_id            = alicloud_cen_transit_router_vpc_attachment.%s.transit_router_attachment_id`, plan.AttachmentLabels[route.Attachment])
+			}
+			cen.WriteString(fmt.Sprintf(`resource "alicloud_cen_transit_router_route_entry" "%s_route_%d" {
+  transit_router_route_table_id                     = alicloud_cen_transit_router_route_table.%s.transit_router_route_table_id
+  transit_router_route_entry_name                   = "%s-route-%d"
+  transit_router_route_entry_destination_cidr_block = "%s"
+  %s
+}
+
+`, routeTableLabel, i+1, routeTableLabel, hclStringContent(routeTable.Name), i+1, route.DestinationCidr, nextHop))
+		}
+	}
+
+	return cen.String()
+}
+
+// generateTencentCcn 生成腾讯云云联网配置
+// 云联网根据挂载的VPC自动学习路由，不支持静态路由，自定义路由表通过实例关联配置生效
+func generateTencentCcn(config renderContext, plan transitPlan) string {
+	var ccn strings.Builder
+	ccn.WriteString(fmt.Sprintf(`resource "tencentcloud_ccn" "tgw" {
+  name                 = "%s"
+  description          = "%s"
+  qos                  = "AU"
+  charge_type          = "POSTPAID"
+  bandwidth_limit_type = "OUTER_REGION_LIMIT"
+
+  tags = local.common_tags
+}
+
+`, hclStringContent(plan.Name), hclStringContent(plan.Description)))
+
+	for _, attachment := range plan.Attachments {
+		ccn.WriteString(fmt.Sprintf(`resource "tencentcloud_ccn_attachment" "%s" {
+  ccn_id          = tencentcloud_ccn.tgw.id
+  instance_type   = "VPC"
+  instance_id     = %s.id
+  instance_region = "%s"
+}
+
+`, attachment.Label, vpcAddress("tencent", attachment.Vpc.Name), config.Region))
+	}
+
+	for _, routeTable := range plan.RouteTables {
+		routeTableLabel := plan.RouteTableLabels[routeTable.Name]
+		ccn.WriteString(fmt.Sprintf(`resource "tencentcloud_ccn_route_table" "%s" {
+  ccn_id      = tencentcloud_ccn.tgw.id
+  name        = "%s"
+  description = "Route table %s"
+}
+
+`, routeTableLabel, hclStringContent(routeTable.Name), hclStringContent(routeTable.Name)))
+
+		var instances strings.Builder
+		var dependsOn []string
+		for _, attachment := range plan.Attachments {
+			if attachment.RouteTable != routeTable.Name {
+				continue
+			}
+			instances.WriteString(fmt.Sprintf(`
+
+  instances {
+    instance_id   = %s.id
+    instance_type = "VPC"
+  }`, vpcAddress("tencent", attachment.Vpc.Name)))
+			dependsOn = append(dependsOn, "tencentcloud_ccn_attachment."+attachment.Label)
+		}
+		if instances.Len() > 0 {
+			ccn.WriteString(fmt.Sprintf(`resource "tencentcloud_ccn_route_table_associate_instance_config" "%s" {
+  ccn_id         = tencentcloud_ccn.tgw.id
+  route_table_id = tencentcloud_ccn_route_table.%s.id%s
+
+  depends_on = %s
+}
+
+`, routeTableLabel, routeTableLabel, instances.String(), hclList(dependsOn)))
+		}
+
+		if len(routeTable.Routes) > 0 {
+			reportWarn(config, fmt.Sprintf("腾讯云云联网不支持静态路由，路由表 %s 中的 %d 条路由已忽略", routeTable.Name, len(routeTable.Routes)))
+		}
+	}
+
+	return ccn.String()
+}
+
+// generateHuaweiEnterpriseRouter 生成华为云企业路由器配置
+// 使用默认路由表时，挂载由企业路由器自动关联和传播到默认路由表
+func generateHuaweiEnterpriseRouter(config renderContext, plan transitPlan, asn int) string {
+	var zones []string
+	seen := make(map[string]bool)
+	for _, attachment := range plan.Attachments {
+		for _, subnet := range attachment.Subnets {
+			zone := subnetZone(config, subnet)
+			if !seen[zone] {
+				seen[zone] = true
+				zones = append(zones, zone)
+			}
+		}
+	}
+	if len(zones) == 0 {
+		zones = []string{config.AZ}
+	}
+
+	var er strings.Builder
+	er.WriteString(fmt.Sprintf(`resource "huaweicloud_er_instance" "tgw" {
+  name                           = "%s"
+  description                    = "%s"
+  asn                            = %d
+  availability_zones             = %s
+  enable_default_association     = %t
+  enable_default_propagation     = %t
+  auto_accept_shared_attachments = %t
+
+  tags = local.common_tags
+}
+
+`, hclStringContent(plan.Name), hclStringContent(plan.Description), asn, hclStringList(zones), plan.UseDefaultRouteTable, plan.UseDefaultRouteTable, plan.AutoAccept == "enable"))
+
+	for _, routeTable := range plan.RouteTables {
+		er.WriteString(fmt.Sprintf(`resource "huaweicloud_er_route_table" "%s" {
+  instance_id = huaweicloud_er_instance.tgw.id
+  name        = "%s"
+}
+
+`, plan.RouteTableLabels[routeTable.Name], hclStringContent(routeTable.Name)))
+	}
+
+	for _, attachment := range plan.Attachments {
+		if len(attachment.Subnets) > 1 {
+			LogInfo(fmt.Sprintf("华为云企业路由器VPC连接只使用一个子网，挂载 %s 使用子网 %s", attachment.Name, attachment.Subnets[0].Name))
+		}
+		er.WriteString(fmt.Sprintf(`resource "huaweicloud_er_vpc_attachment" "%s" {
+  instance_id            = huaweicloud_er_instance.tgw.id
+  vpc_id                 = %s.id
+  subnet_id              = %s.id
+  name                   = "%s"
+  auto_create_vpc_routes = true
+}
+
+`, attachment.Label, vpcAddress("huawei", attachment.Vpc.Name), subnetAddress("huawei", attachment.Subnets[0].Name), hclStringContent(attachment.Name)))
+
+		if attachment.RouteTable != "" {
+			if plan.UseDefaultRouteTable {
+				reportWarn(config, fmt.Sprintf("华为云企业路由器启用默认路由表时挂载会自动关联默认路由表，挂载 %s 的路由表关联 %s 已忽略", attachment.Name, attachment.RouteTable))
+			} else {
+				er.WriteString(fmt.Sprintf(`resource "huaweicloud_er_association" "%s" {
+  instance_id    = huaweicloud_er_instance.tgw.id
+  route_table_id = huaweicloud_er_route_table.%s.id
+  attachment_id  = huaweicloud_er_vpc_attachment.%s.id
+}
+
+`, attachment.Label, plan.RouteTableLabels[attachment.RouteTable], attachment.Label))
+			}
+		}
+
+		for _, name := range attachment.Propagate {
+			routeTableLabel := plan.RouteTableLabels[name]
+			er.WriteString(fmt.Sprintf(`resource "huaweicloud_er_propagation" "%s_%s" {
+  instance_id    = huaweicloud_er_instance.tgw.id
+  route_table_id = huaweicloud_er_route_table.%s.id
+  attachment_id  = huaweicloud_er_vpc_attachment.%s.id
+}
+
+`, attachment.Label, routeTableLabel, routeTableLabel, attachment.Label))
+		}
+	}
+
+	for _, routeTable := range plan.RouteTables {
+		routeTableLabel := plan.RouteTableLabels[routeTable.Name]
+		for i, route := range routeTable.Routes {
+			target := "is_blackhole   = true"
+			if !route.Blackhole {
+				target = fmt.Sprintf("attachment_id  = huaweicloud_er_vpc_attachment.%s.id", plan.AttachmentLabels[route.Attachment])
+			}
+			er.WriteString(fmt.Sprintf(`resource "huaweicloud_er_static_route" "%s_route_%d" {
+  route_table_id = huaweicloud_er_route_table.%s.id
+  destination    = "%s"
+  %s
+}
+
+`, routeTableLabel, i+1, routeTableLabel, route.DestinationCidr, target))
+		}
+	}
+
+	return er.String()
+}
+
+// generateAzureVirtualWan 生成Azure虚拟WAN、虚拟Hub、Hub路由表和VNet连接
+func generateAzureVirtualWan(config renderContext, plan transitPlan, hubAddressPrefix string) string {
+	var wan strings.Builder
+	wan.WriteString(fmt.Sprintf(`resource "azurerm_virtual_wan" "tgw" {
+  name                = "%s"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+
+  tags = local.common_tags
+}
+
+resource "azurerm_virtual_hub" "tgw" {
+  name                = "%s-hub"
+  resource_group_name = azurerm_resource_group.rg.name
+  location            = azurerm_resource_group.rg.location
+  virtual_wan_id      = azurerm_virtual_wan.tgw.id
+  address_prefix      = "%s"
+
+  tags = local.common_tags
+}
+
+`, hclStringContent(plan.Name), hclStringContent(plan.Name), hubAddressPrefix))
+
+	routeTableRef := func(name string) string {
+		if name == "" {
+			return "azurerm_virtual_hub.tgw.default_route_table_id"
+		}
+		return fmt.Sprintf("azurerm_virtual_hub_route_table.%s.id", plan.RouteTableLabels[name])
+	}
+
+	for _, routeTable := range plan.RouteTables {
+		wan.WriteString(fmt.Sprintf(`resource "azurerm_virtual_hub_route_table" "%s" {
+  name           = "%s"
+  virtual_hub_id = azurerm_virtual_hub.tgw.id
+  labels         = ["%s"]
+}
+
+`, plan.RouteTableLabels[routeTable.Name], hclStringContent(routeTable.Name), hclStringContent(routeTable.Name)))
+	}
+
+	for _, attachment := range plan.Attachments {
+		propagations := []string{routeTableRef("")}
+		if len(attachment.Propagate) > 0 {
+			propagations = nil
+			for _, name := range attachment.Propagate {
+				propagations = append(propagations, routeTableRef(name))
+			}
+		}
+
+		wan.WriteString(fmt.Sprintf(`resource "azurerm_virtual_hub_connection" "%s" {
+  name                      = "%s"
+  virtual_hub_id            = azurerm_virtual_hub.tgw.id
+  remote_virtual_network_id = %s.id
+
+  routing {
+    associated_route_table_id = %s
+
+    propagated_route_table {
+      route_table_ids = %s
+    }
+  }
+}
+
+`, attachment.Label, hclStringContent(attachment.Name), vpcAddress("azure", attachment.Vpc.Name), routeTableRef(attachment.RouteTable), hclList(propagations)))
+	}
+
+	// 路由通过独立资源定义，避免Hub路由表与VNet连接之间的循环依赖
+	for _, routeTable := range plan.RouteTables {
+		routeTableLabel := plan.RouteTableLabels[routeTable.Name]
+		for i, route := range routeTable.Routes {
+			if route.Blackhole {
+				reportWarn(config, fmt.Sprintf("Azure虚拟Hub路由表不支持黑洞路由，路由表 %s 中到 %s 的路由已忽略", routeTable.Name, route.DestinationCidr))
+				continue
+			}
+			wan.WriteString(fmt.Sprintf(`resource "azurerm_virtual_hub_route_table_route" "%s_route_%d" {
+  route_table_id    = azurerm_virtual_hub_route_table.%s.id
+  name              = "%s-route-%d"
+  destinations_type = "CIDR"
+  destinations      = ["%s"]
+  next_hop_type     = "ResourceId"
+  next_hop          = azurerm_virtual_hub_connection.%s.id
+}
+
+`, routeTableLabel, i+1, routeTableLabel, hclStringContent(routeTable.Name), i+1, route.DestinationCidr, plan.AttachmentLabels[route.Attachment]))
+		}
+	}
+
+	return wan.String()
+}
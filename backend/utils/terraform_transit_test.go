@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateTransitGatewayConfigUserStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		props    map[string]interface{}
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "aws name and description are escaped",
+			provider: "aws",
+			props:    map[string]interface{}{"name": `tgw" } resource "null_resource" "pwn" {`, "description": "hub ${var.x}"},
+			want:     []string{`Name = "tgw\" } resource \"null_resource\" \"pwn\" {"`, `description                     = "hub $${var.x}"`},
+		},
+		{
+			name:     "alicloud name is escaped",
+			provider: "alicloud",
+			props:    map[string]interface{}{"name": `cen"x`},
+			want:     []string{`cen_instance_name = "cen\"x"`},
+		},
+		{
+			name:     "invalid switch value is rejected",
+			provider: "aws",
+			props:    map[string]interface{}{"dns_support": `enable" }`},
+			want:     []string{`dns_support                     = "enable"`},
+			wantErr:  true,
+		},
+		{
+			name:     "azure hub prefix must be a cidr",
+			provider: "azure",
+			props:    map[string]interface{}{"hub_address_prefix": `10.0.0.0/23" }`},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig(tt.provider, "us-east-1"))
+			body := generateTransitGatewayConfig(config, tt.props)
+			if got := HasErrorFindings(config.findings.Findings()); got != tt.wantErr {
+				t.Fatalf("error findings = %v, want %v: %v", got, tt.wantErr, config.findings.Findings())
+			}
+			if strings.Contains(body, `resource "null_resource"`) {
+				t.Errorf("user input created a resource:\n%s", body)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+		})
+	}
+}
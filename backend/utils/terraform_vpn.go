@@ -0,0 +1,750 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/multi-cloud-landing-zone/backend/models"
+)
+
+// vpnProviders 支持跨云VPN的云提供商
+var vpnProviders = map[string]bool{
+	"aws":      true,
+	"azure":    true,
+	"alicloud": true,
+	"huawei":   true,
+	"tencent":  true,
+}
+
+// vpnRouteTableProviders 启用子网路由表时，VPN路由写入子网路由表的云提供商
+// Azure虚拟网络网关的路由自动传播到VNet中的所有路由表，不需要额外的路由条目
+var vpnRouteTableProviders = map[string]bool{
+	"aws":      true,
+	"alicloud": true,
+	"huawei":   true,
+	"tencent":  true,
+}
+
+// vpnNameRegexp 跨云VPN名称的字符集，名称同时用于生成资源标签
+var vpnNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9 ._-]{0,63}$`)
+
+// vpnRoute 表示VPC中指向VPN网关的路由
+type vpnRoute struct {
+	DestinationCidr string
+	VpnLabel        string
+}
+
+// vpnTunnel 表示对端网关的一个隧道地址，对应本端的一个对端网关和IPsec连接
+type vpnTunnel struct {
+	Label   string
+	Name    string
+	Address string // 对端隧道公网IP的HCL表达式
+	Weight  int    // 到对端网段的路由权重
+}
+
+// vpnSide 表示跨云VPN一端的VPC
+// 本端和多云部署中其他分段的VPC引用VPC资源，另一云上已有的VPC通过ID引用
+type vpnSide struct {
+	Provider      string
+	Region        string
+	Existing      bool   // 是否为不由本配置管理的已有VPC
+	SubnetRoutes  bool   // 是否由路由表生成器将VPN路由写入该VPC的所有子网路由表
+	VpcName       string // 本配置管理的VPC的资源名称
+	VpcId         string // VPC ID的HCL表达式
+	VpcCidr       string
+	SubnetId      string // VPN网关所在子网ID的HCL表达式，可为空
+	AzureVnet     string // Azure VNet名称的HCL表达式
+	AzureGroup    string // Azure资源组名称的HCL表达式
+	AzureLocation string // Azure区域的HCL表达式
+}
+
+// crossCloudVpn 表示解析后的跨云VPN
+type crossCloudVpn struct {
+	Name   string
+	Label  string
+	Local  vpnSide
+	Remote vpnSide
+}
+
+// generateCrossCloudVpnConfig 生成本部署VPC与另一云上VPC之间的站点到站点VPN
+// 两端的VPN网关、对端网关和IPsec连接在同一份配置中生成，预共享密钥由random_password生成，只保存在Terraform状态中
+func generateCrossCloudVpnConfig(config renderContext, propsMap map[string]interface{}) string {
+	if !vpnProviders[config.CloudProvider] {
+		reportWarn(config, fmt.Sprintf("云提供商 %s 暂不支持跨云VPN组件", config.CloudProvider))
+		return ""
+	}
+
+	vpns := resolveCrossCloudVpns(config, propsMap)
+	if len(vpns) == 0 {
+		reportWarn(config, "跨云VPN组件没有有效的连接配置，未生成VPN")
+		return ""
+	}
+
+	var vpnConfig strings.Builder
+
+	// 已有的对端VPC需要对应云提供商的provider，每个云提供商只生成一个provider配置块
+	remoteRegions := make(map[string]string)
+	for _, vpn := range vpns {
+		if !vpn.Remote.Existing {
+			continue
+		}
+		region, exists := remoteRegions[vpn.Remote.Provider]
+		if !exists {
+			remoteRegions[vpn.Remote.Provider] = vpn.Remote.Region
+			vpnConfig.WriteString(generateProviderBlock(vpn.Remote.Provider, vpn.Remote.Region, "", providerDefaultTags(vpn.Remote.Provider, resolveTags(config))))
+			vpnConfig.WriteString("\n")
+		} else if region != vpn.Remote.Region {
+			reportWarn(config, fmt.Sprintf("跨云VPN %s 的对端区域 %s 与同一云提供商的其他连接不同，将使用区域 %s", vpn.Name, vpn.Remote.Region, region))
+		}
+	}
+
+	for _, vpn := range vpns {
+		// AWS预共享密钥只能包含字母、数字、句点和下划线，且不能以0开头，因此只使用字母
+		vpnConfig.WriteString(fmt.Sprintf(`resource "random_password" "%s" {
+  length  = 32
+  special = false
+  numeric = false
+}
+
+`, vpn.Label))
+
+		psk := fmt.Sprintf("random_password.%s.result", vpn.Label)
+		for _, side := range []vpnSide{vpn.Local, vpn.Remote} {
+			vpnConfig.WriteString(generateVpnGateway(config, vpn, side))
+		}
+		vpnConfig.WriteString(generateVpnConnection(vpn, vpn.Local, vpn.Remote, psk))
+		vpnConfig.WriteString(generateVpnConnection(vpn, vpn.Remote, vpn.Local, psk))
+
+		LogInfo(fmt.Sprintf("已生成跨云VPN配置: 名称=%s, %s(%s) <-> %s(%s)", vpn.Name, vpn.Local.Provider, vpn.Local.VpcCidr, vpn.Remote.Provider, vpn.Remote.VpcCidr))
+	}
+	return vpnConfig.String()
+}
+
+// resolveCrossCloudVpns 解析跨云VPN配置
+// 优先使用组件属性vpnConnections，其次使用ComponentConfig.CrossCloudVpns，最后使用组件属性中的单个连接
+func resolveCrossCloudVpns(config renderContext, propsMap map[string]interface{}) []crossCloudVpn {
+	var definitions []models.CrossCloudVpn
+	if !decodeListProp(propsMap, "vpnConnections", &definitions) {
+		definitions = config.ComponentConfig.CrossCloudVpns
+	}
+	if len(definitions) == 0 {
+		definition := models.CrossCloudVpn{
+			Name:           getStringProp(propsMap, "name", ""),
+			LocalVpcIndex:  getIntProp(propsMap, "local_vpc_index", 0),
+			RemoteProvider: getStringProp(propsMap, "remote_provider", ""),
+			RemoteRegion:   getStringProp(propsMap, "remote_region", ""),
+			RemoteVpcId:    getStringProp(propsMap, "remote_vpc_id", ""),
+			RemoteVpcCidr:  getStringProp(propsMap, "remote_vpc_cidr", ""),
+			RemoteSubnetId: getStringProp(propsMap, "remote_subnet_id", ""),
+			RemoteVpcName:  getStringProp(propsMap, "remote_vpc_name", ""),
+		}
+		if definition.RemoteProvider != "" {
+			definitions = append(definitions, definition)
+		}
+	}
+
+	vpcs := resolveVpcs(config)
+	var vpns []crossCloudVpn
+	labels := make(map[string]bool)
+	for i, definition := range definitions {
+		if definition.Name == "" {
+			definition.Name = fmt.Sprintf("vpn-%d", i+1)
+		}
+		if err := checkCrossCloudVpnValues(definition); err != nil {
+			reportError(config, fmt.Sprintf("跨云VPN %s 配置无效，未生成: %v", definition.Name, err))
+			continue
+		}
+		// 对端为多云部署中其他分段的VPC时，从该分段读取VPC信息
+		var remote vpnSide
+		if definition.RemoteVpcName != "" {
+			side, err := peerVpnSide(config, definition)
+			if err != nil {
+				reportWarn(config, fmt.Sprintf("跨云VPN %s 配置无效，已忽略: %v", definition.Name, err))
+				continue
+			}
+			remote = side
+			definition.RemoteRegion = side.Region
+			definition.RemoteVpcId = side.VpcId
+			definition.RemoteVpcCidr = side.VpcCidr
+			definition.RemoteSubnetId = side.SubnetId
+		} else {
+			remote = remoteVpnSide(definition)
+		}
+		if err := validateCrossCloudVpn(config, definition, len(vpcs)); err != nil {
+			reportWarn(config, fmt.Sprintf("跨云VPN %s 配置无效，已忽略: %v", definition.Name, err))
+			continue
+		}
+
+		label := "vpn_" + strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(definition.Name)
+		if labels[label] {
+			reportWarn(config, fmt.Sprintf("跨云VPN %s 重复定义，已忽略", definition.Name))
+			continue
+		}
+		local := localVpnSide(config, vpcs[definition.LocalVpcIndex])
+		if local.Provider == "huawei" && local.SubnetId == "" {
+			reportWarn(config, fmt.Sprintf("跨云VPN %s 的本端VPC %s 没有子网，华为云VPN网关需要子网，已忽略", definition.Name, vpcs[definition.LocalVpcIndex].Name))
+			continue
+		}
+		// 对端为AWS的阿里云连接为隧道2生成以_tunnel2结尾的资源，同样占用该标签
+		labels[label] = true
+		labels[label+"_tunnel2"] = true
+
+		vpns = append(vpns, crossCloudVpn{
+			Name:   definition.Name,
+			Label:  label,
+			Local:  local,
+			Remote: remote,
+		})
+	}
+	return vpns
+}
+
+// validateCrossCloudVpn 校验跨云VPN的本端VPC、对端云提供商和网段
+func validateCrossCloudVpn(config renderContext, definition models.CrossCloudVpn, vpcCount int) error {
+	if definition.LocalVpcIndex < 0 || definition.LocalVpcIndex >= vpcCount {
+		return fmt.Errorf("本端VPC索引 %d 不存在", definition.LocalVpcIndex)
+	}
+	if definition.RemoteProvider == config.CloudProvider {
+		return fmt.Errorf("对端云提供商不能与本端相同，同一云内的VPC请使用对等连接或中转网关")
+	}
+	if !vpnProviders[definition.RemoteProvider] {
+		return fmt.Errorf("不支持的对端云提供商 %s", definition.RemoteProvider)
+	}
+	if definition.RemoteRegion == "" || definition.RemoteVpcId == "" {
+		return fmt.Errorf("必须指定对端区域和对端VPC ID")
+	}
+	if _, _, err := net.ParseCIDR(definition.RemoteVpcCidr); err != nil {
+		return fmt.Errorf("对端VPC网段 %s 无效", definition.RemoteVpcCidr)
+	}
+	localCidr := resolveVpcs(config)[definition.LocalVpcIndex].CIDR
+	if cidrsOverlap(localCidr, definition.RemoteVpcCidr) {
+		return fmt.Errorf("本端网段 %s 与对端网段 %s 重叠", localCidr, definition.RemoteVpcCidr)
+	}
+	if definition.RemoteProvider == "huawei" && definition.RemoteSubnetId == "" {
+		return fmt.Errorf("华为云VPN网关必须指定对端子网ID")
+	}
+	if definition.RemoteProvider == "azure" && definition.RemoteVpcName == "" && len(strings.Split(definition.RemoteVpcId, "/")) < 9 {
+		return fmt.Errorf("Azure对端VPC ID必须是VNet的完整资源ID")
+	}
+	return nil
+}
+
+// checkCrossCloudVpnValues 校验会写入生成配置的用户输入
+// 对端为已有VPC时，对端区域会写入provider配置块，必须是对端云提供商支持的区域
+func checkCrossCloudVpnValues(definition models.CrossCloudVpn) error {
+	if !vpnNameRegexp.MatchString(definition.Name) {
+		return fmt.Errorf("名称 %q 只能包含字母、数字、空格、句点、下划线和连字符，且以字母或数字开头，长度不超过64", definition.Name)
+	}
+	if definition.RemoteVpcName == "" && definition.RemoteRegion != "" && !isKnownRegion(definition.RemoteProvider, definition.RemoteRegion) {
+		return fmt.Errorf("对端区域 %q 不是 %s 支持的区域", definition.RemoteRegion, definition.RemoteProvider)
+	}
+	return nil
+}
+
+// localVpnSide 返回本端VPN的VPC信息，VPN网关使用该VPC下的第一个子网
+func localVpnSide(config renderContext, vpc models.VPC) vpnSide {
+	provider := config.CloudProvider
+	side := vpnSide{
+		Provider:      provider,
+		Region:        config.Region,
+		SubnetRoutes:  config.ComponentConfig.EnableRouteTables && vpnRouteTableProviders[provider],
+		VpcName:       vpc.Name,
+		VpcId:         vpcAddress(provider, vpc.Name) + ".id",
+		VpcCidr:       vpc.CIDR,
+		AzureVnet:     vpcAddress(provider, vpc.Name) + ".name",
+		AzureGroup:    "azurerm_resource_group.rg.name",
+		AzureLocation: "azurerm_resource_group.rg.location",
+	}
+	for _, subnet := range resolveSubnets(config) {
+		if subnetVpc(config, subnet).Name == vpc.Name {
+			side.SubnetId = subnetAddress(provider, subnet.Name) + ".id"
+			break
+		}
+	}
+	return side
+}
+
+// vpcVpnRoutes 返回每个VPC中指向VPN网关的路由，由路由表生成器写入该VPC的所有子网路由表
+// 多云部署中其他分段的VPN以本分段的VPC为对端时，本分段的子网路由表同样需要到该VPN对端网段的路由
+func vpcVpnRoutes(config renderContext) map[string][]vpnRoute {
+	if !vpnRouteTableProviders[config.CloudProvider] {
+		return nil
+	}
+
+	routes := make(map[string][]vpnRoute)
+	if propsMap := componentPropsMap(config, "cross-cloud-vpn"); propsMap != nil {
+		for _, vpn := range resolveCrossCloudVpns(config, propsMap) {
+			routes[vpn.Local.VpcName] = append(routes[vpn.Local.VpcName], vpnRoute{DestinationCidr: vpn.Remote.VpcCidr, VpnLabel: vpn.Label})
+		}
+	}
+	for _, item := range config.Peers {
+		if item.CloudProvider == config.CloudProvider {
+			continue
+		}
+		peer := config.withConfig(item)
+		propsMap := componentPropsMap(peer, "cross-cloud-vpn")
+		if propsMap == nil {
+			continue
+		}
+		peer.Peers = config.Peers
+		for _, vpn := range resolveCrossCloudVpns(peer, propsMap) {
+			if vpn.Remote.Existing || vpn.Remote.Provider != config.CloudProvider || vpn.Remote.Region != config.Region {
+				continue
+			}
+			routes[vpn.Remote.VpcName] = append(routes[vpn.Remote.VpcName], vpnRoute{DestinationCidr: vpn.Local.VpcCidr, VpnLabel: vpn.Label})
+		}
+	}
+	return routes
+}
+
+// remoteVpnSide 返回对端VPN的VPC信息，对端VPC是另一云上已有的VPC
+func remoteVpnSide(definition models.CrossCloudVpn) vpnSide {
+	side := vpnSide{
+		Provider:      definition.RemoteProvider,
+		Region:        definition.RemoteRegion,
+		Existing:      true,
+		VpcId:         hclString(definition.RemoteVpcId),
+		VpcCidr:       definition.RemoteVpcCidr,
+		AzureLocation: hclString(definition.RemoteRegion),
+	}
+	if definition.RemoteSubnetId != "" {
+		side.SubnetId = hclString(definition.RemoteSubnetId)
+	}
+	if definition.RemoteProvider == "azure" {
+		// VNet资源ID格式: /subscriptions/<id>/resourceGroups/<group>/providers/Microsoft.Network/virtualNetworks/<name>
+		parts := strings.Split(definition.RemoteVpcId, "/")
+		side.AzureGroup = hclString(parts[4])
+		side.AzureVnet = hclString(parts[8])
+	}
+	return side
+}
+
+// peerVpnSide 在多云部署的其他分段中查找对端VPC
+func peerVpnSide(config renderContext, definition models.CrossCloudVpn) (vpnSide, error) {
+	for _, item := range config.Peers {
+		if item.CloudProvider != definition.RemoteProvider {
+			continue
+		}
+		peer := config.withConfig(item)
+		for _, vpc := range resolveVpcs(peer) {
+			if matchesLogicalName(definition.RemoteVpcName, vpc.Name, vpc.LogicalID) {
+				return localVpnSide(peer, vpc), nil
+			}
+		}
+	}
+	return vpnSide{}, fmt.Errorf("多云部署中没有 %s 分段的VPC %s", definition.RemoteProvider, definition.RemoteVpcName)
+}
+
+// vpnGatewayAddress 返回VPN网关公网IP的HCL表达式
+// AWS虚拟私有网关没有固定公网IP，使用VPN连接的隧道1地址
+func vpnGatewayAddress(vpn crossCloudVpn, side vpnSide) string {
+	switch side.Provider {
+	case "aws":
+		return fmt.Sprintf("aws_vpn_connection.%s.tunnel1_address", vpn.Label)
+	case "azure":
+		return fmt.Sprintf("azurerm_public_ip.%s.ip_address", vpn.Label)
+	case "alicloud":
+		return fmt.Sprintf("alicloud_vpn_gateway.%s.internet_ip", vpn.Label)
+	case "huawei":
+		return fmt.Sprintf("huaweicloud_vpc_eip.%s_1.address", vpn.Label)
+	case "tencent":
+		return fmt.Sprintf("tencentcloud_vpn_gateway.%s.public_ip_address", vpn.Label)
+	}
+	return ""
+}
+
+// azureGatewaySubnetCidr 返回VNet中GatewaySubnet的网段表达式，使用VNet地址空间中最后一个/27
+func azureGatewaySubnetCidr(config renderContext, vpcCidr string) string {
+	_, network, err := net.ParseCIDR(vpcCidr)
+	if err != nil {
+		return fmt.Sprintf("%q", vpcCidr)
+	}
+	prefix, _ := network.Mask.Size()
+	if prefix >= 27 {
+		reportWarn(config, fmt.Sprintf("VNet网段 %s 过小，无法划分/27的GatewaySubnet", vpcCidr))
+		return fmt.Sprintf("%q", vpcCidr)
+	}
+	newBits := 27 - prefix
+	return fmt.Sprintf("cidrsubnet(\"%s\", %d, %d)", vpcCidr, newBits, (1<<newBits)-1)
+}
+
+// generateVpnGateway 生成一端的VPN网关及其公网地址
+func generateVpnGateway(config renderContext, vpn crossCloudVpn, side vpnSide) string {
+	var gateway strings.Builder
+	switch side.Provider {
+	case "aws":
+		// 未启用子网路由表时VPN路由传播到VPC的主路由表，对端VPC通过数据源读取主路由表
+		// 启用子网路由表时由路由表生成器传播到每个子网路由表
+		routeTable := strings.TrimSuffix(side.VpcId, ".id") + ".main_route_table_id"
+		if side.Existing {
+			gateway.WriteString(fmt.Sprintf(`data "aws_vpc" "%s" {
+  id = %s
+}
+
+`, vpn.Label, side.VpcId))
+			routeTable = fmt.Sprintf("data.aws_vpc.%s.main_route_table_id", vpn.Label)
+		}
+		gateway.WriteString(fmt.Sprintf(`resource "aws_vpn_gateway" "%s" {
+  vpc_id = %s
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+`, vpn.Label, side.VpcId, hclStringContent(vpn.Name)))
+		if !side.SubnetRoutes {
+			gateway.WriteString(fmt.Sprintf(`resource "aws_vpn_gateway_route_propagation" "%s" {
+  vpn_gateway_id = aws_vpn_gateway.%s.id
+  route_table_id = %s
+}
+
+`, vpn.Label, vpn.Label, routeTable))
+		}
+
+	case "azure":
+		gateway.WriteString(fmt.Sprintf(`resource "azurerm_subnet" "%s_gateway" {
+  name                 = "GatewaySubnet"
+  resource_group_name  = %s
+  virtual_network_name = %s
+  address_prefixes     = [%s]
+}
+
+resource "azurerm_public_ip" "%s" {
+  name                = "%s-pip"
+  location            = %s
+  resource_group_name = %s
+  allocation_method   = "Static"
+  sku                 = "Standard"
+
+  tags = local.common_tags
+}
+
+resource "azurerm_virtual_network_gateway" "%s" {
+  name                = "%s-gateway"
+  location            = %s
+  resource_group_name = %s
+  type                = "Vpn"
+  vpn_type            = "RouteBased"
+  sku                 = "VpnGw1"
+  active_active       = false
+  enable_bgp          = false
+
+  ip_configuration {
+    name                          = "vnetGatewayConfig"
+    public_ip_address_id          = azurerm_public_ip.%s.id
+    private_ip_address_allocation = "Dynamic"
+    subnet_id                     = azurerm_subnet.%s_gateway.id
+  }
+
+  tags = local.common_tags
+}
+
+`, vpn.Label, side.AzureGroup, side.AzureVnet, azureGatewaySubnetCidr(config, side.VpcCidr),
+			vpn.Label, hclStringContent(vpn.Name), side.AzureLocation, side.AzureGroup,
+			vpn.Label, hclStringContent(vpn.Name), side.AzureLocation, side.AzureGroup, vpn.Label, vpn.Label))
+
+	case "alicloud":
+		vswitchLine := ""
+		if side.SubnetId != "" {
+			vswitchLine = fmt.Sprintf("\n  vswitch_id       = %s", side.SubnetId)
+		}
+		gateway.WriteString(fmt.Sprintf(`resource "alicloud_vpn_gateway" "%s" {
+  vpn_gateway_name = "%s"
+  vpc_id           = %s%s
+  bandwidth        = 10
+  enable_ipsec     = true
+  enable_ssl       = false
+  payment_type     = "PayAsYouGo"
+
+  tags = local.common_tags
+}
+
+`, vpn.Label, hclStringContent(vpn.Name), side.VpcId, vswitchLine))
+
+	case "huawei":
+		gateway.WriteString(fmt.Sprintf(`data "huaweicloud_vpn_gateway_availability_zones" "%s" {
+  flavor          = "professional1"
+  attachment_type = "vpc"
+}
+`, vpn.Label))
+		for i := 1; i <= 2; i++ {
+			gateway.WriteString(fmt.Sprintf(`
+resource "huaweicloud_vpc_eip" "%s_%d" {
+  publicip {
+    type = "5_bgp"
+  }
+
+  bandwidth {
+    name        = "%s-bandwidth-%d"
+    size        = 10
+    share_type  = "PER"
+    charge_mode = "traffic"
+  }
+
+  tags = local.common_tags
+}
+`, vpn.Label, i, hclStringContent(vpn.Name), i))
+		}
+		gateway.WriteString(fmt.Sprintf(`
+resource "huaweicloud_vpn_gateway" "%s" {
+  name               = "%s"
+  vpc_id             = %s
+  local_subnets      = ["%s"]
+  connect_subnet     = %s
+  availability_zones = slice(data.huaweicloud_vpn_gateway_availability_zones.%s.names, 0, 2)
+
+  eip1 {
+    id = huaweicloud_vpc_eip.%s_1.id
+  }
+
+  eip2 {
+    id = huaweicloud_vpc_eip.%s_2.id
+  }
+}
+
+`, vpn.Label, hclStringContent(vpn.Name), side.VpcId, side.VpcCidr, side.SubnetId, vpn.Label, vpn.Label, vpn.Label))
+
+	case "tencent":
+		gateway.WriteString(fmt.Sprintf(`resource "tencentcloud_vpn_gateway" "%s" {
+  name        = "%s"
+  vpc_id      = %s
+  bandwidth   = 10
+  charge_type = "POSTPAID_BY_HOUR"
+
+  tags = local.common_tags
+}
+
+`, vpn.Label, hclStringContent(vpn.Name), side.VpcId))
+	}
+	return gateway.String()
+}
+
+// generateVpnConnection 生成一端的对端网关、IPsec连接和到对端网段的路由
+// 两端统一使用IKEv2、AES-256、SHA-256和DH Group 14，保证协商参数一致
+// AWS VPN连接的两条隧道使用相同的预共享密钥和协商参数，对端切换到隧道2时不需要修改配置
+func generateVpnConnection(vpn crossCloudVpn, side, peer vpnSide, psk string) string {
+	peerAddress := vpnGatewayAddress(vpn, peer)
+
+	var connection strings.Builder
+	switch side.Provider {
+	case "aws":
+		connection.WriteString(fmt.Sprintf(`resource "aws_customer_gateway" "%s" {
+  bgp_asn    = 65000
+  ip_address = %s
+  type       = "ipsec.1"
+
+  tags = {
+    Name = "%s-%s"
+  }
+}
+
+resource "aws_vpn_connection" "%s" {
+  vpn_gateway_id        = aws_vpn_gateway.%s.id
+  customer_gateway_id   = aws_customer_gateway.%s.id
+  type                  = "ipsec.1"
+  static_routes_only    = true
+  tunnel1_preshared_key = %s
+  tunnel2_preshared_key = %s
+
+  tunnel1_ike_versions                 = ["ikev2"]
+  tunnel1_phase1_encryption_algorithms = ["AES256"]
+  tunnel1_phase1_integrity_algorithms  = ["SHA2-256"]
+  tunnel1_phase1_dh_group_numbers      = [14]
+  tunnel1_phase2_encryption_algorithms = ["AES256"]
+  tunnel1_phase2_integrity_algorithms  = ["SHA2-256"]
+  tunnel1_phase2_dh_group_numbers      = [14]
+
+  tunnel2_ike_versions                 = ["ikev2"]
+  tunnel2_phase1_encryption_algorithms = ["AES256"]
+  tunnel2_phase1_integrity_algorithms  = ["SHA2-256"]
+  tunnel2_phase1_dh_group_numbers      = [14]
+  tunnel2_phase2_encryption_algorithms = ["AES256"]
+  tunnel2_phase2_integrity_algorithms  = ["SHA2-256"]
+  tunnel2_phase2_dh_group_numbers      = [14]
+
+  tags = {
+    Name = "%s"
+  }
+}
+
+resource "aws_vpn_connection_route" "%s" {
+  destination_cidr_block = "%s"
+  vpn_connection_id      = aws_vpn_connection.%s.id
+}
+
+`, vpn.Label, peerAddress, hclStringContent(vpn.Name), peer.Provider, vpn.Label, vpn.Label, vpn.Label, psk, psk, hclStringContent(vpn.Name), vpn.Label, peer.VpcCidr, vpn.Label))
+
+	case "azure":
+		connection.WriteString(fmt.Sprintf(`resource "azurerm_local_network_gateway" "%s" {
+  name                = "%s-%s"
+  location            = %s
+  resource_group_name = %s
+  gateway_address     = %s
+  address_space       = ["%s"]
+
+  tags = local.common_tags
+}
+
+resource "azurerm_virtual_network_gateway_connection" "%s" {
+  name                       = "%s"
+  location                   = %s
+  resource_group_name        = %s
+  type                       = "IPsec"
+  connection_protocol        = "IKEv2"
+  virtual_network_gateway_id = azurerm_virtual_network_gateway.%s.id
+  local_network_gateway_id   = azurerm_local_network_gateway.%s.id
+  shared_key                 = %s
+
+  ipsec_policy {
+    dh_group         = "DHGroup14"
+    ike_encryption   = "AES256"
+    ike_integrity    = "SHA256"
+    ipsec_encryption = "AES256"
+    ipsec_integrity  = "SHA256"
+    pfs_group        = "PFS14"
+  }
+
+  tags = local.common_tags
+}
+
+`, vpn.Label, hclStringContent(vpn.Name), peer.Provider, side.AzureLocation, side.AzureGroup, peerAddress, peer.VpcCidr,
+			vpn.Label, hclStringContent(vpn.Name), side.AzureLocation, side.AzureGroup, vpn.Label, vpn.Label, psk))
+
+	case "alicloud":
+		// 对端为AWS时，AWS VPN连接的两条隧道各对应一个对端网关和IPsec连接，隧道1的路由优先，隧道1中断时切换到隧道2
+		tunnels := []vpnTunnel{{Label: vpn.Label, Name: vpn.Name, Address: peerAddress, Weight: 0}}
+		if peer.Provider == "aws" {
+			tunnels[0].Weight = 100
+			tunnels = append(tunnels, vpnTunnel{Label: vpn.Label + "_tunnel2", Name: vpn.Name + "-tunnel2", Address: fmt.Sprintf("aws_vpn_connection.%s.tunnel2_address", vpn.Label), Weight: 0})
+		}
+		for _, tunnel := range tunnels {
+			connection.WriteString(fmt.Sprintf(`resource "alicloud_vpn_customer_gateway" "%s" {
+  customer_gateway_name = "%s-%s"
+  ip_address            = %s
+}
+
+resource "alicloud_vpn_connection" "%s" {
+  vpn_connection_name = "%s"
+  vpn_gateway_id      = alicloud_vpn_gateway.%s.id
+  customer_gateway_id = alicloud_vpn_customer_gateway.%s.id
+  local_subnet        = ["%s"]
+  remote_subnet       = ["%s"]
+  effect_immediately  = true
+
+  ike_config {
+    ike_version  = "ikev2"
+    ike_enc_alg  = "aes256"
+    ike_auth_alg = "sha256"
+    ike_pfs      = "group14"
+    psk          = %s
+  }
+
+  ipsec_config {
+    ipsec_enc_alg  = "aes256"
+    ipsec_auth_alg = "sha256"
+    ipsec_pfs      = "group14"
+  }
+}
+
+resource "alicloud_vpn_route_entry" "%s" {
+  vpn_gateway_id = alicloud_vpn_gateway.%s.id
+  route_dest     = "%s"
+  next_hop       = alicloud_vpn_connection.%s.id
+  weight         = %d
+  publish_vpc    = true
+}
+
+`, tunnel.Label, hclStringContent(tunnel.Name), peer.Provider, tunnel.Address, tunnel.Label, hclStringContent(tunnel.Name), vpn.Label, tunnel.Label, side.VpcCidr, peer.VpcCidr, psk,
+				tunnel.Label, vpn.Label, peer.VpcCidr, tunnel.Label, tunnel.Weight))
+		}
+
+	case "huawei":
+		connection.WriteString(fmt.Sprintf(`resource "huaweicloud_vpn_customer_gateway" "%s" {
+  name     = "%s-%s"
+  id_value = %s
+}
+
+resource "huaweicloud_vpn_connection" "%s" {
+  name                = "%s"
+  gateway_id          = huaweicloud_vpn_gateway.%s.id
+  gateway_ip          = huaweicloud_vpc_eip.%s_1.id
+  customer_gateway_id = huaweicloud_vpn_customer_gateway.%s.id
+  peer_subnets        = ["%s"]
+  vpn_type            = "static"
+  psk                 = %s
+
+  ikepolicy {
+    ike_version              = "v2"
+    encryption_algorithm     = "aes-256"
+    authentication_algorithm = "sha2-256"
+    dh_group                 = "group14"
+  }
+
+  ipsecpolicy {
+    encryption_algorithm     = "aes-256"
+    authentication_algorithm = "sha2-256"
+    pfs                      = "group14"
+  }
+}
+
+`, vpn.Label, hclStringContent(vpn.Name), peer.Provider, peerAddress, vpn.Label, hclStringContent(vpn.Name), vpn.Label, vpn.Label, vpn.Label, peer.VpcCidr, psk))
+
+	case "tencent":
+		routeTable := strings.TrimSuffix(side.VpcId, ".id") + ".default_route_table_id"
+		if side.Existing {
+			connection.WriteString(fmt.Sprintf(`data "tencentcloud_vpc_route_tables" "%s" {
+  vpc_id           = %s
+  association_main = true
+}
+
+`, vpn.Label, side.VpcId))
+			routeTable = fmt.Sprintf("data.tencentcloud_vpc_route_tables.%s.instance_list[0].route_table_id", vpn.Label)
+		}
+
+		connection.WriteString(fmt.Sprintf(`resource "tencentcloud_vpn_customer_gateway" "%s" {
+  name              = "%s-%s"
+  public_ip_address = %s
+}
+
+resource "tencentcloud_vpn_connection" "%s" {
+  name                = "%s"
+  vpc_id              = %s
+  vpn_gateway_id      = tencentcloud_vpn_gateway.%s.id
+  customer_gateway_id = tencentcloud_vpn_customer_gateway.%s.id
+  pre_share_key       = %s
+
+  ike_version                = "IKEV2"
+  ike_proto_encry_algorithm  = "AES-CBC-256"
+  ike_proto_authen_algorithm = "SHA-256"
+  ike_dh_group_name          = "GROUP14"
+  ipsec_encrypt_algorithm    = "AES-CBC-256"
+  ipsec_integrity_algorithm  = "SHA-256"
+  ipsec_pfs_dh_group         = "DH-GROUP14"
+
+  security_group_policy {
+    local_cidr_block  = "%s"
+    remote_cidr_block = ["%s"]
+  }
+}
+
+`, vpn.Label, hclStringContent(vpn.Name), peer.Provider, peerAddress, vpn.Label, hclStringContent(vpn.Name), side.VpcId, vpn.Label, vpn.Label, psk,
+			side.VpcCidr, peer.VpcCidr))
+
+		// 启用子网路由表时由路由表生成器在每个子网路由表中添加到对端网段的路由
+		if !side.SubnetRoutes {
+			connection.WriteString(fmt.Sprintf(`resource "tencentcloud_route_table_entry" "%s" {
+  route_table_id         = %s
+  destination_cidr_block = "%s"
+  next_type              = "VPN"
+  next_hub               = tencentcloud_vpn_gateway.%s.id
+  description            = "Route to %s via VPN %s"
+}
+
+`, vpn.Label, routeTable, peer.VpcCidr, vpn.Label, peer.Provider, hclStringContent(vpn.Name)))
+		}
+	}
+	return connection.String()
+}
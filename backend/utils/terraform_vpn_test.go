@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCrossCloudVpnRoutes(t *testing.T) {
+	tests := []struct {
+		name        string
+		provider    string
+		region      string
+		routeTables bool
+		want        []string
+		notWant     []string
+	}{
+		{
+			name:     "aws main route table without subnet route tables",
+			provider: "aws",
+			region:   "us-east-1",
+			want: []string{
+				"route_table_id = aws_vpc.main.main_route_table_id",
+				"tunnel2_preshared_key = random_password.vpn_to_ali.result",
+				`tunnel2_ike_versions                 = ["ikev2"]`,
+			},
+		},
+		{
+			name:        "aws propagates to every subnet route table",
+			provider:    "aws",
+			region:      "us-east-1",
+			routeTables: true,
+			want: []string{
+				`resource "aws_vpn_gateway_route_propagation" "pub_vpn_to_ali"`,
+				`resource "aws_vpn_gateway_route_propagation" "app-a_vpn_to_ali"`,
+				`resource "aws_vpn_gateway_route_propagation" "app-b_vpn_to_ali"`,
+			},
+			notWant: []string{"main_route_table_id", `"other-a_vpn_to_ali"`},
+		},
+		{
+			name:        "tencent adds an entry to every subnet route table",
+			provider:    "tencent",
+			region:      "ap-guangzhou",
+			routeTables: true,
+			want: []string{
+				`resource "tencentcloud_route_table_entry" "pub_vpn_to_ali"`,
+				`resource "tencentcloud_route_table_entry" "app-b_vpn_to_ali"`,
+			},
+			notWant: []string{"default_route_table_id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig(tt.provider, tt.region))
+			config.ComponentConfig.EnableRouteTables = tt.routeTables
+			props := map[string]interface{}{
+				"name":            "to-ali",
+				"remote_provider": "alicloud",
+				"remote_region":   "cn-hangzhou",
+				"remote_vpc_id":   "vpc-123",
+				"remote_vpc_cidr": "172.16.0.0/16",
+			}
+			config.Components = []string{"cross-cloud-vpn"}
+			config.ComponentProperties = map[string]interface{}{"cross-cloud-vpn": props}
+
+			body := generateCrossCloudVpnConfig(config, props)
+			if tt.routeTables {
+				body += generateRoutingConfig(config)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("config should not contain %q:\n%s", notWant, body)
+				}
+			}
+		})
+	}
+}
+
+func TestCrossCloudVpnUserValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		props   map[string]interface{}
+		wantErr bool
+		want    []string
+	}{
+		{
+			name: "alicloud peers both aws tunnels",
+			props: map[string]interface{}{
+				"name":            "to-aws",
+				"remote_provider": "aws",
+				"remote_region":   "us-east-1",
+				"remote_vpc_id":   "vpc-123",
+				"remote_vpc_cidr": "172.16.0.0/16",
+			},
+			want: []string{
+				"ip_address            = aws_vpn_connection.vpn_to_aws.tunnel1_address",
+				"ip_address            = aws_vpn_connection.vpn_to_aws.tunnel2_address",
+				`resource "alicloud_vpn_connection" "vpn_to_aws_tunnel2"`,
+				"next_hop       = alicloud_vpn_connection.vpn_to_aws_tunnel2.id\n  weight         = 0",
+				"next_hop       = alicloud_vpn_connection.vpn_to_aws.id\n  weight         = 100",
+			},
+		},
+		{
+			name: "remote vpc id is escaped",
+			props: map[string]interface{}{
+				"name":            "to-aws",
+				"remote_provider": "aws",
+				"remote_region":   "us-east-1",
+				"remote_vpc_id":   `vpc-${file("/etc/passwd")}`,
+				"remote_vpc_cidr": "172.16.0.0/16",
+			},
+			want: []string{`id = "vpc-$${file(\"/etc/passwd\")}"`},
+		},
+		{
+			name: "unknown remote region",
+			props: map[string]interface{}{
+				"name":            "to-aws",
+				"remote_provider": "aws",
+				"remote_region":   `us-east-1" }`,
+				"remote_vpc_id":   "vpc-123",
+				"remote_vpc_cidr": "172.16.0.0/16",
+			},
+			wantErr: true,
+		},
+		{
+			name: "name outside charset",
+			props: map[string]interface{}{
+				"name":            `x" } resource "null_resource" "pwn" {`,
+				"remote_provider": "aws",
+				"remote_region":   "us-east-1",
+				"remote_vpc_id":   "vpc-123",
+				"remote_vpc_cidr": "172.16.0.0/16",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testRenderContext(testNetworkConfig("alicloud", "cn-hangzhou"))
+			config.Components = []string{"cross-cloud-vpn"}
+			config.ComponentProperties = map[string]interface{}{"cross-cloud-vpn": tt.props}
+
+			body := generateCrossCloudVpnConfig(config, tt.props)
+			if got := HasErrorFindings(config.findings.Findings()); got != tt.wantErr {
+				t.Fatalf("error findings = %v, want %v: %v", got, tt.wantErr, config.findings.Findings())
+			}
+			if tt.wantErr && body != "" {
+				t.Errorf("invalid connection should not be rendered:\n%s", body)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("config does not contain %q:\n%s", want, body)
+				}
+			}
+		})
+	}
+}